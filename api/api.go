@@ -1,8 +1,11 @@
 package api
 
 import (
+	systemControllers "github.com/duylamasd/hotels-merge/api/controllers/system"
 	v1Controllers "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
 	"github.com/duylamasd/hotels-merge/api/middlewares"
+	systemRoutes "github.com/duylamasd/hotels-merge/api/routes/system"
 	v1Routes "github.com/duylamasd/hotels-merge/api/routes/v1"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
@@ -10,19 +13,35 @@ import (
 
 func registerRoutes(
 	engine *gin.Engine,
+	systemRoutes *systemRoutes.SystemRoutes,
 	v1Routes *v1Routes.V1Routes,
+	tracingMiddleware *middlewares.TracingMiddleware,
+	requestIDMiddleware *middlewares.RequestIDMiddleware,
+	metricsMiddleware *middlewares.MetricsMiddleware,
 	errorHandler *middlewares.ErrorHandler,
+	rateLimitMiddleware *middlewares.RateLimitMiddleware,
 ) {
+	engine.Use(tracingMiddleware.Handler())
+	engine.Use(requestIDMiddleware.Handler())
+	engine.Use(metricsMiddleware.Handler())
 	engine.Use(errorHandler.Handler())
+	engine.NoRoute(func(c *gin.Context) {
+		_ = c.Error(apiDomains.NewHttpError(apiDomains.ErrCodeNotFound, "The requested route does not exist"))
+	})
 
-	api := engine.Group("/api")
+	systemRoutes.Register(&engine.RouterGroup)
+
+	// Operational endpoints stay outside the rate limit so probes and scrapes are never throttled.
+	api := engine.Group("/api", rateLimitMiddleware.Handler())
 	v1 := api.Group("/v1")
 	v1Routes.Register(v1)
 }
 
 var Module = fx.Options(
 	middlewares.Module,
+	systemControllers.Module,
 	v1Controllers.Module,
+	systemRoutes.Module,
 	v1Routes.Module,
 	fx.Invoke(registerRoutes),
 )
@@ -0,0 +1,7 @@
+package system
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewHealthController),
+)
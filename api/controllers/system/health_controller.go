@@ -0,0 +1,48 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/gin-gonic/gin"
+)
+
+type healthController struct {
+	service domains.HealthService
+}
+
+type HealthController interface {
+	Liveness(ctx *gin.Context)
+	Readiness(ctx *gin.Context)
+	Status(ctx *gin.Context)
+}
+
+func NewHealthController(service domains.HealthService) HealthController {
+	return &healthController{
+		service: service,
+	}
+}
+
+func (c *healthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": domains.HealthStatusOK})
+}
+
+func (c *healthController) Readiness(ctx *gin.Context) {
+	report := c.service.Readiness(ctx.Request.Context())
+	if !report.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (c *healthController) Status(ctx *gin.Context) {
+	report := c.service.Status(ctx.Request.Context())
+	if report.Status != domains.HealthStatusOK {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,107 @@
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/api/controllers/system"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealthService := mocks.NewMockHealthService(ctrl)
+	healthController := system.NewHealthController(mockHealthService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+	router.GET("/status", healthController.Status)
+
+	t.Run("should return 200 for liveness", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/healthz", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 200 when all readiness checks pass", func(t *testing.T) {
+		mockHealthService.EXPECT().Readiness(gomock.Any()).Return(&domains.ReadinessReport{
+			Status: domains.HealthStatusOK,
+			Checks: []domains.HealthCheck{{Name: "database", Status: domains.HealthStatusOK}},
+		}).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domains.ReadinessReport
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, domains.HealthStatusOK, response.Status)
+		assert.Len(t, response.Checks, 1)
+	})
+
+	t.Run("should return 503 when a readiness check fails", func(t *testing.T) {
+		mockHealthService.EXPECT().Readiness(gomock.Any()).Return(&domains.ReadinessReport{
+			Status: domains.HealthStatusFail,
+			Checks: []domains.HealthCheck{{Name: "database", Status: domains.HealthStatusFail, Detail: "connection refused"}},
+		}).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("should return 503 when draining", func(t *testing.T) {
+		mockHealthService.EXPECT().Readiness(gomock.Any()).Return(&domains.ReadinessReport{
+			Status: domains.HealthStatusDraining,
+		}).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("should return status report", func(t *testing.T) {
+		mockHealthService.EXPECT().Status(gomock.Any()).Return(&domains.StatusReport{
+			Status: domains.HealthStatusOK,
+			Build:  domains.BuildInfo{Version: "dev"},
+		}).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/status", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domains.StatusReport
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "dev", response.Build.Version)
+	})
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	v1Dto "github.com/duylamasd/hotels-merge/api/dto/v1"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type adminDestinationController struct {
+	logger  *zap.Logger
+	service domains.DestinationService
+}
+
+type AdminDestinationController interface {
+	Upsert(ctx *gin.Context)
+}
+
+func NewAdminDestinationController(
+	logger *zap.Logger,
+	service domains.DestinationService,
+) AdminDestinationController {
+	return &adminDestinationController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (c *adminDestinationController) Upsert(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.DestinationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+	var body v1Dto.UpsertDestinationDTO
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidBody, err))
+		return
+	}
+
+	logger.Info("PUT /api/v1/admin/destinations/:id - Storing destination", zap.String("destination_id", uri.ID))
+	destination, err := c.service.Upsert(ctx.Request.Context(), sqlc.UpsertDestinationParams{
+		ID:          uri.ID,
+		Name:        body.Name,
+		CountryCode: body.CountryCode,
+		Latitude:    body.Latitude,
+		Longitude:   body.Longitude,
+		Timezone:    body.Timezone,
+	})
+	switch {
+	case errors.Is(err, domains.ErrInvalidDestination):
+		_ = ctx.Error(apiDomains.NewHttpError(apiDomains.ErrCodeInvalidBody, err.Error()))
+		return
+	case err != nil:
+		logger.Error("Could not store destination", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not store destination. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, destination)
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	v1Dto "github.com/duylamasd/hotels-merge/api/dto/v1"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type adminDuplicateController struct {
+	logger  *zap.Logger
+	service domains.DuplicateService
+}
+
+type AdminDuplicateController interface {
+	Detect(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Confirm(ctx *gin.Context)
+	Reject(ctx *gin.Context)
+}
+
+func NewAdminDuplicateController(
+	logger *zap.Logger,
+	service domains.DuplicateService,
+) AdminDuplicateController {
+	return &adminDuplicateController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (c *adminDuplicateController) Detect(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	logger.Info("POST /api/v1/admin/duplicates:detect - Detecting duplicate hotels")
+	result, err := c.service.Detect(ctx.Request.Context())
+	if err != nil {
+		logger.Error("Could not detect duplicate hotels", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not detect duplicate hotels. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c *adminDuplicateController) List(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var query v1Dto.ListDuplicatesQueryDTO
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("GET /api/v1/admin/duplicates - Listing duplicate candidates", zap.String("status", query.StatusOrDefault()))
+	duplicates, err := c.service.List(ctx.Request.Context(), query.StatusOrDefault())
+	if err != nil {
+		logger.Error("Could not list duplicate candidates", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not list duplicate candidates. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, duplicates)
+}
+
+func (c *adminDuplicateController) Confirm(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.DuplicateURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+	var body v1Dto.ConfirmDuplicateDTO
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidBody, err))
+		return
+	}
+
+	logger.Info("POST /api/v1/admin/duplicates/:id:confirm - Merging duplicate hotels",
+		zap.Int32("id", uri.ID),
+		zap.String("canonical_hotel_id", body.CanonicalHotelID),
+	)
+	duplicate, err := c.service.Confirm(ctx.Request.Context(), uri.ID, body.CanonicalHotelID)
+	c.respond(ctx, logger, duplicate, err)
+}
+
+func (c *adminDuplicateController) Reject(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.DuplicateURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("POST /api/v1/admin/duplicates/:id:reject - Rejecting duplicate hotels", zap.Int32("id", uri.ID))
+	duplicate, err := c.service.Reject(ctx.Request.Context(), uri.ID)
+	c.respond(ctx, logger, duplicate, err)
+}
+
+func (c *adminDuplicateController) respond(ctx *gin.Context, logger *zap.Logger, duplicate *sqlc.HotelDuplicate, err error) {
+	switch {
+	case errors.Is(err, domains.ErrDuplicateDecided):
+		_ = ctx.Error(apiDomains.NewHttpError(apiDomains.ErrCodeConflict, "The duplicate candidate is already confirmed or rejected"))
+		return
+	case errors.Is(err, domains.ErrCanonicalHotel):
+		_ = ctx.Error(apiDomains.NewHttpError(apiDomains.ErrCodeInvalidBody, "canonical_hotel_id must be one of the hotels of the duplicate candidate"))
+		return
+	case err != nil:
+		logger.Error("Could not decide duplicate candidate", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not decide duplicate candidate. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, duplicate)
+}
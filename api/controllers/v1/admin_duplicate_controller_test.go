@@ -0,0 +1,138 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAdminDuplicateController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockDuplicateService := mocks.NewMockDuplicateService(ctrl)
+	adminDuplicateController := v1.NewAdminDuplicateController(logger, mockDuplicateService)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.POST("/api/v1/admin/duplicates/detect", adminDuplicateController.Detect)
+	router.GET("/api/v1/admin/duplicates", adminDuplicateController.List)
+	router.POST("/api/v1/admin/duplicates/:id/confirm", adminDuplicateController.Confirm)
+	router.POST("/api/v1/admin/duplicates/:id/reject", adminDuplicateController.Reject)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+	problemCode := func(t *testing.T, w *httptest.ResponseRecorder) apiDomains.ErrorCode {
+		var problem apiDomains.HttpError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		return problem.Code
+	}
+
+	t.Run("should return the detected candidates", func(t *testing.T) {
+		mockDuplicateService.EXPECT().Detect(gomock.Any()).Return(&domains.DetectDuplicatesResult{
+			Candidates: 1,
+			Clusters:   [][]string{{"SjyX", "iJhz"}},
+		}, nil)
+
+		w := request("POST", "/api/v1/admin/duplicates/detect", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result domains.DetectDuplicatesResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, 1, result.Candidates)
+		assert.Equal(t, [][]string{{"SjyX", "iJhz"}}, result.Clusters)
+	})
+
+	t.Run("should list pending candidates by default", func(t *testing.T) {
+		mockDuplicateService.EXPECT().List(gomock.Any(), domains.DuplicatePending).Return([]*sqlc.HotelDuplicate{}, nil)
+
+		w := request("GET", "/api/v1/admin/duplicates", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("should return 400 for an unknown status", func(t *testing.T) {
+		w := request("GET", "/api/v1/admin/duplicates?status=merged", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidQuery, problemCode(t, w))
+	})
+
+	t.Run("should confirm a candidate", func(t *testing.T) {
+		canonical := "iJhz"
+		mockDuplicateService.EXPECT().Confirm(gomock.Any(), int32(7), "iJhz").Return(&sqlc.HotelDuplicate{
+			ID:               7,
+			HotelID:          "SjyX",
+			DuplicateHotelID: "iJhz",
+			Status:           domains.DuplicateConfirmed,
+			CanonicalHotelID: &canonical,
+		}, nil)
+
+		w := request("POST", "/api/v1/admin/duplicates/7/confirm", `{"canonical_hotel_id":"iJhz"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var duplicate sqlc.HotelDuplicate
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &duplicate))
+		assert.Equal(t, domains.DuplicateConfirmed, duplicate.Status)
+	})
+
+	t.Run("should return 400 without a canonical hotel id", func(t *testing.T) {
+		w := request("POST", "/api/v1/admin/duplicates/7/confirm", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+	})
+
+	t.Run("should return 400 for a canonical hotel outside the pair", func(t *testing.T) {
+		mockDuplicateService.EXPECT().Confirm(gomock.Any(), int32(7), "f8c9").Return(nil, domains.ErrCanonicalHotel)
+
+		w := request("POST", "/api/v1/admin/duplicates/7/confirm", `{"canonical_hotel_id":"f8c9"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+	})
+
+	t.Run("should return 409 for a decided candidate", func(t *testing.T) {
+		mockDuplicateService.EXPECT().Confirm(gomock.Any(), int32(7), "iJhz").Return(nil, domains.ErrDuplicateDecided)
+
+		w := request("POST", "/api/v1/admin/duplicates/7/confirm", `{"canonical_hotel_id":"iJhz"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeConflict, problemCode(t, w))
+	})
+
+	t.Run("should return 404 for a missing candidate", func(t *testing.T) {
+		mockDuplicateService.EXPECT().Reject(gomock.Any(), int32(999)).Return(nil, pgx.ErrNoRows)
+
+		w := request("POST", "/api/v1/admin/duplicates/999/reject", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 400 for an invalid id", func(t *testing.T) {
+		w := request("POST", "/api/v1/admin/duplicates/abc/reject", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxImportBodySize bounds the NDJSON body of an import.
+const maxImportBodySize = 64 << 20
+
+type adminHotelController struct {
+	logger  *zap.Logger
+	service domains.HotelDataService
+}
+
+type AdminHotelController interface {
+	Import(ctx *gin.Context)
+	Quality(ctx *gin.Context)
+}
+
+func NewAdminHotelController(
+	logger *zap.Logger,
+	service domains.HotelDataService,
+) AdminHotelController {
+	return &adminHotelController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (c *adminHotelController) Import(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	logger.Info("POST /api/v1/admin/hotels:import - Importing hotels")
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBodySize)
+	result, err := c.service.Import(ctx.Request.Context(), body)
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		e := apiDomains.NewHttpError(apiDomains.ErrCodeBodyTooLarge, fmt.Sprintf("The import must not exceed %d bytes", maxImportBodySize))
+		_ = ctx.Error(e)
+		return
+	case err != nil:
+		logger.Error("Could not import hotels", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not import hotels. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		detail := fmt.Sprintf("%d invalid record(s), nothing was imported", len(result.Errors))
+		_ = ctx.Error(apiDomains.NewRecordsError(detail, result.Errors))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c *adminHotelController) Quality(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	logger.Info("GET /api/v1/admin/quality - Reporting hotel data quality")
+	report, err := c.service.Quality(ctx.Request.Context())
+	if err != nil {
+		logger.Error("Could not report hotel data quality", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not report hotel data quality. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,138 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAdminHotelController_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockHotelDataService := mocks.NewMockHotelDataService(ctrl)
+	adminHotelController := v1.NewAdminHotelController(logger, mockHotelDataService)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.POST("/api/v1/admin/hotels/import", adminHotelController.Import)
+
+	request := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/hotels/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should return the import counts", func(t *testing.T) {
+		body := `{"hotel_id":"a","destination_id":"1","name":"Hotel A"}` + "\n"
+		mockHotelDataService.EXPECT().Import(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, r io.Reader) (*domains.ImportResult, error) {
+			read, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, body, string(read))
+			return &domains.ImportResult{Imported: 1, Inserted: 1, Errors: []domains.DataIssue{}}, nil
+		})
+
+		w := request(body)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result domains.ImportResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 1, result.Inserted)
+	})
+
+	t.Run("should return 422 with the invalid lines", func(t *testing.T) {
+		mockHotelDataService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(&domains.ImportResult{Errors: []domains.DataIssue{
+			{HotelID: "a", Line: 2, Field: "name", Message: "is required"},
+		}}, nil)
+
+		w := request("{}")
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var problem apiDomains.HttpError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apiDomains.ErrCodeInvalidRecords, problem.Code)
+		require.Len(t, problem.Records, 1)
+		assert.Equal(t, 2, problem.Records[0].Line)
+		assert.Equal(t, "name", problem.Records[0].Field)
+	})
+
+	t.Run("should return 500 when the import fails", func(t *testing.T) {
+		mockHotelDataService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil, errors.New("copy failed"))
+
+		w := request("{}")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestAdminHotelController_Quality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockHotelDataService := mocks.NewMockHotelDataService(ctrl)
+	adminHotelController := v1.NewAdminHotelController(logger, mockHotelDataService)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.GET("/api/v1/admin/quality", adminHotelController.Quality)
+
+	request := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/quality", nil)
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should return the quality report", func(t *testing.T) {
+		mockHotelDataService.EXPECT().Quality(gomock.Any()).Return(&domains.QualityReport{
+			Overall:      domains.QualityStats{Hotels: 2, AverageScore: 62.5, MinScore: 45, MaxScore: 80},
+			Destinations: map[string]*domains.QualityStats{"5432": {Hotels: 2, AverageScore: 62.5}},
+			Suppliers:    map[string]*domains.QualityStats{"acme": {Hotels: 2, AverageScore: 62.5}},
+		}, nil)
+
+		w := request()
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var report domains.QualityReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 2, report.Overall.Hotels)
+		assert.Equal(t, 62.5, report.Destinations["5432"].AverageScore)
+		assert.Equal(t, 2, report.Suppliers["acme"].Hotels)
+	})
+
+	t.Run("should return 500 when the report fails", func(t *testing.T) {
+		mockHotelDataService.EXPECT().Quality(gomock.Any()).Return(nil, errors.New("query failed"))
+
+		w := request()
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
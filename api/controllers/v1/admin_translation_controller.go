@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	v1Dto "github.com/duylamasd/hotels-merge/api/dto/v1"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type adminTranslationController struct {
+	logger  *zap.Logger
+	service domains.TranslationService
+}
+
+type AdminTranslationController interface {
+	List(ctx *gin.Context)
+	Upsert(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+func NewAdminTranslationController(
+	logger *zap.Logger,
+	service domains.TranslationService,
+) AdminTranslationController {
+	return &adminTranslationController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (c *adminTranslationController) List(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.TranslationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("GET /api/v1/admin/hotels/:hotel_id/translations - Listing hotel translations", zap.String("hotel_id", uri.HotelID))
+	translations, err := c.service.List(ctx.Request.Context(), uri.HotelID)
+	if err != nil {
+		logger.Error("Could not list hotel translations", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not list hotel translations. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, translations)
+}
+
+func (c *adminTranslationController) Upsert(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.TranslationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+	var body v1Dto.UpsertTranslationDTO
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidBody, err))
+		return
+	}
+
+	logger.Info("PUT /api/v1/admin/hotels/:hotel_id/translations/:locale - Storing hotel translation",
+		zap.String("hotel_id", uri.HotelID),
+		zap.String("locale", uri.Locale),
+	)
+	translation, err := c.service.Upsert(ctx.Request.Context(), sqlc.UpsertHotelTranslationParams{
+		HotelID:           uri.HotelID,
+		Locale:            uri.Locale,
+		Name:              body.Name,
+		Description:       body.Description,
+		AmenityLabels:     body.AmenityLabels,
+		BookingConditions: body.BookingConditions,
+	})
+	if err != nil {
+		c.fail(ctx, logger, err, "Could not store hotel translation. Please retry again")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, translation)
+}
+
+func (c *adminTranslationController) Delete(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.TranslationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("DELETE /api/v1/admin/hotels/:hotel_id/translations/:locale - Deleting hotel translation",
+		zap.String("hotel_id", uri.HotelID),
+		zap.String("locale", uri.Locale),
+	)
+	if err := c.service.Delete(ctx.Request.Context(), uri.HotelID, uri.Locale); err != nil {
+		c.fail(ctx, logger, err, "Could not delete hotel translation. Please retry again")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *adminTranslationController) fail(ctx *gin.Context, logger *zap.Logger, err error, detail string) {
+	switch {
+	case errors.Is(err, domains.ErrInvalidLocale):
+		_ = ctx.Error(apiDomains.NewHttpError(apiDomains.ErrCodeInvalidQuery, err.Error()))
+	case errors.Is(err, domains.ErrUnknownAmenity):
+		_ = ctx.Error(apiDomains.NewHttpError(apiDomains.ErrCodeInvalidBody, err.Error()))
+	default:
+		logger.Error("Could not change hotel translation", zap.Error(err))
+		_ = ctx.Error(apiDomains.FromError(err, detail))
+	}
+}
@@ -0,0 +1,142 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAdminTranslationController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	adminTranslationController := v1.NewAdminTranslationController(logger, mockTranslationService)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.GET("/api/v1/admin/hotels/:hotel_id/translations", adminTranslationController.List)
+	router.PUT("/api/v1/admin/hotels/:hotel_id/translations/:locale", adminTranslationController.Upsert)
+	router.DELETE("/api/v1/admin/hotels/:hotel_id/translations/:locale", adminTranslationController.Delete)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+	problemCode := func(t *testing.T, w *httptest.ResponseRecorder) apiDomains.ErrorCode {
+		var problem apiDomains.HttpError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		return problem.Code
+	}
+
+	name := "ビーチヴィラ"
+	translation := &sqlc.HotelTranslation{
+		HotelID:       "iJhz",
+		Locale:        "ja",
+		Name:          &name,
+		AmenityLabels: dto.AmenityLabels{"pool": "プール"},
+	}
+
+	t.Run("should list the translations of a hotel", func(t *testing.T) {
+		mockTranslationService.EXPECT().List(gomock.Any(), "iJhz").Return([]*sqlc.HotelTranslation{translation}, nil)
+
+		w := request("GET", "/api/v1/admin/hotels/iJhz/translations", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []*sqlc.HotelTranslation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []*sqlc.HotelTranslation{translation}, response)
+	})
+
+	t.Run("should return 404 for an unknown hotel", func(t *testing.T) {
+		mockTranslationService.EXPECT().List(gomock.Any(), "unknown").Return(nil, pgx.ErrNoRows)
+
+		w := request("GET", "/api/v1/admin/hotels/unknown/translations", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should store a translation", func(t *testing.T) {
+		mockTranslationService.EXPECT().Upsert(gomock.Any(), sqlc.UpsertHotelTranslationParams{
+			HotelID:       "iJhz",
+			Locale:        "ja",
+			Name:          &name,
+			AmenityLabels: dto.AmenityLabels{"pool": "プール"},
+		}).Return(translation, nil)
+
+		w := request("PUT", "/api/v1/admin/hotels/iJhz/translations/ja", `{"name":"ビーチヴィラ","amenity_labels":{"pool":"プール"}}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response sqlc.HotelTranslation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "ja", response.Locale)
+	})
+
+	t.Run("should return 400 for an invalid locale", func(t *testing.T) {
+		mockTranslationService.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil, domains.ErrInvalidLocale)
+
+		w := request("PUT", "/api/v1/admin/hotels/iJhz/translations/en", `{"name":"Beach Villas"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidQuery, problemCode(t, w))
+	})
+
+	t.Run("should return 400 for an unknown amenity", func(t *testing.T) {
+		mockTranslationService.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w %q", domains.ErrUnknownAmenity, "moat"))
+
+		w := request("PUT", "/api/v1/admin/hotels/iJhz/translations/ja", `{"amenity_labels":{"moat":"堀"}}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+	})
+
+	t.Run("should return 400 for an empty amenity label", func(t *testing.T) {
+		w := request("PUT", "/api/v1/admin/hotels/iJhz/translations/ja", `{"amenity_labels":{"pool":""}}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+	})
+
+	t.Run("should delete a translation", func(t *testing.T) {
+		mockTranslationService.EXPECT().Delete(gomock.Any(), "iJhz", "ja").Return(nil)
+
+		w := request("DELETE", "/api/v1/admin/hotels/iJhz/translations/ja", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 404 for a missing translation", func(t *testing.T) {
+		mockTranslationService.EXPECT().Delete(gomock.Any(), "iJhz", "fr").Return(pgx.ErrNoRows)
+
+		w := request("DELETE", "/api/v1/admin/hotels/iJhz/translations/fr", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -4,4 +4,9 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewHotelController),
+	fx.Provide(NewAdminHotelController),
+	fx.Provide(NewAdminDuplicateController),
+	fx.Provide(NewAdminTranslationController),
+	fx.Provide(NewDestinationController),
+	fx.Provide(NewAdminDestinationController),
 )
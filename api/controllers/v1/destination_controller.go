@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"net/http"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	v1Dto "github.com/duylamasd/hotels-merge/api/dto/v1"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type destinationController struct {
+	logger  *zap.Logger
+	service domains.DestinationService
+}
+
+type DestinationController interface {
+	List(ctx *gin.Context)
+	Find(ctx *gin.Context)
+}
+
+func NewDestinationController(
+	logger *zap.Logger,
+	service domains.DestinationService,
+) DestinationController {
+	return &destinationController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (c *destinationController) List(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var query v1Dto.ListDestinationsQueryDTO
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("GET /api/v1/destinations - Listing destinations",
+		zap.Int32("limit", query.LimitOrDefault()),
+		zap.Int32("offset", query.Offset),
+	)
+	page, err := c.service.List(ctx.Request.Context(), query.LimitOrDefault(), query.Offset)
+	if err != nil {
+		logger.Error("Could not list destinations", zap.Error(err))
+		e := apiDomains.FromError(err, "Could not list destinations. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (c *destinationController) Find(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.DestinationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+
+	logger.Info("GET /api/v1/destinations/:id - Finding destination", zap.String("destination_id", uri.ID))
+	destination, err := c.service.Find(ctx.Request.Context(), uri.ID)
+	if err != nil {
+		logger.Error("Could not find destination", zap.String("destination_id", uri.ID), zap.Error(err))
+		e := apiDomains.FromError(err, "Could not find destination. Please retry again")
+		_ = ctx.Error(e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, destination)
+}
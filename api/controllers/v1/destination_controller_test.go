@@ -0,0 +1,147 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestDestinationController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockDestinationService := mocks.NewMockDestinationService(ctrl)
+	destinationController := v1.NewDestinationController(logger, mockDestinationService)
+	adminDestinationController := v1.NewAdminDestinationController(logger, mockDestinationService)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.GET("/api/v1/destinations", destinationController.List)
+	router.GET("/api/v1/destinations/:id", destinationController.Find)
+	router.PUT("/api/v1/admin/destinations/:id", adminDestinationController.Upsert)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+	problemCode := func(t *testing.T, w *httptest.ResponseRecorder) apiDomains.ErrorCode {
+		var problem apiDomains.HttpError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		return problem.Code
+	}
+
+	countryCode := "SG"
+	singapore := &sqlc.FindDestinationRow{ID: "5432", Name: "Singapore", CountryCode: &countryCode, HotelCount: 3}
+
+	t.Run("should list a page of destinations, 20 by default", func(t *testing.T) {
+		mockDestinationService.EXPECT().List(gomock.Any(), int32(20), int32(0)).Return(&domains.DestinationPage{
+			Destinations: []*sqlc.ListDestinationsRow{(*sqlc.ListDestinationsRow)(singapore)},
+			Total:        1,
+			Limit:        20,
+		}, nil)
+
+		w := request("GET", "/api/v1/destinations", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response domains.DestinationPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.EqualValues(t, 1, response.Total)
+		require.Len(t, response.Destinations, 1)
+		assert.EqualValues(t, 3, response.Destinations[0].HotelCount)
+	})
+
+	t.Run("should pass limit and offset", func(t *testing.T) {
+		mockDestinationService.EXPECT().List(gomock.Any(), int32(5), int32(10)).Return(&domains.DestinationPage{
+			Destinations: []*sqlc.ListDestinationsRow{},
+			Total:        1,
+			Limit:        5,
+			Offset:       10,
+		}, nil)
+
+		w := request("GET", "/api/v1/destinations?limit=5&offset=10", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 for a limit above 100 or a negative offset", func(t *testing.T) {
+		for _, query := range []string{"limit=101", "limit=0", "offset=-1"} {
+			w := request("GET", "/api/v1/destinations?"+query, "")
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Equal(t, apiDomains.ErrCodeInvalidQuery, problemCode(t, w))
+		}
+	})
+
+	t.Run("should find a destination", func(t *testing.T) {
+		mockDestinationService.EXPECT().Find(gomock.Any(), "5432").Return(singapore, nil)
+
+		w := request("GET", "/api/v1/destinations/5432", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response sqlc.FindDestinationRow
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Singapore", response.Name)
+	})
+
+	t.Run("should return 404 for an unknown destination", func(t *testing.T) {
+		mockDestinationService.EXPECT().Find(gomock.Any(), "unknown").Return(nil, pgx.ErrNoRows)
+
+		w := request("GET", "/api/v1/destinations/unknown", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should store a destination", func(t *testing.T) {
+		timezone := "Asia/Singapore"
+		mockDestinationService.EXPECT().Upsert(gomock.Any(), sqlc.UpsertDestinationParams{
+			ID:          "5432",
+			Name:        "Singapore",
+			CountryCode: &countryCode,
+			Timezone:    &timezone,
+		}).Return(singapore, nil)
+
+		w := request("PUT", "/api/v1/admin/destinations/5432", `{"name":"Singapore","country_code":"SG","timezone":"Asia/Singapore"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 for an invalid destination", func(t *testing.T) {
+		for _, body := range []string{`{}`, `{"name":"Singapore","latitude":1.3}`, `{"name":"Singapore","country_code":"SGP"}`} {
+			w := request("PUT", "/api/v1/admin/destinations/5432", body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+			assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+		}
+
+		mockDestinationService.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil, domains.ErrInvalidDestination)
+		w := request("PUT", "/api/v1/admin/destinations/5432", `{"name":"Singapore","timezone":"Asia/Atlantis"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, apiDomains.ErrCodeInvalidBody, problemCode(t, w))
+	})
+}
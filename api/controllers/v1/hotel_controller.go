@@ -2,73 +2,174 @@ package v1
 
 import (
 	"net/http"
+	"strings"
 
 	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
 	v1Dto "github.com/duylamasd/hotels-merge/api/dto/v1"
 	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services/i18n"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type hotelController struct {
-	logger  *zap.Logger
-	service domains.HotelService
+	logger       *zap.Logger
+	service      domains.HotelService
+	translations domains.TranslationService
 }
 
 type HotelController interface {
 	Find(ctx *gin.Context)
+	// FindByDestination finds the hotels of the destination in the path, like Find with its destination_id.
+	FindByDestination(ctx *gin.Context)
 }
 
 func NewHotelController(
 	logger *zap.Logger,
 	service domains.HotelService,
+	translations domains.TranslationService,
 ) HotelController {
 	return &hotelController{
-		logger:  logger,
-		service: service,
+		logger:       logger,
+		service:      service,
+		translations: translations,
 	}
 }
 
 func (c *hotelController) Find(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
 	var query v1Dto.FindHotelsQueryDTO
-	c.logger.Info("GET /api/v1/hotels - Validating query params")
+	logger.Info("GET /api/v1/hotels - Validating query params")
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		c.logger.Error(err.Error())
-		e := apiDomains.NewHttpError(http.StatusBadRequest, err.Error())
+		logger.Error(err.Error())
+		e := apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err)
 		_ = ctx.Error(e)
 		return
 	}
 
-	c.logger.Info("GET /api/v1/hotels - Validating either destination id or hotel ids is available")
-	if query.DestinationID == nil && query.HotelIDs == nil {
-		c.logger.Error("Either destination or hotel ids was not provided")
-		e := apiDomains.NewHttpError(http.StatusBadRequest, "Either destination or list of hotel ids need to be provided")
+	c.find(ctx, logger, query)
+}
+
+func (c *hotelController) FindByDestination(ctx *gin.Context) {
+	logger := lib.LoggerFromContext(ctx.Request.Context(), c.logger)
+
+	var uri v1Dto.DestinationURIDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err))
+		return
+	}
+	var query v1Dto.FindHotelsQueryDTO
+	logger.Info("GET /api/v1/destinations/:id/hotels - Validating query params")
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Error(err.Error())
+		e := apiDomains.NewValidationError(apiDomains.ErrCodeInvalidQuery, err)
 		_ = ctx.Error(e)
 		return
 	}
+	query.DestinationID = &uri.ID
+
+	c.find(ctx, logger, query)
+}
+
+func (c *hotelController) find(ctx *gin.Context, logger *zap.Logger, query v1Dto.FindHotelsQueryDTO) {
+	logger.Info("GET /api/v1/hotels - Validating either destination id, hotel ids or country code is available")
+	if query.DestinationID == nil && query.HotelIDs == nil && query.CountryCode == nil {
+		logger.Error("Neither destination, hotel ids nor country code was provided")
+		e := apiDomains.NewHttpError(apiDomains.ErrCodeMissingFilter, "Either destination, list of hotel ids or country code need to be provided")
+		_ = ctx.Error(e)
+		return
+	}
+
+	filter := query.Filter()
+	if query.DestinationID == nil && query.HotelIDs == nil {
+		logger.Info("GET /api/v1/hotels - Finding hotels by country code", zap.String("country_code", *filter.CountryCode))
+		hotels, err := c.service.FindByCountryCode(ctx.Request.Context(), *filter.CountryCode)
+		if err != nil {
+			logger.Error("Could not fetch list of hotels by country code due to connectivity issue", zap.String("country_code", *filter.CountryCode), zap.Error(err))
+			e := apiDomains.FromError(err, "Could not fetch list of hotels. Please retry again")
+			_ = ctx.Error(e)
+			return
+		}
+
+		c.respond(ctx, logger, query, filter.Apply(hotels))
+		return
+	}
 
 	if query.DestinationID != nil {
-		c.logger.Info("GET /api/v1/hotels - Finding hotels by destination id", zap.String("destination_id", *query.DestinationID))
-		hotels, err := c.service.FindByDestinationID(ctx, *query.DestinationID)
+		logger.Info("GET /api/v1/hotels - Finding hotels by destination id", zap.String("destination_id", *query.DestinationID))
+		hotels, err := c.service.FindByDestinationID(ctx.Request.Context(), *query.DestinationID)
 		if err != nil {
-			c.logger.Error("Could not fetch list of hotels by destination due to connectivity issue", zap.String("destination_id", *query.DestinationID))
-			e := apiDomains.NewHttpError(http.StatusInternalServerError, "Could not fetch list of hotels. Please retry again")
+			logger.Error("Could not fetch list of hotels by destination due to connectivity issue", zap.String("destination_id", *query.DestinationID), zap.Error(err))
+			e := apiDomains.FromError(err, "Could not fetch list of hotels. Please retry again")
 			_ = ctx.Error(e)
 			return
 		}
 
-		ctx.JSON(http.StatusOK, hotels)
+		c.respond(ctx, logger, query, filter.Apply(hotels))
+		return
+	}
+
+	logger.Info("GET /api/v1/hotels - Finding hotels by hotel ids", zap.Strings("hotel_ids", *query.HotelIDs))
+	hotels, err := c.service.FindByHotelIDs(ctx.Request.Context(), *query.HotelIDs)
+	if err != nil {
+		logger.Error("Could not fetch list of hotels by list of hotel ids due to connectivity issue", zap.Strings("hotel_ids", *query.HotelIDs), zap.Error(err))
+		e := apiDomains.FromError(err, "Could not fetch list of hotels. Please retry again")
+		_ = ctx.Error(e)
 		return
 	}
 
-	c.logger.Info("GET /api/v1/hotels - Finding hotels by hotel ids", zap.Strings("hotel_ids", *query.HotelIDs))
-	hotels, err := c.service.FindByHotelIDs(ctx, *query.HotelIDs)
+	c.respond(ctx, logger, query, filter.Apply(hotels))
+}
+
+// respond translates the hotels to the requested language and renders them in the requested format.
+// Content-Language lists the locales served.
+func (c *hotelController) respond(ctx *gin.Context, logger *zap.Logger, query v1Dto.FindHotelsQueryDTO, hotels []*sqlc.Hotel) {
+	chain := i18n.Chain(query.Lang, ctx.GetHeader("Accept-Language"))
+	localized, locales, err := c.translations.Localize(ctx.Request.Context(), hotels, chain)
 	if err != nil {
-		c.logger.Error("Could not fetch list of hotels by list of hotel ids due to connectivity issue", zap.Strings("hotel_ids", *query.HotelIDs))
-		e := apiDomains.NewHttpError(http.StatusInternalServerError, "Could not fetch list of hotels. Please retry again")
+		logger.Error("Could not translate hotels due to connectivity issue", zap.Strings("locales", chain), zap.Error(err))
+		e := apiDomains.FromError(err, "Could not fetch list of hotels. Please retry again")
 		_ = ctx.Error(e)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, hotels)
+	ctx.Header("Vary", "Accept-Language")
+	if len(locales) > 0 {
+		ctx.Header("Content-Language", strings.Join(locales, ", "))
+	}
+	ctx.JSON(http.StatusOK, render(localized, query.FormatOrDefault()))
+}
+
+// publicHotel is a hotel as served by the API. Its RawText shadows the one of the hotel and is never set, as the
+// text as the supplier wrote it is only served in exports.
+type publicHotel struct {
+	*sqlc.Hotel
+	RawText *dto.RawText `json:"raw_text,omitempty"`
+}
+
+// render returns the hotels with their description and booking conditions in format and without their raw text.
+// Hotels are stored with safe HTML, and plain text is rendered on copies as the hotels may be shared with the cache.
+func render(hotels []*sqlc.Hotel, format string) []publicHotel {
+	rendered := make([]publicHotel, len(hotels))
+	for i, hotel := range hotels {
+		if format == sanitize.FormatHTML {
+			rendered[i] = publicHotel{Hotel: hotel}
+			continue
+		}
+
+		plain := *hotel
+		if hotel.Description != nil {
+			description := sanitize.Text(*hotel.Description)
+			plain.Description = &description
+		}
+		plain.BookingConditions = sanitize.Texts(hotel.BookingConditions)
+		rendered[i] = publicHotel{Hotel: &plain}
+	}
+
+	return rendered
 }
@@ -1,6 +1,7 @@
 package v1_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -20,6 +21,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 )
 
 func createMockLocation() *dto.HotelLocation {
@@ -42,9 +44,10 @@ func TestHotelController_Find(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	logger, _ := lib.NewLogger(&config.Config{LogLevel: "info"})
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
 	mockHotelService := mocks.NewMockHotelService(ctrl)
-	hotelController := v1.NewHotelController(logger, mockHotelService)
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	hotelController := v1.NewHotelController(logger, mockHotelService, mockTranslationService)
 	errorHandler := middlewares.NewErrorHandler(logger)
 
 	gin.SetMode(gin.TestMode)
@@ -55,6 +58,14 @@ func TestHotelController_Find(t *testing.T) {
 	api := router.Group("/api/v1")
 	hotels := api.Group("/hotels")
 	hotels.GET("", hotelController.Find)
+	api.GET("/destinations/:id/hotels", hotelController.FindByDestination)
+
+	mockTranslationService.EXPECT().
+		Localize(gomock.Any(), gomock.Any(), []string{"en"}).
+		DoAndReturn(func(_ context.Context, hotels []*sqlc.Hotel, _ []string) ([]*sqlc.Hotel, []string, error) {
+			return hotels, []string{"en"}, nil
+		}).
+		AnyTimes()
 
 	t.Run("should return 400 if neither destination_id nor hotel_ids is provided", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -68,8 +79,10 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
-		assert.Equal(t, "Either destination or list of hotel ids need to be provided", response.Message)
+		assert.Equal(t, http.StatusBadRequest, response.Status)
+		assert.Equal(t, domains.ErrCodeMissingFilter, response.Code)
+		assert.Equal(t, "Either destination, list of hotel ids or country code need to be provided", response.Detail)
+		assert.Equal(t, domains.ProblemContentType, w.Header().Get("Content-Type"))
 	})
 
 	t.Run("should return 200 with list of hotels when destination_id is provided", func(t *testing.T) {
@@ -125,6 +138,272 @@ func TestHotelController_Find(t *testing.T) {
 		assert.Equal(t, response[1].HotelID, expectedHotels[1].HotelID)
 	})
 
+	t.Run("should return 200 with hotels matching the policy filters", func(t *testing.T) {
+		allowed, denied, free := true, false, dto.PolicyFree
+		expectedHotels := []*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", Policies: &dto.HotelPolicies{PetsAllowed: &allowed, Parking: &free}},
+			{ID: 2, HotelID: "hotel_124", DestinationID: "dest_789", Policies: &dto.HotelPolicies{PetsAllowed: &denied, Parking: &free}},
+			{ID: 3, HotelID: "hotel_125", DestinationID: "dest_789", Policies: &dto.HotelPolicies{PetsAllowed: &allowed}},
+		}
+
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return(expectedHotels, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&pets_allowed=true&free_parking=true", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []*sqlc.Hotel
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response, 1)
+		assert.Equal(t, "hotel_123", response[0].HotelID)
+	})
+
+	t.Run("should return 400 if a policy filter is not a boolean", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&pets_allowed=maybe", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 200 with hotels by country_code when it is the only filter", func(t *testing.T) {
+		singapore := "SG"
+		expectedHotels := []*sqlc.Hotel{{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", CountryCode: &singapore}}
+
+		mockHotelService.EXPECT().FindByCountryCode(gomock.Any(), "SG").Return(expectedHotels, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?country_code=sg", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []*sqlc.Hotel
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response, 1)
+		assert.Equal(t, "hotel_123", response[0].HotelID)
+	})
+
+	t.Run("should return 200 with hotels of destination_id in country_code", func(t *testing.T) {
+		singapore, japan := "SG", "JP"
+		expectedHotels := []*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", CountryCode: &singapore},
+			{ID: 2, HotelID: "hotel_124", DestinationID: "dest_789", CountryCode: &japan},
+			{ID: 3, HotelID: "hotel_125", DestinationID: "dest_789"},
+		}
+
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return(expectedHotels, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&country_code=JP", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []*sqlc.Hotel
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response, 1)
+		assert.Equal(t, "hotel_124", response[0].HotelID)
+	})
+
+	t.Run("should return 400 if country_code is not an alpha-2 code", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?country_code=SGP", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 200 with hotels of destination_id scoring at least min_quality", func(t *testing.T) {
+		high, low := 80, 40
+		expectedHotels := []*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", DataQuality: &dto.DataQuality{Score: &high}},
+			{ID: 2, HotelID: "hotel_124", DestinationID: "dest_789", DataQuality: &dto.DataQuality{Score: &low}},
+		}
+
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return(expectedHotels, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&min_quality=80", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []*sqlc.Hotel
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response, 1)
+		assert.Equal(t, "hotel_123", response[0].HotelID)
+	})
+
+	t.Run("should return the description and booking conditions as plain text or safe html", func(t *testing.T) {
+		description := "<p>Beach <b>villas</b> &amp; spa.</p>"
+		hotel := &sqlc.Hotel{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", Description: &description, BookingConditions: []string{"Pets <i>not</i> allowed."}}
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return([]*sqlc.Hotel{hotel}, nil).Times(2)
+		plain := "Beach villas & spa."
+
+		for format, want := range map[string]*sqlc.Hotel{
+			"":             {Description: &plain, BookingConditions: []string{"Pets not allowed."}},
+			"&format=html": {Description: &description, BookingConditions: []string{"Pets <i>not</i> allowed."}},
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789"+format, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response []*sqlc.Hotel
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response, 1)
+			assert.Equal(t, want.Description, response[0].Description)
+			assert.Equal(t, want.BookingConditions, response[0].BookingConditions)
+		}
+		assert.Equal(t, "<p>Beach <b>villas</b> &amp; spa.</p>", *hotel.Description, "the found hotel should not be changed")
+	})
+
+	t.Run("should not return the raw text of the supplier", func(t *testing.T) {
+		raw := "<div>Beach villas &amp; spa.</div><script>track()</script>"
+		description := "<p>Beach villas &amp; spa.</p>"
+		hotel := &sqlc.Hotel{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", Description: &description, RawText: &dto.RawText{Description: &raw}}
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return([]*sqlc.Hotel{hotel}, nil).Times(3)
+
+		for _, path := range []string{
+			"/api/v1/hotels?destination_id=dest_789",
+			"/api/v1/hotels?destination_id=dest_789&format=html",
+			"/api/v1/destinations/dest_789/hotels",
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), "hotel_123")
+			assert.NotContains(t, w.Body.String(), "raw_text", path)
+			assert.NotContains(t, w.Body.String(), "track()", path)
+		}
+		assert.Equal(t, &raw, hotel.RawText.Description, "the found hotel should not be changed")
+	})
+
+	t.Run("should return 200 with hotels of the destination in the path", func(t *testing.T) {
+		high, low := 90, 40
+		hotels := []*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", DataQuality: &dto.DataQuality{Score: &high}},
+			{ID: 2, HotelID: "hotel_456", DestinationID: "dest_789", DataQuality: &dto.DataQuality{Score: &low}},
+		}
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return(hotels, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/destinations/dest_789/hotels?destination_id=dest_456&min_quality=80", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []*sqlc.Hotel
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, "hotel_123", response[0].HotelID)
+	})
+
+	t.Run("should return 400 for an unknown format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&format=markdown", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return hotels in the language of lang or Accept-Language", func(t *testing.T) {
+		hotel := &sqlc.Hotel{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", Name: "Beach Villas"}
+		translated := &sqlc.Hotel{ID: 1, HotelID: "hotel_123", DestinationID: "dest_789", Name: "ビーチヴィラ"}
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), "dest_789").Return([]*sqlc.Hotel{hotel}, nil).Times(2)
+		mockTranslationService.EXPECT().
+			Localize(gomock.Any(), []*sqlc.Hotel{hotel}, []string{"ja-JP", "ja", "fr", "en"}).
+			Return([]*sqlc.Hotel{translated}, []string{"ja"}, nil)
+		mockTranslationService.EXPECT().
+			Localize(gomock.Any(), []*sqlc.Hotel{hotel}, []string{"ja", "en"}).
+			Return([]*sqlc.Hotel{translated}, []string{"ja"}, nil)
+
+		for _, req := range []*http.Request{
+			httptest.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789", nil),
+			httptest.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&lang=ja", nil),
+		} {
+			req.Header.Set("Accept-Language", "fr;q=0.5, ja-JP")
+			if req.URL.Query().Get("lang") != "" {
+				req.Header.Set("Accept-Language", "de")
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "ja", w.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+
+			var response []*sqlc.Hotel
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response, 1)
+			assert.Equal(t, "ビーチヴィラ", response[0].Name)
+		}
+	})
+
+	t.Run("should return 400 for an invalid lang", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&lang=12345", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 if min_quality is above 100", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&min_quality=101", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response domains.HttpError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []domains.FieldError{
+			{Field: "min_quality", Rule: "max", Param: "100", Message: "must be less than or equal to 100"},
+		}, response.Errors)
+	})
+
+	t.Run("should return 400 if min_quality is below 0", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id=dest_789&min_quality=-1", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response domains.HttpError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []domains.FieldError{
+			{Field: "min_quality", Rule: "min", Param: "0", Message: "must be greater than or equal to 0"},
+		}, response.Errors)
+	})
+
 	t.Run("should return 400 if destination_id is empty string", func(t *testing.T) {
 		destinationID := ""
 
@@ -139,8 +418,11 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
-		assert.Equal(t, "Key: 'FindHotelsQueryDTO.DestinationID' Error:Field validation for 'DestinationID' failed on the 'min' tag", response.Message)
+		assert.Equal(t, http.StatusBadRequest, response.Status)
+		assert.Equal(t, domains.ErrCodeInvalidQuery, response.Code)
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, "destination_id", response.Errors[0].Field)
+		assert.Equal(t, "min", response.Errors[0].Rule)
 	})
 
 	t.Run("should return 400 if hotel_ids is empty", func(t *testing.T) {
@@ -154,7 +436,7 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusBadRequest, response.Status)
 	})
 
 	t.Run("should return 400 if any of hotel_ids is empty string", func(t *testing.T) {
@@ -168,7 +450,10 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusBadRequest, response.Status)
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, "hotel_ids[1]", response.Errors[0].Field)
+		assert.Equal(t, "required", response.Errors[0].Rule)
 	})
 
 	t.Run("should return 500 if service returns error for destination_id", func(t *testing.T) {
@@ -186,8 +471,27 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusInternalServerError, response.Code)
-		assert.Equal(t, "Could not fetch list of hotels. Please retry again", response.Message)
+		assert.Equal(t, http.StatusInternalServerError, response.Status)
+		assert.Equal(t, domains.ErrCodeInternal, response.Code)
+		assert.Equal(t, "Could not fetch list of hotels. Please retry again", response.Detail)
+	})
+
+	t.Run("should return 504 if service times out", func(t *testing.T) {
+		destinationID := "dest_timeout"
+
+		mockHotelService.EXPECT().FindByDestinationID(gomock.Any(), destinationID).Return(nil, context.DeadlineExceeded).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/hotels?destination_id="+destinationID, nil)
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+		var response domains.HttpError
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, domains.ErrCodeTimeout, response.Code)
 	})
 
 	t.Run("should return 500 if service returns error for hotel_ids", func(t *testing.T) {
@@ -203,8 +507,8 @@ func TestHotelController_Find(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusInternalServerError, response.Code)
-		assert.Equal(t, "Could not fetch list of hotels. Please retry again", response.Message)
+		assert.Equal(t, http.StatusInternalServerError, response.Status)
+		assert.Equal(t, "Could not fetch list of hotels. Please retry again", response.Detail)
 	})
 
 	t.Run("should return 200 with empty list when no hotels found for destination_id", func(t *testing.T) {
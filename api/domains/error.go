@@ -1,19 +1,99 @@
 package domains
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
 
-type HttpError struct {
-	Code    int    `json:"code"`
+	"github.com/duylamasd/hotels-merge/domains"
+)
+
+const ProblemContentType = "application/problem+json"
+
+type ErrorCode string
+
+const (
+	ErrCodeInvalidQuery        ErrorCode = "invalid_query"
+	ErrCodeInvalidBody         ErrorCode = "invalid_body"
+	ErrCodeMissingFilter       ErrorCode = "missing_filter"
+	ErrCodeNotFound            ErrorCode = "not_found"
+	ErrCodeConflict            ErrorCode = "conflict"
+	ErrCodeUnauthorized        ErrorCode = "unauthorized"
+	ErrCodeBodyTooLarge        ErrorCode = "body_too_large"
+	ErrCodeInvalidRecords      ErrorCode = "invalid_records"
+	ErrCodeRateLimited         ErrorCode = "rate_limited"
+	ErrCodeTimeout             ErrorCode = "timeout"
+	ErrCodeDatabaseUnavailable ErrorCode = "database_unavailable"
+	ErrCodeInternal            ErrorCode = "internal_error"
+)
+
+type errorDefinition struct {
+	Status int
+	Title  string
+}
+
+var errorCatalogue = map[ErrorCode]errorDefinition{
+	ErrCodeInvalidQuery:        {Status: http.StatusBadRequest, Title: "Invalid query parameters"},
+	ErrCodeInvalidBody:         {Status: http.StatusBadRequest, Title: "Invalid request body"},
+	ErrCodeMissingFilter:       {Status: http.StatusBadRequest, Title: "Missing filter"},
+	ErrCodeNotFound:            {Status: http.StatusNotFound, Title: "Resource not found"},
+	ErrCodeConflict:            {Status: http.StatusConflict, Title: "Conflict"},
+	ErrCodeUnauthorized:        {Status: http.StatusUnauthorized, Title: "Unauthorized"},
+	ErrCodeBodyTooLarge:        {Status: http.StatusRequestEntityTooLarge, Title: "Request body too large"},
+	ErrCodeInvalidRecords:      {Status: http.StatusUnprocessableEntity, Title: "Invalid records"},
+	ErrCodeRateLimited:         {Status: http.StatusTooManyRequests, Title: "Too many requests"},
+	ErrCodeTimeout:             {Status: http.StatusGatewayTimeout, Title: "Request timed out"},
+	ErrCodeDatabaseUnavailable: {Status: http.StatusServiceUnavailable, Title: "Database unavailable"},
+	ErrCodeInternal:            {Status: http.StatusInternalServerError, Title: "Internal server error"},
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
 	Message string `json:"message"`
 }
 
+// HttpError is an RFC 7807 problem details object extended with a stable error code.
+type HttpError struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      ErrorCode    `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Records reports the rejected lines of bulk requests.
+	Records []domains.DataIssue `json:"records,omitempty"`
+}
+
 func (e HttpError) Error() string {
-	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
+	return fmt.Sprintf("status: %d, code: %s, detail: %s", e.Status, e.Code, e.Detail)
 }
 
-func NewHttpError(code int, message string) HttpError {
+func NewHttpError(code ErrorCode, detail string) HttpError {
+	definition, ok := errorCatalogue[code]
+	if !ok {
+		code = ErrCodeInternal
+		definition = errorCatalogue[code]
+	}
+
 	return HttpError{
-		Code:    code,
-		Message: message,
+		Type:   ProblemType(code),
+		Title:  definition.Title,
+		Status: definition.Status,
+		Detail: detail,
+		Code:   code,
 	}
 }
+
+func ProblemType(code ErrorCode) string {
+	return "/problems/" + string(code)
+}
+
+func NewRecordsError(detail string, records []domains.DataIssue) HttpError {
+	e := NewHttpError(ErrCodeInvalidRecords, detail)
+	e.Records = records
+
+	return e
+}
@@ -0,0 +1,32 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// FromError maps well-known errors to problems; fallbackDetail is used for everything else.
+func FromError(err error, fallbackDetail string) HttpError {
+	var httpError HttpError
+	if errors.As(err, &httpError) {
+		return httpError
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return NewHttpError(ErrCodeNotFound, "The requested resource does not exist")
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return NewHttpError(ErrCodeTimeout, "The request took too long to complete. Please retry again")
+	case errors.As(err, &connectErr), errors.As(err, &netErr):
+		return NewHttpError(ErrCodeDatabaseUnavailable, "The database is currently unavailable. Please retry again")
+	default:
+		return NewHttpError(ErrCodeInternal, fallbackDetail)
+	}
+}
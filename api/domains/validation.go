@@ -0,0 +1,91 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName reports fields by their query or JSON name instead of the Go struct field name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "json", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+func NewValidationError(code ErrorCode, err error) HttpError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return NewHttpError(code, err.Error())
+	}
+
+	httpError := NewHttpError(code, "One or more parameters are invalid")
+	for _, fe := range validationErrors {
+		httpError.Errors = append(httpError.Errors, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: validationMessage(fe),
+		})
+	}
+
+	return httpError
+}
+
+// fieldPath drops the root struct name so nested and slice fields read like "hotel_ids[1]".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+
+	return path
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		switch fe.Kind() {
+		case reflect.Slice, reflect.Map:
+			return fmt.Sprintf("must contain at least %s item(s)", fe.Param())
+		case reflect.String:
+			return fmt.Sprintf("must be at least %s character(s) long", fe.Param())
+		default:
+			return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+		}
+	case "max":
+		switch fe.Kind() {
+		case reflect.Slice, reflect.Map:
+			return fmt.Sprintf("must contain at most %s item(s)", fe.Param())
+		case reflect.String:
+			return fmt.Sprintf("must be at most %s character(s) long", fe.Param())
+		default:
+			return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+		}
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+	}
+}
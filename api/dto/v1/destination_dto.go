@@ -0,0 +1,31 @@
+package v1
+
+// defaultDestinationsLimit is the page size of destinations when none is asked.
+const defaultDestinationsLimit = 20
+
+type ListDestinationsQueryDTO struct {
+	Limit  *int32 `form:"limit" binding:"omitnil,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"min=0"`
+}
+
+func (q ListDestinationsQueryDTO) LimitOrDefault() int32 {
+	if q.Limit == nil {
+		return defaultDestinationsLimit
+	}
+
+	return *q.Limit
+}
+
+type DestinationURIDTO struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type UpsertDestinationDTO struct {
+	Name string `json:"name" binding:"required"`
+	// CountryCode is an ISO 3166-1 alpha-2 code, in any case.
+	CountryCode *string  `json:"country_code" binding:"omitnil,len=2,alpha"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitnil,min=-90,max=90"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitnil,min=-180,max=180"`
+	// Timezone is an IANA time zone name, such as Asia/Singapore.
+	Timezone *string `json:"timezone" binding:"omitnil,min=1"`
+}
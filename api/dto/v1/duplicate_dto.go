@@ -0,0 +1,24 @@
+package v1
+
+import "github.com/duylamasd/hotels-merge/domains"
+
+type ListDuplicatesQueryDTO struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending confirmed rejected"`
+}
+
+// StatusOrDefault lists pending candidates unless another status is asked.
+func (q ListDuplicatesQueryDTO) StatusOrDefault() string {
+	if q.Status == "" {
+		return domains.DuplicatePending
+	}
+
+	return q.Status
+}
+
+type DuplicateURIDTO struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type ConfirmDuplicateDTO struct {
+	CanonicalHotelID string `json:"canonical_hotel_id" binding:"required"`
+}
@@ -1,6 +1,53 @@
 package v1
 
+import (
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+)
+
 type FindHotelsQueryDTO struct {
 	DestinationID *string   `form:"destination_id" binding:"omitnil,min=1"`
 	HotelIDs      *[]string `form:"hotel_ids" binding:"omitnil,min=1,dive,required"`
+	// CountryCode is an ISO 3166-1 alpha-2 code, in any case.
+	CountryCode *string `form:"country_code" binding:"omitnil,len=2,alpha"`
+	// MinQuality is the lowest data quality score of the hotels, from 0 to 100.
+	MinQuality *int `form:"min_quality" binding:"omitnil,min=0,max=100"`
+	// Format is the format of the description and booking conditions, text by default.
+	Format string `form:"format" binding:"omitempty,oneof=text html"`
+	// Lang is the preferred locale of the content, over the Accept-Language header.
+	Lang string `form:"lang" binding:"omitempty,bcp47_language_tag"`
+
+	PetsAllowed        *bool `form:"pets_allowed"`
+	FreeWifi           *bool `form:"free_wifi"`
+	FreeParking        *bool `form:"free_parking"`
+	ChildrenAllowed    *bool `form:"children_allowed"`
+	PrepaymentRequired *bool `form:"prepayment_required"`
+}
+
+func (q FindHotelsQueryDTO) FormatOrDefault() string {
+	if q.Format == "" {
+		return sanitize.FormatText
+	}
+
+	return q.Format
+}
+
+func (q FindHotelsQueryDTO) Filter() domains.HotelFilter {
+	var countryCode *string
+	if q.CountryCode != nil {
+		code := strings.ToUpper(*q.CountryCode)
+		countryCode = &code
+	}
+
+	return domains.HotelFilter{
+		CountryCode:        countryCode,
+		MinQuality:         q.MinQuality,
+		PetsAllowed:        q.PetsAllowed,
+		FreeWifi:           q.FreeWifi,
+		FreeParking:        q.FreeParking,
+		ChildrenAllowed:    q.ChildrenAllowed,
+		PrepaymentRequired: q.PrepaymentRequired,
+	}
 }
@@ -0,0 +1,14 @@
+package v1
+
+type TranslationURIDTO struct {
+	HotelID string `uri:"hotel_id" binding:"required"`
+	Locale  string `uri:"locale"`
+}
+
+type UpsertTranslationDTO struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	// AmenityLabels maps canonical amenity codes to their translated labels.
+	AmenityLabels     map[string]string `json:"amenity_labels" binding:"omitempty,dive,keys,required,endkeys,required"`
+	BookingConditions []string          `json:"booking_conditions" binding:"omitempty,dive,required"`
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuthMiddleware requires the configured admin bearer token. Admin endpoints do not exist when no token is set.
+type AdminAuthMiddleware struct {
+	token string
+}
+
+func (m *AdminAuthMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.token == "" {
+			_ = c.Error(apiDomains.NewHttpError(apiDomains.ErrCodeNotFound, "The requested route does not exist"))
+			c.Abort()
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			_ = c.Error(apiDomains.NewHttpError(apiDomains.ErrCodeUnauthorized, "A valid admin bearer token is required"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func NewAdminAuthMiddleware(config *config.Config) *AdminAuthMiddleware {
+	return &AdminAuthMiddleware{token: config.Auth.AdminToken}
+}
@@ -0,0 +1,71 @@
+package middlewares_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	newRouter := func(token string) *gin.Engine {
+		cfg := config.Default()
+		cfg.Auth.AdminToken = token
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(errorHandler.Handler())
+		router.Use(middlewares.NewAdminAuthMiddleware(cfg).Handler())
+		router.POST("/admin", func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+
+		return router
+	}
+
+	request := func(router *gin.Engine, authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should hide admin endpoints when no token is configured", func(t *testing.T) {
+		w := request(newRouter(""), "Bearer ")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should reject a missing or wrong token", func(t *testing.T) {
+		router := newRouter("s3cret")
+
+		for _, authorization := range []string{"", "Bearer wrong", "s3cret"} {
+			w := request(router, authorization)
+			assert.Equal(t, http.StatusUnauthorized, w.Code, authorization)
+			assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+			var problem domains.HttpError
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, domains.ErrCodeUnauthorized, problem.Code)
+		}
+	})
+
+	t.Run("should accept the configured token", func(t *testing.T) {
+		w := request(newRouter("s3cret"), "Bearer s3cret")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/lib"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -15,16 +16,34 @@ type ErrorHandler struct {
 func (h *ErrorHandler) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		logger := lib.LoggerFromContext(c.Request.Context(), h.logger)
 		for _, err := range c.Errors {
-			switch e := err.Err.(type) {
-			case domains.HttpError:
-				c.AbortWithStatusJSON(e.Code, e)
-			default:
-				c.AbortWithStatusJSON(http.StatusInternalServerError, domains.NewHttpError(
-					http.StatusInternalServerError,
-					"Unexpected error occurred",
-				))
+			e := domains.FromError(err.Err, "Unexpected error occurred")
+			e.Instance = c.Request.URL.RequestURI()
+			e.RequestID = lib.RequestIDFromContext(c.Request.Context())
+
+			fields := []zap.Field{
+				zap.Int("status", e.Status),
+				zap.String("code", string(e.Code)),
+				zap.String("path", c.Request.URL.Path),
+				zap.Error(err.Err),
+			}
+			if e.Status >= http.StatusInternalServerError {
+				logger.Error("Request failed", fields...)
+			} else {
+				logger.Warn("Request rejected", fields...)
 			}
+
+			if c.Writer.Written() {
+				continue
+			}
+
+			c.Header("Content-Type", domains.ProblemContentType)
+			c.AbortWithStatusJSON(e.Status, e)
 		}
 	}
 }
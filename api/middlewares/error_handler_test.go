@@ -2,6 +2,7 @@ package middlewares_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,15 +12,17 @@ import (
 	"github.com/duylamasd/hotels-merge/config"
 	"github.com/duylamasd/hotels-merge/lib"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 )
 
 func TestErrorHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	logger, _ := lib.NewLogger(&config.Config{LogLevel: "info"})
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
 	errorHandler := middlewares.NewErrorHandler(logger)
 
 	gin.SetMode(gin.TestMode)
@@ -28,10 +31,18 @@ func TestErrorHandler(t *testing.T) {
 	router.Use(errorHandler.Handler())
 
 	router.GET("/test", func(c *gin.Context) {
-		e := domains.NewHttpError(http.StatusBadRequest, "Error")
+		e := domains.NewHttpError(domains.ErrCodeInvalidQuery, "Error")
 		_ = c.Error(e)
 	})
 
+	router.GET("/not-found", func(c *gin.Context) {
+		_ = c.Error(pgx.ErrNoRows)
+	})
+
+	router.GET("/unexpected", func(c *gin.Context) {
+		_ = c.Error(errors.New("boom"))
+	})
+
 	t.Run("should return 400 for HttpError", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/test", nil)
@@ -44,7 +55,41 @@ func TestErrorHandler(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
-		assert.Equal(t, "Error", response.Message)
+		assert.Equal(t, http.StatusBadRequest, response.Status)
+		assert.Equal(t, domains.ErrCodeInvalidQuery, response.Code)
+		assert.Equal(t, "Error", response.Detail)
+		assert.Equal(t, "/test", response.Instance)
+		assert.Equal(t, domains.ProblemContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should return 404 for pgx.ErrNoRows", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/not-found", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response domains.HttpError
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, domains.ErrCodeNotFound, response.Code)
+	})
+
+	t.Run("should return 500 for unexpected errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/unexpected", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		var response domains.HttpError
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, domains.ErrCodeInternal, response.Code)
+		assert.Equal(t, "Unexpected error occurred", response.Detail)
 	})
 }
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+)
+
+const unmatchedRoute = "unmatched"
+
+type MetricsMiddleware struct {
+	metrics *lib.Metrics
+}
+
+func (m *MetricsMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Label by route template rather than raw path to keep cardinality bounded.
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		status := strconv.Itoa(c.Writer.Status())
+		m.metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+func NewMetricsMiddleware(metrics *lib.Metrics) *MetricsMiddleware {
+	return &MetricsMiddleware{metrics: metrics}
+}
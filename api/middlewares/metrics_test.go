@@ -0,0 +1,49 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	metrics := lib.NewMetrics()
+	metricsMiddleware := middlewares.NewMetricsMiddleware(metrics)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(metricsMiddleware.Handler())
+
+	router.GET("/hotels/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("should count requests by route template", func(t *testing.T) {
+		for _, path := range []string{"/hotels/1", "/hotels/2"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			router.ServeHTTP(w, req)
+		}
+
+		count := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues("GET", "/hotels/:id", "204"))
+		assert.Equal(t, float64(2), count)
+	})
+
+	t.Run("should label unknown routes as unmatched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/unknown", nil)
+
+		router.ServeHTTP(w, req)
+
+		count := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues("GET", "unmatched", "404"))
+		assert.Equal(t, float64(1), count)
+	})
+}
@@ -4,4 +4,10 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewErrorHandler),
+	fx.Provide(NewMetricsMiddleware),
+	fx.Provide(NewTracingMiddleware),
+	fx.Provide(NewRequestIDMiddleware),
+	fx.Provide(NewRateLimitMiddleware),
+	fx.Provide(NewAdminAuthMiddleware),
+	fx.Invoke(SubscribeRateLimit),
 )
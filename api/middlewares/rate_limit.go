@@ -0,0 +1,116 @@
+package middlewares
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/gin-gonic/gin"
+)
+
+const maxRateLimitedClients = 10000
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// RateLimitMiddleware limits requests per client IP with a token bucket whose limits can be updated at runtime.
+type RateLimitMiddleware struct {
+	mu                sync.Mutex
+	enabled           bool
+	requestsPerSecond float64
+	burst             int
+	clients           map[string]*tokenBucket
+	now               func() time.Time
+}
+
+func (m *RateLimitMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := m.allow(c.ClientIP())
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			_ = c.Error(apiDomains.NewHttpError(apiDomains.ErrCodeRateLimited, "Rate limit exceeded, please retry later"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Update applies new limits, clients keep their remaining tokens up to the new burst.
+func (m *RateLimitMiddleware) Update(rateLimit config.RateLimitConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enabled = rateLimit.Enabled
+	m.requestsPerSecond = rateLimit.RequestsPerSecond
+	m.burst = rateLimit.Burst
+	if !m.enabled {
+		m.clients = map[string]*tokenBucket{}
+	}
+}
+
+func (m *RateLimitMiddleware) allow(client string) (bool, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.enabled {
+		return true, 0
+	}
+
+	now := m.now()
+	bucket, ok := m.clients[client]
+	if !ok {
+		if len(m.clients) >= maxRateLimitedClients {
+			m.evictIdleClients(now)
+		}
+		bucket = &tokenBucket{tokens: float64(m.burst), updatedAt: now}
+		m.clients[client] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(m.burst), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*m.requestsPerSecond)
+	bucket.updatedAt = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - bucket.tokens) / m.requestsPerSecond * float64(time.Second))
+}
+
+// evictIdleClients drops clients whose bucket has refilled, as they are indistinguishable from new clients.
+func (m *RateLimitMiddleware) evictIdleClients(now time.Time) {
+	for client, bucket := range m.clients {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*m.requestsPerSecond >= float64(m.burst) {
+			delete(m.clients, client)
+		}
+	}
+
+	if len(m.clients) >= maxRateLimitedClients {
+		m.clients = map[string]*tokenBucket{}
+	}
+}
+
+func NewRateLimitMiddleware(config *config.Config) *RateLimitMiddleware {
+	m := &RateLimitMiddleware{
+		clients: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+	m.Update(config.RateLimit)
+
+	return m
+}
+
+// SubscribeRateLimit applies reloaded rate limits to the middleware.
+func SubscribeRateLimit(watcher *config.Watcher, m *RateLimitMiddleware) {
+	watcher.Subscribe("rate_limit", func(config *config.Config) error {
+		m.Update(config.RateLimit)
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package middlewares_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	cfg := config.Default()
+	cfg.RateLimit = config.RateLimitConfig{Enabled: true, RequestsPerSecond: 0.001, Burst: 2}
+	rateLimitMiddleware := middlewares.NewRateLimitMiddleware(cfg)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(errorHandler.Handler())
+	router.Use(rateLimitMiddleware.Handler())
+
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = remoteAddr
+
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should reject requests beyond the burst", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, request("10.0.0.1:1234").Code)
+		assert.Equal(t, http.StatusNoContent, request("10.0.0.1:1234").Code)
+
+		w := request("10.0.0.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		var problem domains.HttpError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, domains.ErrCodeRateLimited, problem.Code)
+	})
+
+	t.Run("should limit each client separately", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, request("10.0.0.2:1234").Code)
+	})
+
+	t.Run("should apply updated limits", func(t *testing.T) {
+		rateLimitMiddleware.Update(config.RateLimitConfig{Enabled: false})
+
+		assert.Equal(t, http.StatusNoContent, request("10.0.0.1:1234").Code)
+	})
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const maxRequestIDLength = 128
+
+type RequestIDMiddleware struct {
+	logger *zap.Logger
+}
+
+func (m *RequestIDMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(lib.RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		fields := []zap.Field{zap.String("request_id", requestID)}
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+		}
+
+		ctx := lib.ContextWithRequestID(c.Request.Context(), requestID)
+		ctx = lib.ContextWithLogger(ctx, m.logger.With(fields...))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Header(lib.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// isValidRequestID only accepts short printable ASCII values so client-provided IDs cannot inject into logs.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func NewRequestIDMiddleware(logger *zap.Logger) *RequestIDMiddleware {
+	return &RequestIDMiddleware{logger: logger}
+}
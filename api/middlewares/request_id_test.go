@@ -0,0 +1,75 @@
+package middlewares_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	requestIDMiddleware := middlewares.NewRequestIDMiddleware(logger)
+	errorHandler := middlewares.NewErrorHandler(logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(requestIDMiddleware.Handler())
+	router.Use(errorHandler.Handler())
+
+	router.GET("/test", func(c *gin.Context) {
+		assert.NotSame(t, logger, lib.LoggerFromContext(c.Request.Context(), logger))
+		c.String(http.StatusOK, lib.RequestIDFromContext(c.Request.Context()))
+	})
+
+	router.GET("/error", func(c *gin.Context) {
+		_ = c.Error(domains.NewHttpError(domains.ErrCodeInvalidQuery, "Error"))
+	})
+
+	t.Run("should keep a valid incoming request id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set(lib.RequestIDHeader, "req-123")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "req-123", w.Header().Get(lib.RequestIDHeader))
+		assert.Equal(t, "req-123", w.Body.String())
+	})
+
+	t.Run("should generate a request id when missing or invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set(lib.RequestIDHeader, "bad id\nwith newline")
+
+		router.ServeHTTP(w, req)
+
+		requestID := w.Header().Get(lib.RequestIDHeader)
+		assert.NotEmpty(t, requestID)
+		assert.NotEqual(t, "bad id\nwith newline", requestID)
+		assert.Equal(t, requestID, w.Body.String())
+	})
+
+	t.Run("should echo the request id in error responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/error", nil)
+		req.Header.Set(lib.RequestIDHeader, "req-456")
+
+		router.ServeHTTP(w, req)
+
+		var response domains.HttpError
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "req-456", response.RequestID)
+	})
+}
@@ -0,0 +1,24 @@
+package system
+
+import (
+	systemControllers "github.com/duylamasd/hotels-merge/api/controllers/system"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthRoutes struct {
+	controller systemControllers.HealthController
+}
+
+func (s *HealthRoutes) Register(group *gin.RouterGroup) {
+	group.GET("/healthz", s.controller.Liveness)
+	group.GET("/readyz", s.controller.Readiness)
+	group.GET("/status", s.controller.Status)
+}
+
+func NewHealthRoutes(
+	controller systemControllers.HealthController,
+) *HealthRoutes {
+	return &HealthRoutes{
+		controller: controller,
+	}
+}
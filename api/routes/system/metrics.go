@@ -0,0 +1,24 @@
+package system
+
+import (
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsRoutes struct {
+	metrics *lib.Metrics
+}
+
+func (s *MetricsRoutes) Register(group *gin.RouterGroup) {
+	handler := promhttp.HandlerFor(s.metrics.Registry, promhttp.HandlerOpts{
+		Registry: s.metrics.Registry,
+	})
+	group.GET("/metrics", gin.WrapH(handler))
+}
+
+func NewMetricsRoutes(metrics *lib.Metrics) *MetricsRoutes {
+	return &MetricsRoutes{
+		metrics: metrics,
+	}
+}
@@ -0,0 +1,32 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type SystemRoutes struct {
+	HealthRoutes  *HealthRoutes
+	MetricsRoutes *MetricsRoutes
+}
+
+func (r *SystemRoutes) Register(group *gin.RouterGroup) {
+	r.HealthRoutes.Register(group)
+	r.MetricsRoutes.Register(group)
+}
+
+func NewSystemRoutes(
+	healthRoutes *HealthRoutes,
+	metricsRoutes *MetricsRoutes,
+) *SystemRoutes {
+	return &SystemRoutes{
+		HealthRoutes:  healthRoutes,
+		MetricsRoutes: metricsRoutes,
+	}
+}
+
+var Module = fx.Options(
+	fx.Provide(NewHealthRoutes),
+	fx.Provide(NewMetricsRoutes),
+	fx.Provide(NewSystemRoutes),
+)
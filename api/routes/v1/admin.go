@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"strings"
+
+	v1Controllers "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/api/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminRoutes struct {
+	hotelController       v1Controllers.AdminHotelController
+	duplicateController   v1Controllers.AdminDuplicateController
+	translationController v1Controllers.AdminTranslationController
+	destinationController v1Controllers.AdminDestinationController
+	auth                  *middlewares.AdminAuthMiddleware
+}
+
+func (s *AdminRoutes) Register(group *gin.RouterGroup) {
+	admin := group.Group("/admin", s.auth.Handler())
+	admin.POST("/hotels:method", customMethods("method", map[string]gin.HandlerFunc{
+		"import": s.hotelController.Import,
+	}))
+	admin.GET("/quality", s.hotelController.Quality)
+	admin.GET("/hotels/:hotel_id/translations", s.translationController.List)
+	admin.PUT("/hotels/:hotel_id/translations/:locale", s.translationController.Upsert)
+	admin.DELETE("/hotels/:hotel_id/translations/:locale", s.translationController.Delete)
+
+	admin.PUT("/destinations/:id", s.destinationController.Upsert)
+
+	admin.GET("/duplicates", s.duplicateController.List)
+	admin.POST("/duplicates:method", customMethods("method", map[string]gin.HandlerFunc{
+		"detect": s.duplicateController.Detect,
+	}))
+	admin.POST("/duplicates/:id", customMethods("id", map[string]gin.HandlerFunc{
+		"confirm": s.duplicateController.Confirm,
+		"reject":  s.duplicateController.Reject,
+	}))
+}
+
+// customMethods dispatches "resource:method" routes. Gin reads the colon as the start of a parameter,
+// so the method arrives after a colon in param: ":import" for "/hotels:method", "12:confirm" for
+// "/duplicates/:id". The handler sees param without the method.
+func customMethods(param string, handlers map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, method, found := strings.Cut(c.Param(param), ":")
+		if handler, ok := handlers[method]; found && ok {
+			for i := range c.Params {
+				if c.Params[i].Key == param {
+					c.Params[i].Value = value
+				}
+			}
+			handler(c)
+			return
+		}
+
+		_ = c.Error(apiDomains.NewHttpError(apiDomains.ErrCodeNotFound, "The requested route does not exist"))
+	}
+}
+
+func NewAdminRoutes(
+	hotelController v1Controllers.AdminHotelController,
+	duplicateController v1Controllers.AdminDuplicateController,
+	translationController v1Controllers.AdminTranslationController,
+	destinationController v1Controllers.AdminDestinationController,
+	auth *middlewares.AdminAuthMiddleware,
+) *AdminRoutes {
+	return &AdminRoutes{
+		hotelController:       hotelController,
+		duplicateController:   duplicateController,
+		translationController: translationController,
+		destinationController: destinationController,
+		auth:                  auth,
+	}
+}
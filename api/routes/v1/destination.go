@@ -0,0 +1,28 @@
+package v1
+
+import (
+	v1Controllers "github.com/duylamasd/hotels-merge/api/controllers/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type DestinationRoutes struct {
+	controller      v1Controllers.DestinationController
+	hotelController v1Controllers.HotelController
+}
+
+func (s *DestinationRoutes) Register(group *gin.RouterGroup) {
+	destinations := group.Group("/destinations")
+	destinations.GET("", s.controller.List)
+	destinations.GET("/:id", s.controller.Find)
+	destinations.GET("/:id/hotels", s.hotelController.FindByDestination)
+}
+
+func NewDestinationRoutes(
+	controller v1Controllers.DestinationController,
+	hotelController v1Controllers.HotelController,
+) *DestinationRoutes {
+	return &DestinationRoutes{
+		controller:      controller,
+		hotelController: hotelController,
+	}
+}
@@ -6,22 +6,32 @@ import (
 )
 
 type V1Routes struct {
-	HotelRoutes *HotelRoutes
+	HotelRoutes       *HotelRoutes
+	DestinationRoutes *DestinationRoutes
+	AdminRoutes       *AdminRoutes
 }
 
 func (r *V1Routes) Register(group *gin.RouterGroup) {
 	r.HotelRoutes.Register(group)
+	r.DestinationRoutes.Register(group)
+	r.AdminRoutes.Register(group)
 }
 
 func NewV1Routes(
 	hotelRoutes *HotelRoutes,
+	destinationRoutes *DestinationRoutes,
+	adminRoutes *AdminRoutes,
 ) *V1Routes {
 	return &V1Routes{
-		HotelRoutes: hotelRoutes,
+		HotelRoutes:       hotelRoutes,
+		DestinationRoutes: destinationRoutes,
+		AdminRoutes:       adminRoutes,
 	}
 }
 
 var Module = fx.Options(
 	fx.Provide(NewHotelRoutes),
+	fx.Provide(NewDestinationRoutes),
+	fx.Provide(NewAdminRoutes),
 	fx.Provide(NewV1Routes),
 )
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// RegisterMemorySeed loads database.memory_seed into the memory backend before the HTTP server listens.
+func RegisterMemorySeed(
+	lc fx.Lifecycle,
+	hotelDataService domains.HotelDataService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) {
+	if cfg.Database.Backend != config.BackendMemory || cfg.Database.MemorySeed == "" {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			file, err := os.Open(cfg.Database.MemorySeed)
+			if err != nil {
+				return fmt.Errorf("could not open the memory seed: %w", err)
+			}
+			defer file.Close()
+
+			result, err := hotelDataService.Import(ctx, file)
+			if err != nil {
+				return fmt.Errorf("could not load the memory seed: %w", err)
+			}
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("memory seed %s has %d invalid record(s), the first one: %s", cfg.Database.MemorySeed, len(result.Errors), result.Errors[0])
+			}
+
+			logger.Info("Loaded the memory seed", zap.String("file", cfg.Database.MemorySeed), zap.Int("hotels", result.Imported))
+			return nil
+		},
+	})
+}
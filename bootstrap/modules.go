@@ -1,8 +1,6 @@
 package bootstrap
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	"github.com/duylamasd/hotels-merge/api"
@@ -13,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func NewGinEngine(logger *zap.Logger) *gin.Engine {
@@ -20,31 +19,32 @@ func NewGinEngine(logger *zap.Logger) *gin.Engine {
 
 	r := gin.New()
 
-	r.Use(ginzap.Ginzap(logger, time.RFC3339, true))
+	r.Use(ginzap.GinzapWithConfig(logger, &ginzap.Config{
+		TimeFormat:   time.RFC3339,
+		UTC:          true,
+		DefaultLevel: zapcore.InfoLevel,
+		Context: func(c *gin.Context) []zapcore.Field {
+			return []zapcore.Field{zap.String("request_id", lib.RequestIDFromContext(c.Request.Context()))}
+		},
+	}))
 	r.Use(ginzap.RecoveryWithZap(logger, true))
 
 	return r
 }
 
-func RegisterHooks(lc fx.Lifecycle, engine *gin.Engine, config *config.Config) {
-	lc.Append(fx.Hook{
-		OnStart: func(ctx context.Context) error {
-			addr := fmt.Sprintf(":%s", config.Port)
-			go engine.Run(addr)
-
-			return nil
-		},
-		OnStop: func(ctx context.Context) error {
-			return nil
-		},
-	})
-}
-
-var Modules = fx.Options(
+// CoreModules provide the configuration, logging, telemetry and database, shared by the server and CLI commands.
+var CoreModules = fx.Options(
 	config.Module,
 	lib.Module,
+)
+
+var Modules = fx.Options(
+	CoreModules,
 	fx.Provide(NewGinEngine),
+	fx.Provide(NewHTTPServer),
 	services.Module,
 	api.Module,
+	fx.Invoke(RegisterSchemaGuard),
+	fx.Invoke(RegisterMemorySeed),
 	fx.Invoke(RegisterHooks),
 )
@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// RegisterSchemaGuard fails startup before the HTTP server listens when the database schema is behind the binary.
+func RegisterSchemaGuard(
+	lc fx.Lifecycle,
+	migrationService domains.MigrationService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) {
+	// The memory backend has no schema to check.
+	if cfg.Database.Backend == config.BackendMemory {
+		return
+	}
+	if !cfg.Database.SchemaCheck {
+		logger.Warn("Database schema check is disabled")
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := migrationService.CheckSchema(ctx); err != nil {
+				return fmt.Errorf("refusing to serve: %w", err)
+			}
+
+			logger.Info("Database schema is up to date")
+			return nil
+		},
+	})
+}
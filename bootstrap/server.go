@@ -0,0 +1,81 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func NewHTTPServer(engine *gin.Engine, config *config.Config) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", config.Server.Port),
+		Handler:           engine,
+		ReadTimeout:       config.Server.ReadTimeout,
+		ReadHeaderTimeout: config.Server.ReadTimeout,
+		WriteTimeout:      config.Server.WriteTimeout,
+		IdleTimeout:       config.Server.IdleTimeout,
+	}
+}
+
+func RegisterHooks(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	server *http.Server,
+	healthService domains.HealthService,
+	config *config.Config,
+	logger *zap.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// Listening synchronously lets fx fail startup on errors such as a port already in use.
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("could not listen on %s: %w", server.Addr, err)
+			}
+
+			logger.Info("HTTP server is listening", zap.String("addr", listener.Addr().String()))
+
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("HTTP server stopped unexpectedly", zap.Error(err))
+					_ = shutdowner.Shutdown(fx.ExitCode(1))
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			// Fail readiness first so load balancers stop routing new traffic before connections are drained.
+			healthService.MarkDraining()
+			if config.Server.DrainDelay > 0 {
+				logger.Info("Waiting for load balancers to observe draining", zap.Duration("delay", config.Server.DrainDelay))
+				select {
+				case <-time.After(config.Server.DrainDelay):
+				case <-ctx.Done():
+				}
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, config.Server.ShutdownTimeout)
+			defer cancel()
+
+			logger.Info("Draining HTTP server connections", zap.Duration("timeout", config.Server.ShutdownTimeout))
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				logger.Error("Could not drain HTTP server connections in time", zap.Error(err))
+				_ = server.Close()
+				return err
+			}
+
+			logger.Info("HTTP server stopped")
+			return nil
+		},
+	})
+}
@@ -1,13 +1,13 @@
 package main
 
 import (
-	"github.com/duylamasd/hotels-merge/bootstrap"
+	"os"
+
 	_ "github.com/joho/godotenv/autoload"
-	"go.uber.org/fx"
 )
 
 func main() {
-	fx.New(
-		bootstrap.Modules,
-	).Run()
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/spf13/cobra"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+
+	cmd.AddCommand(newConfigPrintCommand())
+
+	return cmd
+}
+
+func newConfigPrintCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(*loadOptions(cmd))
+			if err != nil {
+				return err
+			}
+
+			return cfg.Print(cmd.OutOrStdout(), format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "yaml", "Output format (yaml, json)")
+
+	return cmd
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func runHotelData(cmd *cobra.Command, task func(ctx context.Context, hotelDataService domains.HotelDataService) error) error {
+	return runTask(cmd, task, fx.Provide(services.NewHotelChanges, services.NewHotelDataService))
+}
+
+func newIngestCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ingest",
+		Short: "Fetch hotels from every supplier, merge them and update the stored hotels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTask(cmd, func(ctx context.Context, ingestService domains.IngestService) error {
+				result, err := ingestService.Ingest(ctx)
+				if err != nil {
+					return err
+				}
+
+				for _, supplier := range slices.Sorted(maps.Keys(result.Fetched)) {
+					fmt.Fprintf(cmd.OutOrStdout(), "Fetched %d hotels from %s\n", result.Fetched[supplier], supplier)
+				}
+				printIssues(cmd.ErrOrStderr(), "Skipped", result.Skipped)
+				for _, term := range slices.Sorted(maps.Keys(result.UnmappedAmenities)) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Unmapped amenity %q used by %d supplier hotels\n", term, result.UnmappedAmenities[term])
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Stored %d hotels, removed %d no supplier returned anymore\n", result.Stored, result.Removed)
+				return nil
+			}, fx.Provide(services.NewHotelChanges, services.NewIngestService))
+		},
+	}
+}
+
+func newExportCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export every stored hotel as NDJSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHotelData(cmd, func(ctx context.Context, hotelDataService domains.HotelDataService) error {
+				var count int
+				err := writeOutput(cmd, output, func(w io.Writer) error {
+					var err error
+					count, err = hotelDataService.Export(ctx, w)
+					return err
+				})
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d hotels\n", count)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write, stdout when empty or -")
+
+	return cmd
+}
+
+func newImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Upsert hotels from an NDJSON file, or stdin, through a staging table in one transaction",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, closeInput, err := openInput(cmd, args)
+			if err != nil {
+				return err
+			}
+			defer closeInput()
+
+			return runHotelData(cmd, func(ctx context.Context, hotelDataService domains.HotelDataService) error {
+				result, err := hotelDataService.Import(ctx, input)
+				if err != nil {
+					return err
+				}
+
+				if len(result.Errors) > 0 {
+					printIssues(cmd.ErrOrStderr(), "Invalid", result.Errors)
+					return fmt.Errorf("%d invalid record(s), nothing imported", len(result.Errors))
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Imported %d hotels, %d inserted and %d updated\n", result.Imported, result.Inserted, result.Updated)
+				return nil
+			})
+		},
+	}
+}
+
+func newValidateDataCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "validate-data",
+		Short: "Check the stored hotels, or an NDJSON file, against the import rules",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var issues []domains.DataIssue
+			if file != "" {
+				input, closeInput, err := openInput(cmd, []string{file})
+				if err != nil {
+					return err
+				}
+				defer closeInput()
+
+				// A file is validated without touching the database, so no fx app is needed.
+				_, issues, err = services.ParseHotels(input)
+				if err != nil {
+					return err
+				}
+			} else {
+				err := runHotelData(cmd, func(ctx context.Context, hotelDataService domains.HotelDataService) error {
+					var err error
+					issues, err = hotelDataService.Validate(ctx)
+					return err
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(issues) > 0 {
+				printIssues(cmd.OutOrStdout(), "Invalid", issues)
+				return fmt.Errorf("found %d issue(s)", len(issues))
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "No issues found")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Validate this NDJSON file, - for stdin, instead of the stored hotels")
+
+	return cmd
+}
+
+// openInput opens the file named by the first argument, or stdin when there is none or it is -.
+func openInput(cmd *cobra.Command, args []string) (io.Reader, func(), error) {
+	if len(args) == 0 || args[0] == "-" {
+		return cmd.InOrStdin(), func() {}, nil
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, func() { _ = file.Close() }, nil
+}
+
+// writeOutput calls write with the file named by output, or stdout when it is empty or -. The file is removed when
+// write or closing it fails, so that no partial file is left behind.
+func writeOutput(cmd *cobra.Command, output string, write func(w io.Writer) error) error {
+	if output == "" || output == "-" {
+		return write(cmd.OutOrStdout())
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(output)
+		return err
+	}
+
+	return nil
+}
+
+func printIssues(w io.Writer, prefix string, issues []domains.DataIssue) {
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s: %s\n", prefix, issue)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func newHotelsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hotels",
+		Short: "Look up stored hotels",
+	}
+
+	cmd.AddCommand(newHotelsGetCommand())
+
+	return cmd
+}
+
+func newHotelsGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <hotel_id>",
+		Short: "Print a hotel as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTask(cmd, func(ctx context.Context, hotelService domains.HotelService) error {
+				hotel, err := hotelService.FindByHotelID(ctx, args[0])
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("hotel %s not found", args[0])
+				} else if err != nil {
+					return err
+				}
+
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(hotel)
+			}, fx.Provide(services.NewHotelService))
+		},
+	}
+}
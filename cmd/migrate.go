@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert or inspect the embedded database migrations",
+	}
+
+	cmd.AddCommand(
+		newMigrateUpCommand(),
+		newMigrateDownCommand(),
+		newMigrateStatusCommand(),
+	)
+
+	return cmd
+}
+
+func runMigration(cmd *cobra.Command, task func(ctx context.Context, migrationService domains.MigrationService) error) error {
+	return runTask(cmd, task, fx.Provide(services.NewMigrationService))
+}
+
+func parseCount(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+
+	count, err := strconv.Atoi(args[0])
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("count must be a positive integer, got %q", args[0])
+	}
+
+	return count, nil
+}
+
+func newMigrateUpCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up [count]",
+		Short: "Apply pending migrations, all of them unless count is given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := parseCount(args, 0)
+			if err != nil {
+				return err
+			}
+
+			return runMigration(cmd, func(ctx context.Context, migrationService domains.MigrationService) error {
+				applied, err := migrationService.Up(ctx, count)
+				for _, migration := range applied {
+					fmt.Fprintf(cmd.OutOrStdout(), "Applied %s_%s\n", migration.Version, migration.Description)
+				}
+				if err == nil && len(applied) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No pending migrations")
+				}
+				return err
+			})
+		},
+	}
+}
+
+func newMigrateDownCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down [count]",
+		Short: "Revert the latest applied migration, or the latest count",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := parseCount(args, 1)
+			if err != nil {
+				return err
+			}
+
+			return runMigration(cmd, func(ctx context.Context, migrationService domains.MigrationService) error {
+				reverted, err := migrationService.Down(ctx, count)
+				for _, migration := range reverted {
+					fmt.Fprintf(cmd.OutOrStdout(), "Reverted %s_%s\n", migration.Version, migration.Description)
+				}
+				if err == nil && len(reverted) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No applied migrations")
+				}
+				return err
+			})
+		},
+	}
+}
+
+func newMigrateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigration(cmd, func(ctx context.Context, migrationService domains.MigrationService) error {
+				status, err := migrationService.Status(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tDESCRIPTION\tSTATE\tEXECUTED AT")
+				for _, migration := range status.Migrations {
+					executedAt := "-"
+					if migration.ExecutedAt != nil {
+						executedAt = migration.ExecutedAt.Format(time.RFC3339)
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", migration.Version, migration.Description, migration.State, executedAt)
+				}
+				if err := w.Flush(); err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "\nCurrent version: %s, latest version: %s, pending: %d\n", status.CurrentVersion, status.LatestVersion, status.Pending)
+				return nil
+			})
+		},
+	}
+}
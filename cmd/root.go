@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/spf13/cobra"
+)
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "app",
+		Short:         "Serve and maintain the merged hotels catalogue",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		// Running without a subcommand serves the API, so existing deployments keep working.
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd)
+		},
+	}
+
+	config.BindFlags(root.PersistentFlags())
+
+	root.AddCommand(
+		newServeCommand(),
+		newConfigCommand(),
+		newMigrateCommand(),
+		newIngestCommand(),
+		newExportCommand(),
+		newImportCommand(),
+		newValidateDataCommand(),
+		newHotelsCommand(),
+		newSnapshotCommand(),
+	)
+
+	return root
+}
+
+func loadOptions(cmd *cobra.Command) *config.LoadOptions {
+	return &config.LoadOptions{Flags: cmd.Flags()}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/duylamasd/hotels-merge/bootstrap"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func newServeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP API",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd)
+		},
+	}
+}
+
+func runServe(cmd *cobra.Command) error {
+	app := fx.New(
+		bootstrap.Modules,
+		fx.Supply(loadOptions(cmd)),
+	)
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	app.Run()
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+func newSnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export or restore a checksummed archive of the catalogue",
+	}
+
+	cmd.AddCommand(
+		newSnapshotExportCommand(),
+		newSnapshotRestoreCommand(),
+	)
+
+	return cmd
+}
+
+func runSnapshot(cmd *cobra.Command, task func(ctx context.Context, snapshotService domains.SnapshotService) error) error {
+	return runTask(cmd, task, fx.Provide(services.NewHotelChanges, services.NewMigrationService, services.NewSnapshotService))
+}
+
+func printManifest(cmd *cobra.Command, action string, manifest *domains.SnapshotManifest) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s snapshot of schema version %s created at %s\n", action, manifest.SchemaVersion, manifest.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	for _, file := range manifest.Files {
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s: %d records, sha256 %s\n", file.Name, file.Records, file.SHA256)
+	}
+}
+
+func newSnapshotExportCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the hotels, destinations, duplicates, redirects, translations and applied schema revisions to a .tar.gz archive",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSnapshot(cmd, func(ctx context.Context, snapshotService domains.SnapshotService) error {
+				var manifest *domains.SnapshotManifest
+				err := writeOutput(cmd, output, func(w io.Writer) error {
+					var err error
+					manifest, err = snapshotService.Export(ctx, w)
+					return err
+				})
+				if err != nil {
+					return err
+				}
+
+				printManifest(cmd, "Exported", manifest)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "snapshot.tar.gz", "Archive to write, - for stdout")
+
+	return cmd
+}
+
+func newSnapshotRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [archive]",
+		Short: "Load an archive into an empty database migrated to the snapshot schema version",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, closeInput, err := openInput(cmd, args)
+			if err != nil {
+				return err
+			}
+			defer closeInput()
+
+			return runSnapshot(cmd, func(ctx context.Context, snapshotService domains.SnapshotService) error {
+				manifest, err := snapshotService.Restore(ctx, input)
+				if err != nil {
+					return err
+				}
+
+				printManifest(cmd, "Restored", manifest)
+				return nil
+			})
+		},
+	}
+}
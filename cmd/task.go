@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+
+	"github.com/duylamasd/hotels-merge/bootstrap"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// runTask starts the core modules plus options, runs task with the populated dependency and stops the app.
+func runTask[T any](cmd *cobra.Command, task func(ctx context.Context, dependency T) error, options ...fx.Option) error {
+	var dependency T
+
+	app := fx.New(
+		fx.NopLogger,
+		bootstrap.CoreModules,
+		fx.Supply(loadOptions(cmd)),
+		fx.Options(options...),
+		fx.Populate(&dependency),
+	)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	startCtx, cancel := context.WithTimeout(ctx, app.StartTimeout())
+	defer cancel()
+	if err := app.Start(startCtx); err != nil {
+		return err
+	}
+
+	taskErr := task(ctx, dependency)
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+	defer cancel()
+	if err := app.Stop(stopCtx); err != nil && taskErr == nil {
+		return err
+	}
+
+	return taskErr
+}
@@ -1,29 +1,191 @@
 package config
 
 import (
-	"os"
+	"time"
 
 	"go.uber.org/fx"
 )
 
 type Config struct {
-	DBUri    string
-	Port     string
-	Env      string
-	LogLevel string
+	Env       string          `config:"env" usage:"Runtime environment, production enables JSON logs"`
+	Server    ServerConfig    `config:"server"`
+	Database  DatabaseConfig  `config:"database"`
+	Log       LogConfig       `config:"log"`
+	Readiness ReadinessConfig `config:"readiness"`
+	Tracing   TracingConfig   `config:"tracing"`
+	Cache     CacheConfig     `config:"cache"`
+	RateLimit RateLimitConfig `config:"rate_limit"`
+	Auth      AuthConfig      `config:"auth"`
+	Reload    ReloadConfig    `config:"reload"`
+	Ingest    IngestConfig    `config:"ingest"`
+	Features  []string        `config:"features" usage:"Enabled feature flags, comma separated"`
 }
 
-func NewConfig() *Config {
+type ServerConfig struct {
+	Port            string        `config:"port" env:"PORT" usage:"TCP port the HTTP server listens on"`
+	ReadTimeout     time.Duration `config:"read_timeout" usage:"Maximum duration for reading a request"`
+	WriteTimeout    time.Duration `config:"write_timeout" usage:"Maximum duration before timing out writes of a response"`
+	IdleTimeout     time.Duration `config:"idle_timeout" usage:"Maximum time to wait for the next request on keep-alive connections"`
+	ShutdownTimeout time.Duration `config:"shutdown_timeout" usage:"Maximum time to drain in-flight requests on shutdown"`
+	DrainDelay      time.Duration `config:"drain_delay" usage:"Time to keep serving after failing readiness on shutdown"`
+}
+
+type DatabaseConfig struct {
+	Backend              string        `config:"backend" usage:"Storage backend (postgres, memory), memory keeps hotels in the process for local development and demos"`
+	MemorySeed           string        `config:"memory_seed" usage:"NDJSON file of hotels loaded at startup by the memory backend"`
+	URI                  string        `config:"uri" env:"DB_URI" secret:"true" usage:"PostgreSQL connection URI"`
+	MaxConns             int32         `config:"max_conns" usage:"Maximum number of connections in the pool"`
+	MinConns             int32         `config:"min_conns" usage:"Minimum number of idle connections kept in the pool"`
+	ReplicaURIs          []string      `config:"replica_uris" env:"DB_REPLICA_URIS" secret:"true" usage:"Read replica connection URIs, comma separated"`
+	ReplicaMaxLag        time.Duration `config:"replica_max_lag" usage:"Replicas lagging behind the primary by more than this stop serving reads"`
+	ReplicaCheckInterval time.Duration `config:"replica_check_interval" usage:"How often replica health and lag are checked"`
+	MaxConnLifetime      time.Duration `config:"max_conn_lifetime" usage:"Maximum age of a connection before it is closed"`
+	MaxConnIdleTime      time.Duration `config:"max_conn_idle_time" usage:"Maximum time a connection can stay idle before it is closed"`
+	HealthCheckPeriod    time.Duration `config:"health_check_period" usage:"How often idle connections are health checked"`
+	ConnectTimeout       time.Duration `config:"connect_timeout" usage:"Maximum time to establish a connection"`
+	StatementTimeout     time.Duration `config:"statement_timeout" usage:"Server-side statement_timeout of every connection, 0 disables it"`
+	QueryTimeout         time.Duration `config:"query_timeout" usage:"Deadline of each service call to the database, including retries"`
+	Retry                RetryConfig   `config:"retry"`
+	SchemaCheck          bool          `config:"schema_check" usage:"Refuse to serve when the database schema is behind the embedded migrations"`
+}
+
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory"
+)
+
+type RetryConfig struct {
+	MaxAttempts    int           `config:"max_attempts" usage:"Attempts for queries failing with serialization or connection errors, 1 disables retries"`
+	InitialBackoff time.Duration `config:"initial_backoff" usage:"Backoff before the first retry, doubled on each attempt"`
+	MaxBackoff     time.Duration `config:"max_backoff" usage:"Maximum backoff between retries"`
+}
+
+type LogConfig struct {
+	Level string `config:"level" usage:"Minimum log level (debug, info, warn, error)"`
+}
+
+type ReadinessConfig struct {
+	MaxSyncAge time.Duration `config:"max_sync_age" usage:"Fail readiness when the last sync is older than this, 0 disables the check"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `config:"exporter" usage:"Span exporter (none, otlp, stdout)"`
+	OTLPEndpoint string  `config:"otlp_endpoint" usage:"OTLP/HTTP collector endpoint"`
+	OTLPInsecure bool    `config:"otlp_insecure" usage:"Disable TLS for the OTLP exporter"`
+	SampleRatio  float64 `config:"sample_ratio" usage:"Ratio of new traces to sample, between 0 and 1"`
+}
+
+type CacheConfig struct {
+	Enabled    bool          `config:"enabled" usage:"Cache hotel lookups in memory"`
+	TTL        time.Duration `config:"ttl" usage:"Time to live of cached hotel lookups"`
+	MaxEntries int           `config:"max_entries" usage:"Maximum number of cached hotel lookups"`
+}
+
+type RateLimitConfig struct {
+	Enabled           bool    `config:"enabled" usage:"Rate limit API requests per client IP"`
+	RequestsPerSecond float64 `config:"requests_per_second" usage:"Sustained requests per second allowed per client IP"`
+	Burst             int     `config:"burst" usage:"Maximum burst of requests allowed per client IP"`
+}
+
+type AuthConfig struct {
+	AdminToken string `config:"admin_token" secret:"true" usage:"Bearer token required by admin endpoints, empty disables them"`
+}
+
+type ReloadConfig struct {
+	WatchInterval time.Duration `config:"watch_interval" usage:"How often the config file is checked for changes, 0 only reloads on SIGHUP"`
+}
+
+type IngestConfig struct {
+	AcmeURL       string        `config:"acme_url" usage:"URL of the acme supplier, http(s) or file://"`
+	PatagoniaURL  string        `config:"patagonia_url" usage:"URL of the patagonia supplier, http(s) or file://"`
+	PaperfliesURL string        `config:"paperflies_url" usage:"URL of the paperflies supplier, http(s) or file://"`
+	Timeout       time.Duration `config:"timeout" usage:"Timeout of each supplier request"`
+}
+
+// FeatureEnabled reports whether the feature flag is set, see Watcher.FeatureEnabled for reloaded flags.
+func (c *Config) FeatureEnabled(name string) bool {
+	for _, feature := range c.Features {
+		if feature == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func Default() *Config {
 	return &Config{
-		DBUri:    os.Getenv("DB_URI"),
-		Port:     os.Getenv("PORT"),
-		Env:      os.Getenv("ENV"),
-		LogLevel: os.Getenv("LOG_LEVEL"),
+		Env: "development",
+		Server: ServerConfig{
+			Port:            "8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Backend:              BackendPostgres,
+			MaxConns:             10,
+			ReplicaURIs:          []string{},
+			ReplicaMaxLag:        10 * time.Second,
+			ReplicaCheckInterval: 5 * time.Second,
+			MaxConnLifetime:      time.Hour,
+			MaxConnIdleTime:      30 * time.Minute,
+			HealthCheckPeriod:    time.Minute,
+			ConnectTimeout:       5 * time.Second,
+			StatementTimeout:     5 * time.Second,
+			QueryTimeout:         10 * time.Second,
+			SchemaCheck:          true,
+			Retry: RetryConfig{
+				MaxAttempts:    3,
+				InitialBackoff: 50 * time.Millisecond,
+				MaxBackoff:     time.Second,
+			},
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "localhost:4318",
+			SampleRatio:  1,
+		},
+		Cache: CacheConfig{
+			TTL:        time.Minute,
+			MaxEntries: 1000,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 20,
+			Burst:             40,
+		},
+		Reload: ReloadConfig{
+			WatchInterval: 5 * time.Second,
+		},
+		Ingest: IngestConfig{
+			Timeout: 30 * time.Second,
+		},
+		Features: []string{},
+	}
+}
+
+type ConfigParams struct {
+	fx.In
+
+	Options *LoadOptions `optional:"true"`
+}
+
+func NewConfig(params ConfigParams) (*Config, error) {
+	var options LoadOptions
+	if params.Options != nil {
+		options = *params.Options
 	}
+
+	return Load(options)
 }
 
 var Module = fx.Options(
 	fx.Provide(NewConfig),
+	fx.Provide(NewWatcher),
 	fx.Provide(NewDBConn),
 	fx.Provide(NewDBStore),
 )
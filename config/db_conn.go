@@ -2,14 +2,20 @@ package config
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 )
 
-func NewDBConn(lc fx.Lifecycle, config *Config) (*pgxpool.Pool, error) {
-	ctx := context.Background()
-	conn, err := pgxpool.New(ctx, config.DBUri)
+// NewDBConn returns a nil pool with the memory backend.
+func NewDBConn(lc fx.Lifecycle, config *Config, tracerProvider trace.TracerProvider) (*pgxpool.Pool, error) {
+	if config.Database.Backend == BackendMemory {
+		return nil, nil
+	}
+
+	conn, err := newPool(config.Database.URI, config, tracerProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -23,3 +29,24 @@ func NewDBConn(lc fx.Lifecycle, config *Config) (*pgxpool.Pool, error) {
 
 	return conn, nil
 }
+
+// newPool applies the pool settings of config to a pool connecting to uri, used for the primary and replicas alike.
+func newPool(uri string, config *Config, tracerProvider trace.TracerProvider) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.MaxConns = config.Database.MaxConns
+	poolConfig.MinConns = config.Database.MinConns
+	poolConfig.MaxConnLifetime = config.Database.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = config.Database.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = config.Database.HealthCheckPeriod
+	poolConfig.ConnConfig.ConnectTimeout = config.Database.ConnectTimeout
+	if config.Database.StatementTimeout > 0 {
+		// Bounds every statement server side, so a query outliving its request cannot keep a connection busy.
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.Database.StatementTimeout.Milliseconds(), 10)
+	}
+	poolConfig.ConnConfig.Tracer = NewQueryTracer(tracerProvider)
+
+	return pgxpool.NewWithConfig(context.Background(), poolConfig)
+}
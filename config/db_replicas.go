@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const replicaCheckTimeout = 2 * time.Second
+
+// replicaLagQuery reports 0 for a caught up replica, or for a plain database, so that two independent
+// local databases can stand in for a primary and a replica.
+const replicaLagQuery = `SELECT CASE
+  WHEN NOT pg_is_in_recovery() THEN 0
+  WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+  ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::float8`
+
+type replicaState struct {
+	healthy   bool
+	lag       time.Duration
+	lastError string
+	checkedAt time.Time
+}
+
+// Replica is a read replica whose health and replication lag are checked periodically.
+type Replica struct {
+	Name     string
+	Queries  sqlc.Querier
+	ConnPool *pgxpool.Pool
+
+	mu    sync.RWMutex
+	state replicaState
+}
+
+func NewReplica(name string, connPool *pgxpool.Pool, queries sqlc.Querier) *Replica {
+	return &Replica{
+		Name:     name,
+		Queries:  queries,
+		ConnPool: connPool,
+	}
+}
+
+func (r *Replica) Healthy() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.state.healthy
+}
+
+// State returns whether the replica serves reads, its last measured lag, the last check error and when it was checked.
+func (r *Replica) State() (healthy bool, lag time.Duration, lastError string, checkedAt time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.state.healthy, r.state.lag, r.state.lastError, r.state.checkedAt
+}
+
+func (r *Replica) setState(state replicaState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = state
+}
+
+// Check measures the replication lag and marks the replica unhealthy when it is unreachable or lags more than maxLag.
+func (r *Replica) Check(ctx context.Context, maxLag time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	state := replicaState{checkedAt: time.Now()}
+
+	var lagSeconds float64
+	err := r.ConnPool.QueryRow(ctx, replicaLagQuery).Scan(&lagSeconds)
+	switch {
+	case err != nil:
+		state.lastError = err.Error()
+	default:
+		state.lag = time.Duration(lagSeconds * float64(time.Second))
+		if state.lag > maxLag {
+			err = fmt.Errorf("replication lag %s exceeds %s", state.lag.Round(time.Millisecond), maxLag)
+			state.lastError = err.Error()
+		} else {
+			state.healthy = true
+		}
+	}
+
+	r.setState(state)
+	return err
+}
+
+type replicaSet struct {
+	replicas []*Replica
+	next     atomic.Uint64
+	maxLag   time.Duration
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// pick round-robins over healthy replicas and returns nil when none is healthy.
+func (s *replicaSet) pick() *Replica {
+	count := len(s.replicas)
+	if count == 0 {
+		return nil
+	}
+
+	start := s.next.Add(1)
+	for i := range count {
+		replica := s.replicas[(start+uint64(i))%uint64(count)]
+		if replica.Healthy() {
+			return replica
+		}
+	}
+
+	return nil
+}
+
+func (s *replicaSet) checkAll(ctx context.Context) {
+	for _, replica := range s.replicas {
+		wasHealthy := replica.Healthy()
+		err := replica.Check(ctx, s.maxLag)
+
+		switch {
+		case err != nil && wasHealthy:
+			s.logger.Warn("Read replica removed from rotation, reads fall back to other replicas or the primary", zap.String("replica", replica.Name), zap.Error(err))
+		case err == nil && !wasHealthy:
+			s.logger.Info("Read replica added to rotation", zap.String("replica", replica.Name))
+		}
+	}
+}
+
+func (s *replicaSet) watch() func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	ticker := time.NewTicker(s.interval)
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-ticker.C:
+				s.checkAll(context.Background())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+	connectionExceptionClass = "08"
+	adminShutdownCode        = "57P01"
+	cannotConnectNowCode     = "57P03"
+)
+
+// IsRetryableError reports whether a query failed on a serialization conflict or a broken connection,
+// which are worth retrying. Timeouts and cancellations are never retried.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == serializationFailureCode, pgErr.Code == deadlockDetectedCode:
+			return true
+		case pgErr.Code == adminShutdownCode, pgErr.Code == cannotConnectNowCode:
+			return true
+		case len(pgErr.Code) == 5 && pgErr.Code[:2] == connectionExceptionClass:
+			return true
+		}
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	return pgconn.SafeToRetry(err)
+}
+
+// Retry calls fn until it succeeds, fails with a non-retryable error, runs out of attempts or ctx is done.
+// Backoff doubles after each attempt, capped at MaxBackoff, with full jitter.
+func Retry[T any](ctx context.Context, policy RetryConfig, logger *zap.Logger, operation string, fn func(ctx context.Context) (T, error)) (T, error) {
+	backoff := policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !IsRetryableError(err) {
+			return result, err
+		}
+
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1))
+		logger.Warn("Retrying query after a transient error",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
+
+type retryingQuerier struct {
+	next   sqlc.Querier
+	policy RetryConfig
+	logger *zap.Logger
+}
+
+// NewRetryingQuerier retries every query of next that fails with a retryable error.
+func NewRetryingQuerier(next sqlc.Querier, policy RetryConfig, logger *zap.Logger) sqlc.Querier {
+	return &retryingQuerier{
+		next:   next,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+func (q *retryingQuerier) CountDestinations(ctx context.Context) (int64, error) {
+	return Retry(ctx, q.policy, q.logger, "CountDestinations", q.next.CountDestinations)
+}
+
+func (q *retryingQuerier) CountHotels(ctx context.Context) (int64, error) {
+	return Retry(ctx, q.policy, q.logger, "CountHotels", q.next.CountHotels)
+}
+
+func (q *retryingQuerier) CountHotelsByDestination(ctx context.Context) ([]*sqlc.CountHotelsByDestinationRow, error) {
+	return Retry(ctx, q.policy, q.logger, "CountHotelsByDestination", q.next.CountHotelsByDestination)
+}
+
+func (q *retryingQuerier) DecideHotelDuplicate(ctx context.Context, arg sqlc.DecideHotelDuplicateParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "DecideHotelDuplicate", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.DecideHotelDuplicate(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) DeleteHotel(ctx context.Context, hotelID string) error {
+	_, err := Retry(ctx, q.policy, q.logger, "DeleteHotel", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.DeleteHotel(ctx, hotelID)
+	})
+	return err
+}
+
+func (q *retryingQuerier) DeleteHotelTranslation(ctx context.Context, arg sqlc.DeleteHotelTranslationParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "DeleteHotelTranslation", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.DeleteHotelTranslation(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) DeletePendingHotelDuplicates(ctx context.Context) error {
+	_, err := Retry(ctx, q.policy, q.logger, "DeletePendingHotelDuplicates", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.DeletePendingHotelDuplicates(ctx)
+	})
+	return err
+}
+
+func (q *retryingQuerier) DeletePendingHotelDuplicatesOfHotel(ctx context.Context, hotelID string) error {
+	_, err := Retry(ctx, q.policy, q.logger, "DeletePendingHotelDuplicatesOfHotel", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.DeletePendingHotelDuplicatesOfHotel(ctx, hotelID)
+	})
+	return err
+}
+
+func (q *retryingQuerier) FindDestination(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+	return Retry(ctx, q.policy, q.logger, "FindDestination", func(ctx context.Context) (*sqlc.FindDestinationRow, error) {
+		return q.next.FindDestination(ctx, id)
+	})
+}
+
+func (q *retryingQuerier) FindHotelByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelByHotelID", func(ctx context.Context) (*sqlc.Hotel, error) {
+		return q.next.FindHotelByHotelID(ctx, hotelID)
+	})
+}
+
+func (q *retryingQuerier) FindHotelDuplicate(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelDuplicate", func(ctx context.Context) (*sqlc.HotelDuplicate, error) {
+		return q.next.FindHotelDuplicate(ctx, id)
+	})
+}
+
+func (q *retryingQuerier) FindHotelTranslation(ctx context.Context, arg sqlc.FindHotelTranslationParams) (*sqlc.HotelTranslation, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelTranslation", func(ctx context.Context) (*sqlc.HotelTranslation, error) {
+		return q.next.FindHotelTranslation(ctx, arg)
+	})
+}
+
+func (q *retryingQuerier) FindHotelsByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelsByCountryCode", func(ctx context.Context) ([]*sqlc.Hotel, error) {
+		return q.next.FindHotelsByCountryCode(ctx, countryCode)
+	})
+}
+
+func (q *retryingQuerier) FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg sqlc.FindHotelsByDestinationAndHotelIDsParams) ([]*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelsByDestinationAndHotelIDs", func(ctx context.Context) ([]*sqlc.Hotel, error) {
+		return q.next.FindHotelsByDestinationAndHotelIDs(ctx, arg)
+	})
+}
+
+func (q *retryingQuerier) FindHotelsByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelsByDestinationID", func(ctx context.Context) ([]*sqlc.Hotel, error) {
+		return q.next.FindHotelsByDestinationID(ctx, destinationID)
+	})
+}
+
+func (q *retryingQuerier) FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) ([]*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "FindHotelsByHotelIDs", func(ctx context.Context) ([]*sqlc.Hotel, error) {
+		return q.next.FindHotelsByHotelIDs(ctx, hotelIds)
+	})
+}
+
+func (q *retryingQuerier) GetLastSyncedAt(ctx context.Context) (pgtype.Timestamptz, error) {
+	return Retry(ctx, q.policy, q.logger, "GetLastSyncedAt", q.next.GetLastSyncedAt)
+}
+
+func (q *retryingQuerier) InsertMissingDestinations(ctx context.Context) error {
+	_, err := Retry(ctx, q.policy, q.logger, "InsertMissingDestinations", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.InsertMissingDestinations(ctx)
+	})
+	return err
+}
+
+func (q *retryingQuerier) ListAllHotelTranslations(ctx context.Context) ([]*sqlc.HotelTranslation, error) {
+	return Retry(ctx, q.policy, q.logger, "ListAllHotelTranslations", q.next.ListAllHotelTranslations)
+}
+
+func (q *retryingQuerier) ListDestinations(ctx context.Context, arg sqlc.ListDestinationsParams) ([]*sqlc.ListDestinationsRow, error) {
+	return Retry(ctx, q.policy, q.logger, "ListDestinations", func(ctx context.Context) ([]*sqlc.ListDestinationsRow, error) {
+		return q.next.ListDestinations(ctx, arg)
+	})
+}
+
+func (q *retryingQuerier) ListHotelDuplicates(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error) {
+	return Retry(ctx, q.policy, q.logger, "ListHotelDuplicates", func(ctx context.Context) ([]*sqlc.HotelDuplicate, error) {
+		return q.next.ListHotelDuplicates(ctx, status)
+	})
+}
+
+func (q *retryingQuerier) ListHotelRedirects(ctx context.Context) ([]*sqlc.HotelRedirect, error) {
+	return Retry(ctx, q.policy, q.logger, "ListHotelRedirects", q.next.ListHotelRedirects)
+}
+
+func (q *retryingQuerier) ListHotelTranslations(ctx context.Context, hotelIds []string) ([]*sqlc.HotelTranslation, error) {
+	return Retry(ctx, q.policy, q.logger, "ListHotelTranslations", func(ctx context.Context) ([]*sqlc.HotelTranslation, error) {
+		return q.next.ListHotelTranslations(ctx, hotelIds)
+	})
+}
+
+func (q *retryingQuerier) ListHotels(ctx context.Context) ([]*sqlc.Hotel, error) {
+	return Retry(ctx, q.policy, q.logger, "ListHotels", q.next.ListHotels)
+}
+
+func (q *retryingQuerier) RepointHotelRedirects(ctx context.Context, arg sqlc.RepointHotelRedirectsParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RepointHotelRedirects", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RepointHotelRedirects(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) ResetHotelDuplicateIDSequence(ctx context.Context) error {
+	_, err := Retry(ctx, q.policy, q.logger, "ResetHotelDuplicateIDSequence", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.ResetHotelDuplicateIDSequence(ctx)
+	})
+	return err
+}
+
+func (q *retryingQuerier) ResetHotelIDSequence(ctx context.Context) error {
+	_, err := Retry(ctx, q.policy, q.logger, "ResetHotelIDSequence", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.ResetHotelIDSequence(ctx)
+	})
+	return err
+}
+
+func (q *retryingQuerier) RestoreDestination(ctx context.Context, arg sqlc.RestoreDestinationParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RestoreDestination", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RestoreDestination(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) RestoreHotel(ctx context.Context, arg sqlc.RestoreHotelParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RestoreHotel", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RestoreHotel(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) RestoreHotelDuplicate(ctx context.Context, arg sqlc.RestoreHotelDuplicateParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RestoreHotelDuplicate", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RestoreHotelDuplicate(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) RestoreHotelRedirect(ctx context.Context, arg sqlc.RestoreHotelRedirectParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RestoreHotelRedirect", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RestoreHotelRedirect(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) RestoreHotelTranslation(ctx context.Context, arg sqlc.RestoreHotelTranslationParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "RestoreHotelTranslation", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.RestoreHotelTranslation(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) UpsertDestination(ctx context.Context, arg sqlc.UpsertDestinationParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "UpsertDestination", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.UpsertDestination(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) UpsertHotel(ctx context.Context, arg sqlc.UpsertHotelParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "UpsertHotel", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.UpsertHotel(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) UpsertHotelDuplicate(ctx context.Context, arg sqlc.UpsertHotelDuplicateParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "UpsertHotelDuplicate", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.UpsertHotelDuplicate(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) UpsertHotelRedirect(ctx context.Context, arg sqlc.UpsertHotelRedirectParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "UpsertHotelRedirect", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.UpsertHotelRedirect(ctx, arg)
+	})
+	return err
+}
+
+func (q *retryingQuerier) UpsertHotelTranslation(ctx context.Context, arg sqlc.UpsertHotelTranslationParams) error {
+	_, err := Retry(ctx, q.policy, q.logger, "UpsertHotelTranslation", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, q.next.UpsertHotelTranslation(ctx, arg)
+	})
+	return err
+}
@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "statement timeout", err: &pgconn.PgError{Code: "57014"}, want: false},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: false},
+		{name: "other", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.IsRetryableError(tt.err))
+		})
+	}
+}
+
+func TestRetryingQuerier(t *testing.T) {
+	policy := config.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	ctx := context.Background()
+
+	t.Run("should retry transient errors until success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockQuerier(ctrl)
+		querier := config.NewRetryingQuerier(next, policy, zap.NewNop())
+
+		hotel := &sqlc.Hotel{HotelID: "hotel_1"}
+		gomock.InOrder(
+			next.EXPECT().FindHotelByHotelID(ctx, "hotel_1").Return(nil, &pgconn.PgError{Code: "40001"}),
+			next.EXPECT().FindHotelByHotelID(ctx, "hotel_1").Return(hotel, nil),
+		)
+
+		result, err := querier.FindHotelByHotelID(ctx, "hotel_1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, hotel, result)
+	})
+
+	t.Run("should give up after the maximum attempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockQuerier(ctrl)
+		querier := config.NewRetryingQuerier(next, policy, zap.NewNop())
+
+		next.EXPECT().FindHotelsByDestinationID(ctx, "dest_1").Return(nil, &pgconn.PgError{Code: "08006"}).Times(3)
+
+		_, err := querier.FindHotelsByDestinationID(ctx, "dest_1")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not retry other errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockQuerier(ctrl)
+		querier := config.NewRetryingQuerier(next, policy, zap.NewNop())
+
+		next.EXPECT().FindHotelsByHotelIDs(ctx, []string{"hotel_1"}).Return(nil, &pgconn.PgError{Code: "57014"}).Times(1)
+
+		_, err := querier.FindHotelsByHotelIDs(ctx, []string{"hotel_1"})
+
+		assert.Error(t, err)
+	})
+}
@@ -1,18 +1,162 @@
 package config
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/duylamasd/hotels-merge/db/memory"
 	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
+// DBStore holds the primary, used for writes and admin traffic, and the read replicas.
+// With the memory backend it holds an in-memory store and ConnPool is nil.
 type DBStore struct {
 	Queries  sqlc.Querier
 	ConnPool *pgxpool.Pool
+
+	memory   *memory.Store
+	replicas *replicaSet
+	retry    RetryConfig
+	logger   *zap.Logger
 }
 
-func NewDBStore(connPool *pgxpool.Pool) *DBStore {
-	return &DBStore{
-		Queries:  sqlc.New(connPool),
+func NewDBStore(
+	lc fx.Lifecycle,
+	connPool *pgxpool.Pool,
+	config *Config,
+	logger *zap.Logger,
+	tracerProvider trace.TracerProvider,
+) (*DBStore, error) {
+	if config.Database.Backend == BackendMemory {
+		logger.Warn("Using the in-memory storage backend, hotels are lost when the process exits")
+		return NewMemoryDBStore(memory.New()), nil
+	}
+
+	store := &DBStore{
+		Queries:  NewRetryingQuerier(sqlc.New(connPool), config.Database.Retry, logger),
 		ConnPool: connPool,
+		retry:    config.Database.Retry,
+		logger:   logger,
+	}
+
+	if len(config.Database.ReplicaURIs) == 0 {
+		return store, nil
+	}
+
+	store.replicas = &replicaSet{
+		maxLag:   config.Database.ReplicaMaxLag,
+		interval: config.Database.ReplicaCheckInterval,
+		logger:   logger,
+	}
+	for _, uri := range config.Database.ReplicaURIs {
+		pool, err := newPool(uri, config, tracerProvider)
+		if err != nil {
+			store.closeReplicas()
+			return nil, fmt.Errorf("could not configure read replica: %w", err)
+		}
+
+		poolConfig := pool.Config().ConnConfig
+		name := fmt.Sprintf("%s:%d/%s", poolConfig.Host, poolConfig.Port, poolConfig.Database)
+		queries := NewRetryingQuerier(sqlc.New(pool), config.Database.Retry, logger.With(zap.String("replica", name)))
+		store.replicas.replicas = append(store.replicas.replicas, NewReplica(name, pool, queries))
+	}
+
+	var stop func()
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// Replicas only join the rotation once checked, an unreachable replica does not block startup.
+			store.replicas.checkAll(ctx)
+			stop = store.replicas.watch()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if stop != nil {
+				stop()
+			}
+			store.closeReplicas()
+			return nil
+		},
+	})
+
+	return store, nil
+}
+
+// NewMemoryDBStore stores hotels in store instead of a database.
+func NewMemoryDBStore(store *memory.Store) *DBStore {
+	return &DBStore{
+		Queries: store.Queries(),
+		memory:  store,
+	}
+}
+
+// InMemory reports whether hotels are kept in memory, without a database.
+func (s *DBStore) InMemory() bool {
+	return s.memory != nil
+}
+
+// Reader returns the queries of a healthy replica, or of the primary when no replica is healthy.
+// Only read-only queries may be run through it.
+func (s *DBStore) Reader() sqlc.Querier {
+	if s.replicas != nil {
+		if replica := s.replicas.pick(); replica != nil {
+			return replica.Queries
+		}
+	}
+
+	return s.Queries
+}
+
+// InTx runs fn in a transaction on the primary, committed when fn succeeds. The whole transaction is
+// retried on retryable errors, so fn must not have side effects outside the database.
+func (s *DBStore) InTx(ctx context.Context, fn func(queries sqlc.Querier) error) error {
+	return s.InTxWithOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// InTxWithOptions is InTx with the isolation level and access mode of opts, such as a repeatable read, read only
+// transaction reading every table as of the same moment. The in-memory store ignores opts.
+func (s *DBStore) InTxWithOptions(ctx context.Context, opts pgx.TxOptions, fn func(queries sqlc.Querier) error) error {
+	if s.memory != nil {
+		return s.memory.InTx(ctx, fn)
+	}
+
+	return s.withTxOptions(ctx, opts, func(tx pgx.Tx) error {
+		return fn(sqlc.New(tx))
+	})
+}
+
+// WithTx is InTx for statements sqlc cannot generate, such as COPY or temporary tables. It needs a database.
+func (s *DBStore) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return s.withTxOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+func (s *DBStore) withTxOptions(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	if s.ConnPool == nil {
+		return errors.New("connection pool is not configured")
+	}
+
+	_, err := Retry(ctx, s.retry, s.logger, "WithTx", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, pgx.BeginTxFunc(ctx, s.ConnPool, opts, fn)
+	})
+	return err
+}
+
+// Replicas returns the configured read replicas.
+func (s *DBStore) Replicas() []*Replica {
+	if s.replicas == nil {
+		return nil
+	}
+
+	return s.replicas.replicas
+}
+
+func (s *DBStore) closeReplicas() {
+	for _, replica := range s.Replicas() {
+		replica.ConnPool.Close()
 	}
 }
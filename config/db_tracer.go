@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const dbTracerName = "github.com/duylamasd/hotels-merge/config"
+
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+func NewQueryTracer(tracerProvider trace.TracerProvider) pgx.QueryTracer {
+	return &queryTracer{
+		tracer: tracerProvider.Tracer(dbTracerName),
+	}
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBQueryText(data.SQL),
+			attribute.Int("db.query.args_count", len(data.Args)),
+		),
+	)
+
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	operation, _, _ := strings.Cut(data.CommandTag.String(), " ")
+	span.SetAttributes(
+		semconv.DBOperationName(operation),
+		semconv.DBResponseReturnedRows(int(data.CommandTag.RowsAffected())),
+	)
+}
@@ -0,0 +1,292 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ConfigFileEnv  = "CONFIG_FILE"
+	ConfigFileFlag = "config"
+)
+
+type LoadOptions struct {
+	// File is the YAML or TOML file to load, CONFIG_FILE is used when empty.
+	File string
+	// Flags overrides values with the flags registered by BindFlags that were explicitly set.
+	Flags *pflag.FlagSet
+	// LookupEnv defaults to os.LookupEnv.
+	LookupEnv func(key string) (string, bool)
+}
+
+type field struct {
+	key    string
+	envs   []string
+	secret bool
+	usage  string
+	value  reflect.Value
+}
+
+// file returns the config file set by the options, the --config flag or CONFIG_FILE, in that order.
+func (options LoadOptions) file() string {
+	if options.File != "" {
+		return options.File
+	}
+
+	if options.Flags != nil {
+		if file, _ := options.Flags.GetString(ConfigFileFlag); file != "" {
+			return file
+		}
+	}
+
+	lookupEnv := options.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	file, _ := lookupEnv(ConfigFileEnv)
+
+	return file
+}
+
+// Load builds the configuration from defaults, then the config file, then env vars, then flags, and validates it.
+func Load(options LoadOptions) (*Config, error) {
+	lookupEnv := options.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	cfg := Default()
+	fields := cfg.fields()
+
+	file := options.file()
+	if file != "" {
+		if err := loadFile(file, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		for _, env := range f.envs {
+			if value, ok := lookupEnv(env); ok && value != "" {
+				if err := f.set(value); err != nil {
+					return nil, fmt.Errorf("invalid configuration: %s (from %s): %w", f.key, env, err)
+				}
+				break
+			}
+		}
+	}
+
+	if options.Flags != nil {
+		for _, f := range fields {
+			flag := options.Flags.Lookup(f.key)
+			if flag == nil || !flag.Changed {
+				continue
+			}
+			if err := f.set(flag.Value.String()); err != nil {
+				return nil, fmt.Errorf("invalid configuration: %s (from --%s): %w", f.key, f.key, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// BindFlags registers --config and one flag per configuration key, such as --server.port.
+func BindFlags(flags *pflag.FlagSet) {
+	flags.String(ConfigFileFlag, "", fmt.Sprintf("Path to a YAML or TOML config file (env %s)", ConfigFileEnv))
+
+	for _, f := range Default().fields() {
+		defaultValue := f.string()
+		if f.secret {
+			defaultValue = ""
+		}
+		flags.String(f.key, defaultValue, fmt.Sprintf("%s (env %s)", f.usage, strings.Join(f.envs, ", ")))
+	}
+}
+
+func loadFile(path string, fields []field) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return fmt.Errorf("unsupported config file format %q, expected .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", raw, values)
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("invalid configuration: %s: unknown key in %s", key, path)
+		}
+		if err := f.set(values[key]); err != nil {
+			return fmt.Errorf("invalid configuration: %s (from %s): %w", key, path, err)
+		}
+	}
+
+	return nil
+}
+
+func flatten(prefix string, raw map[string]any, out map[string]string) {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			flatten(key, v, out)
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+func (c *Config) fields() []field {
+	var fields []field
+	collectFields("", reflect.ValueOf(c).Elem(), &fields)
+	return fields
+}
+
+func collectFields(prefix string, v reflect.Value, fields *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name := structField.Tag.Get("config")
+		if name == "" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if structField.Type.Kind() == reflect.Struct && structField.Type != reflect.TypeOf(time.Duration(0)) {
+			collectFields(key, v.Field(i), fields)
+			continue
+		}
+
+		envs := []string{strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+		if alias := structField.Tag.Get("env"); alias != "" {
+			envs = append(envs, strings.Split(alias, ",")...)
+		}
+
+		*fields = append(*fields, field{
+			key:    key,
+			envs:   envs,
+			secret: structField.Tag.Get("secret") == "true",
+			usage:  structField.Tag.Get("usage"),
+			value:  v.Field(i),
+		})
+	}
+}
+
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if f.value.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("must be a duration such as 10s or 1m: %w", err)
+		}
+		f.value.SetInt(int64(d))
+		return nil
+	}
+
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("must be a boolean: %w", err)
+		}
+		f.value.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, f.value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer: %w", err)
+		}
+		f.value.SetInt(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number: %w", err)
+		}
+		f.value.SetFloat(n)
+	case reflect.Slice:
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", f.value.Type())
+	}
+
+	return nil
+}
+
+func (f field) string() string {
+	if f.value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(f.value.Int()).String()
+	}
+
+	if f.value.Kind() == reflect.Slice {
+		items := make([]string, f.value.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(f.value.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(f.value.Interface())
+}
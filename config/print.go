@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "REDACTED"
+
+// Print writes the effective configuration as YAML or JSON with secrets redacted.
+func (c *Config) Print(w io.Writer, format string) error {
+	tree := map[string]any{}
+	for _, f := range c.fields() {
+		value := f.value.Interface()
+		if f.value.Type() == reflect.TypeOf(time.Duration(0)) {
+			value = f.string()
+		}
+		if f.secret {
+			value = redact(f.string())
+			if items, ok := f.value.Interface().([]string); ok {
+				redactedItems := make([]string, len(items))
+				for i, item := range items {
+					redactedItems[i] = redact(item)
+				}
+				value = redactedItems
+			}
+		}
+
+		node := tree
+		parts := strings.Split(f.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+
+	switch format {
+	case "yaml", "":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(tree)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tree)
+	default:
+		return fmt.Errorf("unsupported output format %q, expected yaml or json", format)
+	}
+}
+
+// redact keeps connection URIs readable by only hiding the password part.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if u, err := url.Parse(value); err == nil && u.User != nil && u.Host != "" {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		if query := u.Query(); query.Has("password") {
+			query.Set("password", redacted)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+
+	return redacted
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap/zapcore"
+)
+
+type ValidationError struct {
+	Key     string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s: %s", e.Key, e.Message)
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, ValidationError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 0 || port > 65535 {
+		invalid("server.port", "must be a TCP port between 0 and 65535, got %q", c.Server.Port)
+	}
+	if c.Server.ReadTimeout <= 0 {
+		invalid("server.read_timeout", "must be greater than 0")
+	}
+	if c.Server.WriteTimeout <= 0 {
+		invalid("server.write_timeout", "must be greater than 0")
+	}
+	if c.Server.IdleTimeout <= 0 {
+		invalid("server.idle_timeout", "must be greater than 0")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("server.shutdown_timeout", "must be greater than 0")
+	}
+	if c.Server.DrainDelay < 0 {
+		invalid("server.drain_delay", "must not be negative")
+	}
+
+	switch c.Database.Backend {
+	case BackendPostgres:
+	case BackendMemory:
+		if len(c.Database.ReplicaURIs) > 0 {
+			invalid("database.replica_uris", "must be empty with the memory backend")
+		}
+	default:
+		invalid("database.backend", "must be one of postgres, memory, got %q", c.Database.Backend)
+	}
+	if c.Database.MemorySeed != "" && c.Database.Backend != BackendMemory {
+		invalid("database.memory_seed", "requires database.backend memory")
+	}
+	switch {
+	case c.Database.URI != "":
+		if _, err := pgx.ParseConfig(c.Database.URI); err != nil {
+			invalid("database.uri", "must be a valid PostgreSQL connection string")
+		}
+	case c.Database.Backend == BackendPostgres:
+		invalid("database.uri", "is required with the postgres backend")
+	}
+	if c.Database.MaxConns < 1 {
+		invalid("database.max_conns", "must be at least 1")
+	}
+	if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+		invalid("database.min_conns", "must be between 0 and database.max_conns")
+	}
+	for i, uri := range c.Database.ReplicaURIs {
+		if _, err := pgx.ParseConfig(uri); err != nil {
+			invalid("database.replica_uris", "item %d must be a valid PostgreSQL connection string", i)
+		}
+	}
+	if c.Database.ReplicaMaxLag <= 0 {
+		invalid("database.replica_max_lag", "must be greater than 0")
+	}
+	if c.Database.ReplicaCheckInterval <= 0 {
+		invalid("database.replica_check_interval", "must be greater than 0")
+	}
+	if c.Database.MaxConnLifetime <= 0 {
+		invalid("database.max_conn_lifetime", "must be greater than 0")
+	}
+	if c.Database.MaxConnIdleTime <= 0 {
+		invalid("database.max_conn_idle_time", "must be greater than 0")
+	}
+	if c.Database.HealthCheckPeriod <= 0 {
+		invalid("database.health_check_period", "must be greater than 0")
+	}
+	if c.Database.ConnectTimeout <= 0 {
+		invalid("database.connect_timeout", "must be greater than 0")
+	}
+	if c.Database.StatementTimeout < 0 {
+		invalid("database.statement_timeout", "must not be negative")
+	}
+	if c.Database.QueryTimeout <= 0 {
+		invalid("database.query_timeout", "must be greater than 0")
+	}
+	if c.Database.Retry.MaxAttempts < 1 {
+		invalid("database.retry.max_attempts", "must be at least 1")
+	}
+	if c.Database.Retry.InitialBackoff <= 0 {
+		invalid("database.retry.initial_backoff", "must be greater than 0")
+	}
+	if c.Database.Retry.MaxBackoff < c.Database.Retry.InitialBackoff {
+		invalid("database.retry.max_backoff", "must not be less than database.retry.initial_backoff")
+	}
+
+	if _, err := zapcore.ParseLevel(c.Log.Level); err != nil {
+		invalid("log.level", "must be one of debug, info, warn, error, dpanic, panic, fatal, got %q", c.Log.Level)
+	}
+
+	if c.Readiness.MaxSyncAge < 0 {
+		invalid("readiness.max_sync_age", "must not be negative")
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "stdout":
+	default:
+		invalid("tracing.exporter", "must be one of none, otlp, stdout, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.Exporter == "otlp" && c.Tracing.OTLPEndpoint == "" {
+		invalid("tracing.otlp_endpoint", "is required when tracing.exporter is otlp")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	if c.Cache.TTL <= 0 {
+		invalid("cache.ttl", "must be greater than 0")
+	}
+	if c.Cache.MaxEntries < 1 {
+		invalid("cache.max_entries", "must be at least 1")
+	}
+
+	if c.RateLimit.RequestsPerSecond <= 0 {
+		invalid("rate_limit.requests_per_second", "must be greater than 0")
+	}
+	if c.RateLimit.Burst < 1 {
+		invalid("rate_limit.burst", "must be at least 1")
+	}
+
+	if c.Reload.WatchInterval < 0 {
+		invalid("reload.watch_interval", "must not be negative")
+	}
+
+	for _, supplier := range []struct{ key, url string }{
+		{"ingest.acme_url", c.Ingest.AcmeURL},
+		{"ingest.patagonia_url", c.Ingest.PatagoniaURL},
+		{"ingest.paperflies_url", c.Ingest.PaperfliesURL},
+	} {
+		if supplier.url == "" {
+			continue
+		}
+		if parsed, err := url.Parse(supplier.url); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "file") {
+			invalid(supplier.key, "must be an http(s) or file:// URL")
+		}
+	}
+	if c.Ingest.Timeout <= 0 {
+		invalid("ingest.timeout", "must be greater than 0")
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// reloadableKeys are the keys, or key prefixes ending with a dot, that can change without a restart.
+var reloadableKeys = []string{
+	"log.level",
+	"rate_limit.",
+	"cache.ttl",
+	"features",
+}
+
+// Subscriber is notified with the new configuration after a reload changed a reloadable key.
+type Subscriber func(config *Config) error
+
+type subscription struct {
+	name string
+	fn   Subscriber
+}
+
+// Watcher reloads the configuration on SIGHUP or when the config file changes.
+// Only reloadable keys are applied, other changes are reported and need a restart.
+type Watcher struct {
+	options     LoadOptions
+	logger      *zap.Logger
+	current     atomic.Pointer[Config]
+	mu          sync.Mutex
+	subscribers []subscription
+}
+
+type WatcherParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *Config
+	Logger    *zap.Logger
+	Options   *LoadOptions `optional:"true"`
+}
+
+func NewWatcher(params WatcherParams) *Watcher {
+	watcher := &Watcher{logger: params.Logger}
+	if params.Options != nil {
+		watcher.options = *params.Options
+	}
+	watcher.current.Store(params.Config)
+
+	var stop func()
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			stop = watcher.watch()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if stop != nil {
+				stop()
+			}
+			return nil
+		},
+	})
+
+	return watcher
+}
+
+// Current returns the latest applied configuration.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// FeatureEnabled reports whether the feature flag is set in the current configuration.
+// Read flags through the watcher, the injected *Config keeps the flags set at startup.
+func (w *Watcher) FeatureEnabled(name string) bool {
+	return w.Current().FeatureEnabled(name)
+}
+
+// Subscribe registers fn to be called after each reload that changed a reloadable key.
+func (w *Watcher) Subscribe(name string, fn Subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, subscription{name: name, fn: fn})
+}
+
+// Reload loads the configuration again and notifies subscribers when a reloadable key changed.
+func (w *Watcher) Reload(trigger string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	logger := w.logger.With(zap.String("trigger", trigger))
+
+	next, err := Load(w.options)
+	if err != nil {
+		logger.Error("Configuration reload failed, keeping the current configuration", zap.Error(err))
+		return err
+	}
+
+	applied, changed, ignored := w.Current().merge(next)
+	if len(ignored) > 0 {
+		logger.Warn("Configuration changes need a restart to take effect", zap.Strings("keys", ignored))
+	}
+	if len(changed) == 0 {
+		logger.Info("Configuration reloaded without changes to reloadable keys")
+		return nil
+	}
+
+	w.current.Store(applied)
+
+	var errs []error
+	for _, subscriber := range w.subscribers {
+		if err := subscriber.fn(applied); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		logger.Error("Configuration reloaded but some subscribers failed to apply it", zap.Strings("keys", changed), zap.Error(err))
+		return err
+	}
+
+	logger.Info("Configuration reloaded", zap.Strings("keys", changed))
+	return nil
+}
+
+func (w *Watcher) watch() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	var ticks <-chan time.Time
+	var ticker *time.Ticker
+	file := w.options.file()
+	interval := w.Current().Reload.WatchInterval
+	if file != "" && interval > 0 {
+		ticker = time.NewTicker(interval)
+		ticks = ticker.C
+	}
+
+	modTime := fileModTime(file)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-signals:
+				_ = w.Reload("SIGHUP")
+			case <-ticks:
+				if current := fileModTime(file); !current.Equal(modTime) {
+					modTime = current
+					_ = w.Reload("file change")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(done)
+		<-stopped
+	}
+}
+
+func fileModTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+// merge returns a copy of c with the reloadable keys taken from next,
+// along with the reloadable keys that changed and the other keys that changed but were not applied.
+func (c *Config) merge(next *Config) (*Config, []string, []string) {
+	merged := *c
+
+	var changed, ignored []string
+	nextFields := next.fields()
+	for i, f := range merged.fields() {
+		nextField := nextFields[i]
+		if reflect.DeepEqual(f.value.Interface(), nextField.value.Interface()) {
+			continue
+		}
+
+		if !isReloadable(f.key) {
+			ignored = append(ignored, f.key)
+			continue
+		}
+
+		f.value.Set(nextField.value)
+		changed = append(changed, f.key)
+	}
+
+	return &merged, changed, ignored
+}
+
+func isReloadable(key string) bool {
+	for _, reloadable := range reloadableKeys {
+		if key == reloadable || (strings.HasSuffix(reloadable, ".") && strings.HasPrefix(key, reloadable)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	file := writeFile(t, "config.yaml", "log:\n  level: info\n")
+	options := &config.LoadOptions{File: file, LookupEnv: envFrom(map[string]string{"DB_URI": testDatabaseURI})}
+
+	cfg, err := config.Load(*options)
+	require.NoError(t, err)
+
+	watcher := config.NewWatcher(config.WatcherParams{
+		Lifecycle: fxtest.NewLifecycle(t),
+		Config:    cfg,
+		Logger:    zap.NewNop(),
+		Options:   options,
+	})
+
+	var notified []*config.Config
+	watcher.Subscribe("test", func(reloaded *config.Config) error {
+		notified = append(notified, reloaded)
+		return nil
+	})
+
+	t.Run("should apply reloadable keys and notify subscribers", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("log:\n  level: debug\nrate_limit:\n  burst: 5\nfeatures: [beta]\n"), 0o600))
+
+		require.NoError(t, watcher.Reload("test"))
+
+		require.Len(t, notified, 1)
+		assert.Equal(t, "debug", watcher.Current().Log.Level)
+		assert.Equal(t, 5, watcher.Current().RateLimit.Burst)
+		assert.True(t, watcher.FeatureEnabled("beta"))
+		assert.False(t, cfg.FeatureEnabled("beta"))
+		assert.Equal(t, "info", cfg.Log.Level)
+	})
+
+	t.Run("should ignore keys that need a restart", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("log:\n  level: debug\nrate_limit:\n  burst: 5\nfeatures: [beta]\nserver:\n  port: \"9999\"\n"), 0o600))
+
+		require.NoError(t, watcher.Reload("test"))
+
+		assert.Len(t, notified, 1)
+		assert.Equal(t, "8080", watcher.Current().Server.Port)
+	})
+
+	t.Run("should keep the current configuration when the new one is invalid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("log:\n  level: loud\n"), 0o600))
+
+		assert.Error(t, watcher.Reload("test"))
+		assert.Equal(t, "debug", watcher.Current().Log.Level)
+	})
+
+	t.Run("should report subscriber errors", func(t *testing.T) {
+		watcher.Subscribe("failing", func(*config.Config) error {
+			return errors.New("boom")
+		})
+		require.NoError(t, os.WriteFile(file, []byte("cache:\n  ttl: 5m\n"), 0o600))
+
+		err := watcher.Reload("test")
+
+		assert.ErrorContains(t, err, "failing: boom")
+		assert.Equal(t, 5*time.Minute, watcher.Current().Cache.TTL)
+	})
+}
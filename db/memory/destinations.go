@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) CountDestinations(ctx context.Context) (int64, error) {
+	var count int64
+	err := q.read(ctx, func(state *state) error {
+		count = int64(len(state.destinations))
+		return nil
+	})
+	return count, err
+}
+
+func (q *queries) FindDestination(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+	var found *sqlc.FindDestinationRow
+	err := q.read(ctx, func(state *state) error {
+		destination, ok := state.destinations[id]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		row := sqlc.FindDestinationRow(*withHotelCount(state, destination))
+		found = &row
+		return nil
+	})
+	return found, err
+}
+
+func (q *queries) InsertMissingDestinations(ctx context.Context) error {
+	return q.write(ctx, func(state *state) error {
+		byDestination := map[string][]*sqlc.Hotel{}
+		for _, hotel := range state.hotels {
+			byDestination[hotel.DestinationID] = append(byDestination[hotel.DestinationID], hotel)
+		}
+
+		now := q.now()
+		for id, hotels := range byDestination {
+			if _, ok := state.destinations[id]; ok {
+				continue
+			}
+
+			destination := &sqlc.Destination{ID: id, Name: id, CreatedAt: now, UpdatedAt: now}
+			var cities, countryCodes []string
+			var latitudes, longitudes []float64
+			for _, hotel := range hotels {
+				if hotel.CountryCode != nil {
+					countryCodes = append(countryCodes, *hotel.CountryCode)
+				}
+				if hotel.Location == nil {
+					continue
+				}
+				if hotel.Location.City != nil {
+					cities = append(cities, *hotel.Location.City)
+				}
+				if checked(hotel) {
+					if hotel.Location.Latitude != nil {
+						latitudes = append(latitudes, *hotel.Location.Latitude)
+					}
+					if hotel.Location.Longitude != nil {
+						longitudes = append(longitudes, *hotel.Location.Longitude)
+					}
+				}
+			}
+			if city, ok := mode(cities); ok {
+				destination.Name = city
+			}
+			if countryCode, ok := mode(countryCodes); ok {
+				destination.CountryCode = &countryCode
+			}
+			destination.Latitude = average(latitudes)
+			destination.Longitude = average(longitudes)
+
+			state.destinations[id] = destination
+		}
+		return nil
+	})
+}
+
+func (q *queries) ListDestinations(ctx context.Context, arg sqlc.ListDestinationsParams) ([]*sqlc.ListDestinationsRow, error) {
+	var items []*sqlc.ListDestinationsRow
+	err := q.read(ctx, func(state *state) error {
+		for _, destination := range state.destinations {
+			items = append(items, withHotelCount(state, destination))
+		}
+		return nil
+	})
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].ID < items[j].ID
+	})
+	return page(items, arg.Limit, arg.Offset), err
+}
+
+func (q *queries) RestoreDestination(ctx context.Context, arg sqlc.RestoreDestinationParams) error {
+	return q.write(ctx, func(state *state) error {
+		if _, ok := state.destinations[arg.ID]; ok {
+			return uniqueViolation("destinations", "destinations_pkey")
+		}
+
+		state.destinations[arg.ID] = cloneDestination(&sqlc.Destination{
+			ID:          arg.ID,
+			Name:        arg.Name,
+			CountryCode: arg.CountryCode,
+			Latitude:    arg.Latitude,
+			Longitude:   arg.Longitude,
+			Timezone:    arg.Timezone,
+			CreatedAt:   arg.CreatedAt,
+			UpdatedAt:   arg.UpdatedAt,
+		})
+		return nil
+	})
+}
+
+func (q *queries) UpsertDestination(ctx context.Context, arg sqlc.UpsertDestinationParams) error {
+	return q.write(ctx, func(state *state) error {
+		now := q.now()
+		destination, ok := state.destinations[arg.ID]
+		if !ok {
+			destination = &sqlc.Destination{ID: arg.ID, CreatedAt: now}
+		}
+		destination.Name = arg.Name
+		destination.CountryCode = clonePointer(arg.CountryCode)
+		destination.Latitude = clonePointer(arg.Latitude)
+		destination.Longitude = clonePointer(arg.Longitude)
+		destination.Timezone = clonePointer(arg.Timezone)
+		destination.UpdatedAt = now
+
+		state.destinations[arg.ID] = cloneDestination(destination)
+		return nil
+	})
+}
+
+func withHotelCount(state *state, destination *sqlc.Destination) *sqlc.ListDestinationsRow {
+	row := &sqlc.ListDestinationsRow{
+		ID:          destination.ID,
+		Name:        destination.Name,
+		CountryCode: clonePointer(destination.CountryCode),
+		Latitude:    clonePointer(destination.Latitude),
+		Longitude:   clonePointer(destination.Longitude),
+		Timezone:    clonePointer(destination.Timezone),
+		CreatedAt:   destination.CreatedAt,
+		UpdatedAt:   destination.UpdatedAt,
+	}
+	for _, hotel := range state.hotels {
+		if hotel.DestinationID == destination.ID {
+			row.HotelCount++
+		}
+	}
+
+	return row
+}
+
+// checked reports whether the coordinates of hotel passed the location check, possibly once repaired.
+func checked(hotel *sqlc.Hotel) bool {
+	if hotel.DataQuality == nil {
+		return false
+	}
+	status := hotel.DataQuality.Location.Status
+
+	return status == dto.LocationOK || status == dto.LocationRepaired
+}
+
+// mode returns the most common value, the smallest of those as common, like MODE() WITHIN GROUP.
+func mode(values []string) (string, bool) {
+	counts := map[string]int{}
+	for _, value := range values {
+		counts[value]++
+	}
+
+	var best string
+	found := false
+	for value, count := range counts {
+		if !found || count > counts[best] || (count == counts[best] && value < best) {
+			best, found = value, true
+		}
+	}
+
+	return best, found
+}
+
+func average(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	avg := sum / float64(len(values))
+
+	return &avg
+}
+
+// page applies LIMIT and OFFSET to items.
+func page[T any](items []T, limit int32, offset int32) []T {
+	start := min(int(offset), len(items))
+	end := min(start+int(limit), len(items))
+
+	return items[start:end]
+}
+
+func cloneDestination(destination *sqlc.Destination) *sqlc.Destination {
+	cloned := *destination
+	cloned.CountryCode = clonePointer(destination.CountryCode)
+	cloned.Latitude = clonePointer(destination.Latitude)
+	cloned.Longitude = clonePointer(destination.Longitude)
+	cloned.Timezone = clonePointer(destination.Timezone)
+
+	return &cloned
+}
@@ -0,0 +1,217 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+const duplicatePending = "pending"
+
+func (q *queries) DecideHotelDuplicate(ctx context.Context, arg sqlc.DecideHotelDuplicateParams) error {
+	return q.write(ctx, func(state *state) error {
+		duplicate, ok := state.duplicates[arg.ID]
+		if !ok {
+			return nil
+		}
+		duplicate.Status = arg.Status
+		duplicate.CanonicalHotelID = clonePointer(arg.CanonicalHotelID)
+		duplicate.UpdatedAt = q.now()
+		return nil
+	})
+}
+
+func (q *queries) DeletePendingHotelDuplicates(ctx context.Context) error {
+	return q.write(ctx, func(state *state) error {
+		for id, duplicate := range state.duplicates {
+			if duplicate.Status == duplicatePending {
+				delete(state.duplicates, id)
+			}
+		}
+		return nil
+	})
+}
+
+func (q *queries) DeletePendingHotelDuplicatesOfHotel(ctx context.Context, hotelID string) error {
+	return q.write(ctx, func(state *state) error {
+		for id, duplicate := range state.duplicates {
+			if duplicate.Status == duplicatePending && (duplicate.HotelID == hotelID || duplicate.DuplicateHotelID == hotelID) {
+				delete(state.duplicates, id)
+			}
+		}
+		return nil
+	})
+}
+
+func (q *queries) FindHotelDuplicate(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error) {
+	var found *sqlc.HotelDuplicate
+	err := q.read(ctx, func(state *state) error {
+		duplicate, ok := state.duplicates[id]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		found = cloneDuplicate(duplicate)
+		return nil
+	})
+	return found, err
+}
+
+func (q *queries) ListHotelDuplicates(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error) {
+	var items []*sqlc.HotelDuplicate
+	err := q.read(ctx, func(state *state) error {
+		for _, duplicate := range state.duplicates {
+			if duplicate.Status == status {
+				items = append(items, cloneDuplicate(duplicate))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (q *queries) ListHotelRedirects(ctx context.Context) ([]*sqlc.HotelRedirect, error) {
+	var items []*sqlc.HotelRedirect
+	err := q.read(ctx, func(state *state) error {
+		for _, redirect := range state.redirects {
+			copied := *redirect
+			items = append(items, &copied)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].HotelID < items[j].HotelID
+	})
+	return items, nil
+}
+
+func (q *queries) RepointHotelRedirects(ctx context.Context, arg sqlc.RepointHotelRedirectsParams) error {
+	return q.write(ctx, func(state *state) error {
+		for _, redirect := range state.redirects {
+			if redirect.CanonicalHotelID == arg.HotelID {
+				redirect.CanonicalHotelID = arg.CanonicalHotelID
+			}
+		}
+		return nil
+	})
+}
+
+func (q *queries) ResetHotelDuplicateIDSequence(ctx context.Context) error {
+	return q.write(ctx, func(state *state) error {
+		state.lastDuplicateID = 0
+		for id := range state.duplicates {
+			state.lastDuplicateID = max(state.lastDuplicateID, id)
+		}
+		return nil
+	})
+}
+
+func (q *queries) RestoreHotelDuplicate(ctx context.Context, arg sqlc.RestoreHotelDuplicateParams) error {
+	return q.write(ctx, func(state *state) error {
+		if _, ok := state.duplicates[arg.ID]; ok {
+			return uniqueViolation("hotel_duplicates", "hotel_duplicates_pkey")
+		}
+		for _, duplicate := range state.duplicates {
+			if duplicate.HotelID == arg.HotelID && duplicate.DuplicateHotelID == arg.DuplicateHotelID {
+				return uniqueViolation("hotel_duplicates", "hotel_duplicates_hotel_id_duplicate_hotel_id_key")
+			}
+		}
+
+		state.duplicates[arg.ID] = cloneDuplicate(&sqlc.HotelDuplicate{
+			ID:               arg.ID,
+			HotelID:          arg.HotelID,
+			DuplicateHotelID: arg.DuplicateHotelID,
+			DestinationID:    arg.DestinationID,
+			Score:            arg.Score,
+			NameScore:        arg.NameScore,
+			AddressScore:     arg.AddressScore,
+			DistanceKm:       arg.DistanceKm,
+			Status:           arg.Status,
+			CanonicalHotelID: arg.CanonicalHotelID,
+			CreatedAt:        arg.CreatedAt,
+			UpdatedAt:        arg.UpdatedAt,
+		})
+		return nil
+	})
+}
+
+func (q *queries) RestoreHotelRedirect(ctx context.Context, arg sqlc.RestoreHotelRedirectParams) error {
+	return q.write(ctx, func(state *state) error {
+		if _, ok := state.redirects[arg.HotelID]; ok {
+			return uniqueViolation("hotel_redirects", "hotel_redirects_pkey")
+		}
+
+		state.redirects[arg.HotelID] = &sqlc.HotelRedirect{HotelID: arg.HotelID, CanonicalHotelID: arg.CanonicalHotelID, CreatedAt: arg.CreatedAt}
+		return nil
+	})
+}
+
+func (q *queries) UpsertHotelDuplicate(ctx context.Context, arg sqlc.UpsertHotelDuplicateParams) error {
+	return q.write(ctx, func(state *state) error {
+		state.lastDuplicateID++
+		now := q.now()
+
+		var existing *sqlc.HotelDuplicate
+		for _, duplicate := range state.duplicates {
+			if duplicate.HotelID == arg.HotelID && duplicate.DuplicateHotelID == arg.DuplicateHotelID {
+				existing = duplicate
+			}
+		}
+		if existing != nil && existing.Status != duplicatePending {
+			return nil
+		}
+
+		duplicate := &sqlc.HotelDuplicate{ID: state.lastDuplicateID, Status: duplicatePending, CreatedAt: now}
+		if existing != nil {
+			duplicate = existing
+		}
+		duplicate.HotelID = arg.HotelID
+		duplicate.DuplicateHotelID = arg.DuplicateHotelID
+		duplicate.DestinationID = arg.DestinationID
+		duplicate.Score = arg.Score
+		duplicate.NameScore = arg.NameScore
+		duplicate.AddressScore = clonePointer(arg.AddressScore)
+		duplicate.DistanceKm = clonePointer(arg.DistanceKm)
+		duplicate.UpdatedAt = now
+
+		state.duplicates[duplicate.ID] = duplicate
+		return nil
+	})
+}
+
+func (q *queries) UpsertHotelRedirect(ctx context.Context, arg sqlc.UpsertHotelRedirectParams) error {
+	return q.write(ctx, func(state *state) error {
+		redirect := &sqlc.HotelRedirect{HotelID: arg.HotelID, CreatedAt: q.now()}
+		if existing, ok := state.redirects[arg.HotelID]; ok {
+			redirect = existing
+		}
+		redirect.CanonicalHotelID = arg.CanonicalHotelID
+
+		state.redirects[arg.HotelID] = redirect
+		return nil
+	})
+}
+
+func cloneDuplicate(duplicate *sqlc.HotelDuplicate) *sqlc.HotelDuplicate {
+	cloned := *duplicate
+	cloned.AddressScore = clonePointer(duplicate.AddressScore)
+	cloned.DistanceKm = clonePointer(duplicate.DistanceKm)
+	cloned.CanonicalHotelID = clonePointer(duplicate.CanonicalHotelID)
+
+	return &cloned
+}
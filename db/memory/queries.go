@@ -0,0 +1,431 @@
+// Package memory is an in-memory sqlc.Querier with the semantics of db/queries, for local development and demos.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const uniqueViolationCode = "23505"
+
+type state struct {
+	hotels map[string]*sqlc.Hotel
+	// lastID is the last value of the hotels id sequence, as nextval consumes it even when an upsert updates.
+	lastID int32
+
+	duplicates      map[int32]*sqlc.HotelDuplicate
+	lastDuplicateID int32
+	redirects       map[string]*sqlc.HotelRedirect
+	translations    map[translationKey]*sqlc.HotelTranslation
+	destinations    map[string]*sqlc.Destination
+}
+
+func newState() *state {
+	return &state{
+		hotels:       map[string]*sqlc.Hotel{},
+		duplicates:   map[int32]*sqlc.HotelDuplicate{},
+		redirects:    map[string]*sqlc.HotelRedirect{},
+		translations: map[translationKey]*sqlc.HotelTranslation{},
+		destinations: map[string]*sqlc.Destination{},
+	}
+}
+
+func (s *state) clone() *state {
+	cloned := newState()
+	cloned.lastID, cloned.lastDuplicateID = s.lastID, s.lastDuplicateID
+	for hotelID, hotel := range s.hotels {
+		cloned.hotels[hotelID] = cloneHotel(hotel)
+	}
+	for id, duplicate := range s.duplicates {
+		cloned.duplicates[id] = cloneDuplicate(duplicate)
+	}
+	for hotelID, redirect := range s.redirects {
+		copied := *redirect
+		cloned.redirects[hotelID] = &copied
+	}
+	for key, translation := range s.translations {
+		cloned.translations[key] = cloneTranslation(translation)
+	}
+	for id, destination := range s.destinations {
+		cloned.destinations[id] = cloneDestination(destination)
+	}
+
+	return cloned
+}
+
+// resolve follows the redirect of hotelID, if any.
+func (s *state) resolve(hotelID string) string {
+	if redirect, ok := s.redirects[hotelID]; ok {
+		return redirect.CanonicalHotelID
+	}
+
+	return hotelID
+}
+
+func (s *state) resolveAll(hotelIDs []string) []string {
+	resolved := make([]string, len(hotelIDs))
+	for i, hotelID := range hotelIDs {
+		resolved[i] = s.resolve(hotelID)
+	}
+
+	return resolved
+}
+
+// Store holds the hotels, their duplicate candidates, redirects, translations and destinations. Its queries are safe for concurrent use.
+type Store struct {
+	mu    sync.RWMutex
+	state *state
+	now   func() time.Time
+}
+
+func New() *Store {
+	return &Store{
+		state: newState(),
+		now:   time.Now,
+	}
+}
+
+// Queries returns the queries of the store, each one run atomically.
+func (s *Store) Queries() sqlc.Querier {
+	return &queries{store: s}
+}
+
+// InTx runs fn against a copy of the store, which replaces the store when fn succeeds.
+// Transactions are serialized, and queries outside them wait until they finish.
+func (s *Store) InTx(ctx context.Context, fn func(queries sqlc.Querier) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx := &queries{store: s, tx: s.state.clone()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	s.state = tx.tx
+	return nil
+}
+
+type queries struct {
+	store *Store
+	// tx is the state of a transaction, whose lock is already held.
+	tx *state
+}
+
+func (q *queries) read(ctx context.Context, fn func(state *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if q.tx != nil {
+		return fn(q.tx)
+	}
+
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+	return fn(q.store.state)
+}
+
+func (q *queries) write(ctx context.Context, fn func(state *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if q.tx != nil {
+		return fn(q.tx)
+	}
+
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+	return fn(q.store.state)
+}
+
+// now truncates to microseconds, the precision of timestamptz.
+func (q *queries) now() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: q.store.now().Truncate(time.Microsecond), Valid: true}
+}
+
+func (q *queries) CountHotels(ctx context.Context) (int64, error) {
+	var count int64
+	err := q.read(ctx, func(state *state) error {
+		count = int64(len(state.hotels))
+		return nil
+	})
+	return count, err
+}
+
+func (q *queries) CountHotelsByDestination(ctx context.Context) ([]*sqlc.CountHotelsByDestinationRow, error) {
+	var items []*sqlc.CountHotelsByDestinationRow
+	err := q.read(ctx, func(state *state) error {
+		counts := map[string]int64{}
+		for _, hotel := range state.hotels {
+			counts[hotel.DestinationID]++
+		}
+		for destinationID, count := range counts {
+			items = append(items, &sqlc.CountHotelsByDestinationRow{DestinationID: destinationID, HotelCount: count})
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (q *queries) DeleteHotel(ctx context.Context, hotelID string) error {
+	return q.write(ctx, func(state *state) error {
+		delete(state.hotels, hotelID)
+		return nil
+	})
+}
+
+func (q *queries) FindHotelByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
+	var found *sqlc.Hotel
+	err := q.read(ctx, func(state *state) error {
+		hotel, ok := state.hotels[state.resolve(hotelID)]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		found = cloneHotel(hotel)
+		return nil
+	})
+	return found, err
+}
+
+func (q *queries) FindHotelsByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	return q.filter(ctx, func(hotel *sqlc.Hotel) bool {
+		return hotel.CountryCode != nil && *hotel.CountryCode == countryCode
+	})
+}
+
+func (q *queries) FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg sqlc.FindHotelsByDestinationAndHotelIDsParams) ([]*sqlc.Hotel, error) {
+	return q.filterState(ctx, func(state *state) func(hotel *sqlc.Hotel) bool {
+		hotelIDs := state.resolveAll(arg.HotelIds)
+		return func(hotel *sqlc.Hotel) bool {
+			return hotel.DestinationID == arg.DestinationID && slices.Contains(hotelIDs, hotel.HotelID)
+		}
+	})
+}
+
+func (q *queries) FindHotelsByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+	return q.filter(ctx, func(hotel *sqlc.Hotel) bool {
+		return hotel.DestinationID == destinationID
+	})
+}
+
+func (q *queries) FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) ([]*sqlc.Hotel, error) {
+	return q.filterState(ctx, func(state *state) func(hotel *sqlc.Hotel) bool {
+		hotelIDs := state.resolveAll(hotelIds)
+		return func(hotel *sqlc.Hotel) bool {
+			return slices.Contains(hotelIDs, hotel.HotelID)
+		}
+	})
+}
+
+func (q *queries) GetLastSyncedAt(ctx context.Context) (pgtype.Timestamptz, error) {
+	var last pgtype.Timestamptz
+	err := q.read(ctx, func(state *state) error {
+		for _, hotel := range state.hotels {
+			if hotel.UpdatedAt.Valid && (!last.Valid || hotel.UpdatedAt.Time.After(last.Time)) {
+				last = hotel.UpdatedAt
+			}
+		}
+		return nil
+	})
+	return last, err
+}
+
+func (q *queries) ListHotels(ctx context.Context) ([]*sqlc.Hotel, error) {
+	items, err := q.filter(ctx, func(*sqlc.Hotel) bool { return true })
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].HotelID < items[j].HotelID
+	})
+	return items, err
+}
+
+func (q *queries) ResetHotelIDSequence(ctx context.Context) error {
+	return q.write(ctx, func(state *state) error {
+		state.lastID = 0
+		for _, hotel := range state.hotels {
+			state.lastID = max(state.lastID, hotel.ID)
+		}
+		return nil
+	})
+}
+
+func (q *queries) RestoreHotel(ctx context.Context, arg sqlc.RestoreHotelParams) error {
+	return q.write(ctx, func(state *state) error {
+		if _, ok := state.hotels[arg.HotelID]; ok {
+			return uniqueViolation("hotels", "hotels_hotel_id_key")
+		}
+		for _, hotel := range state.hotels {
+			if hotel.ID == arg.ID {
+				return uniqueViolation("hotels", "hotels_pkey")
+			}
+		}
+
+		state.hotels[arg.HotelID] = cloneHotel(&sqlc.Hotel{
+			ID:                arg.ID,
+			HotelID:           arg.HotelID,
+			DestinationID:     arg.DestinationID,
+			Name:              arg.Name,
+			Location:          arg.Location,
+			Description:       arg.Description,
+			Images:            arg.Images,
+			Amenities:         arg.Amenities,
+			BookingConditions: arg.BookingConditions,
+			Policies:          arg.Policies,
+			CountryCode:       arg.CountryCode,
+			DataQuality:       arg.DataQuality,
+			RawText:           arg.RawText,
+			CreatedAt:         arg.CreatedAt,
+			UpdatedAt:         arg.UpdatedAt,
+		})
+		return nil
+	})
+}
+
+func (q *queries) UpsertHotel(ctx context.Context, arg sqlc.UpsertHotelParams) error {
+	return q.write(ctx, func(state *state) error {
+		state.lastID++
+		now := q.now()
+
+		hotel := &sqlc.Hotel{ID: state.lastID, CreatedAt: now}
+		if existing, ok := state.hotels[arg.HotelID]; ok {
+			hotel.ID, hotel.CreatedAt = existing.ID, existing.CreatedAt
+		}
+		hotel.HotelID = arg.HotelID
+		hotel.DestinationID = arg.DestinationID
+		hotel.Name = arg.Name
+		hotel.Location = arg.Location
+		hotel.Description = arg.Description
+		hotel.Images = arg.Images
+		hotel.Amenities = arg.Amenities
+		hotel.BookingConditions = arg.BookingConditions
+		hotel.Policies = arg.Policies
+		hotel.CountryCode = arg.CountryCode
+		hotel.DataQuality = arg.DataQuality
+		hotel.RawText = arg.RawText
+		hotel.UpdatedAt = now
+
+		state.hotels[arg.HotelID] = cloneHotel(hotel)
+		return nil
+	})
+}
+
+// filter returns copies of the matching hotels in id order, or nil when none match, like sqlc does.
+func (q *queries) filter(ctx context.Context, match func(hotel *sqlc.Hotel) bool) ([]*sqlc.Hotel, error) {
+	return q.filterState(ctx, func(*state) func(hotel *sqlc.Hotel) bool { return match })
+}
+
+// filterState is filter with a match built from the state, such as one following redirects.
+func (q *queries) filterState(ctx context.Context, matcher func(state *state) func(hotel *sqlc.Hotel) bool) ([]*sqlc.Hotel, error) {
+	var items []*sqlc.Hotel
+	err := q.read(ctx, func(state *state) error {
+		match := matcher(state)
+		for _, hotel := range state.hotels {
+			if match(hotel) {
+				items = append(items, cloneHotel(hotel))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func uniqueViolation(table string, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           uniqueViolationCode,
+		Message:        "duplicate key value violates unique constraint \"" + constraint + "\"",
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+// cloneHotel deep copies hotel, so callers cannot change stored hotels.
+func cloneHotel(hotel *sqlc.Hotel) *sqlc.Hotel {
+	cloned := *hotel
+	cloned.Description = clonePointer(hotel.Description)
+	cloned.BookingConditions = slices.Clone(hotel.BookingConditions)
+	cloned.CountryCode = clonePointer(hotel.CountryCode)
+
+	if hotel.Location != nil {
+		cloned.Location = &dto.HotelLocation{
+			Latitude:   clonePointer(hotel.Location.Latitude),
+			Longitude:  clonePointer(hotel.Location.Longitude),
+			Address:    clonePointer(hotel.Location.Address),
+			City:       clonePointer(hotel.Location.City),
+			Country:    clonePointer(hotel.Location.Country),
+			PostalCode: clonePointer(hotel.Location.PostalCode),
+		}
+	}
+	if hotel.Images != nil {
+		cloned.Images = &dto.HotelImages{
+			Rooms:     slices.Clone(hotel.Images.Rooms),
+			Site:      slices.Clone(hotel.Images.Site),
+			Amenities: slices.Clone(hotel.Images.Amenities),
+		}
+	}
+	if hotel.Amenities != nil {
+		cloned.Amenities = &dto.HotelAmenities{
+			General:         slices.Clone(hotel.Amenities.General),
+			Room:            slices.Clone(hotel.Amenities.Room),
+			Canonical:       slices.Clone(hotel.Amenities.Canonical),
+			TaxonomyVersion: hotel.Amenities.TaxonomyVersion,
+		}
+	}
+
+	if hotel.Policies != nil {
+		policies := *hotel.Policies
+		policies.PetsAllowed = clonePointer(policies.PetsAllowed)
+		policies.Wifi = clonePointer(policies.Wifi)
+		policies.Parking = clonePointer(policies.Parking)
+		policies.Children.Allowed = clonePointer(policies.Children.Allowed)
+		policies.Children.FreeUnderAge = clonePointer(policies.Children.FreeUnderAge)
+		policies.PrepaymentRequired = clonePointer(policies.PrepaymentRequired)
+		policies.CheckIn.PhotoIDRequired = clonePointer(policies.CheckIn.PhotoIDRequired)
+		policies.CheckIn.CreditCardRequired = clonePointer(policies.CheckIn.CreditCardRequired)
+		cloned.Policies = &policies
+	}
+	if hotel.DataQuality != nil {
+		quality := *hotel.DataQuality
+		quality.Location.Issues = slices.Clone(quality.Location.Issues)
+		quality.Location.DistanceKm = clonePointer(quality.Location.DistanceKm)
+		quality.Score = clonePointer(quality.Score)
+		quality.Missing = slices.Clone(quality.Missing)
+		quality.Suppliers = slices.Clone(quality.Suppliers)
+		cloned.DataQuality = &quality
+	}
+	if hotel.RawText != nil {
+		rawText := *hotel.RawText
+		rawText.Description = clonePointer(rawText.Description)
+		rawText.BookingConditions = slices.Clone(rawText.BookingConditions)
+		cloned.RawText = &rawText
+	}
+
+	return &cloned
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	cloned := *value
+	return &cloned
+}
@@ -0,0 +1,81 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/db/querytest"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerierContract(t *testing.T) {
+	querytest.Run(t, func(t *testing.T) sqlc.Querier {
+		return memory.New().Queries()
+	})
+}
+
+func TestQuerierContractInTx(t *testing.T) {
+	querytest.Run(t, func(t *testing.T) sqlc.Querier {
+		store := memory.New()
+		var queries sqlc.Querier
+		unblock := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = store.InTx(context.Background(), func(tx sqlc.Querier) error {
+				queries = tx
+				unblock <- struct{}{}
+				<-unblock
+				return nil
+			})
+		}()
+		<-unblock
+		t.Cleanup(func() {
+			unblock <- struct{}{}
+			<-done
+		})
+		return queries
+	})
+}
+
+func TestStoreInTx(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	hotel := sqlc.UpsertHotelParams{HotelID: "a", DestinationID: "1", Name: "Hotel A"}
+
+	t.Run("should discard the writes of a failed transaction", func(t *testing.T) {
+		failure := errors.New("failure")
+		err := store.InTx(ctx, func(queries sqlc.Querier) error {
+			require.NoError(t, queries.UpsertHotel(ctx, hotel))
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		count, err := store.Queries().CountHotels(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("should keep the writes of a committed transaction", func(t *testing.T) {
+		err := store.InTx(ctx, func(queries sqlc.Querier) error {
+			return queries.UpsertHotel(ctx, hotel)
+		})
+		require.NoError(t, err)
+
+		found, err := store.Queries().FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "Hotel A", found.Name)
+	})
+
+	t.Run("should stop on a cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := store.Queries().CountHotels(cancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
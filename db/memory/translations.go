@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+// translationKey is the primary key of hotel translations.
+type translationKey struct {
+	hotelID string
+	locale  string
+}
+
+func (q *queries) DeleteHotelTranslation(ctx context.Context, arg sqlc.DeleteHotelTranslationParams) error {
+	return q.write(ctx, func(state *state) error {
+		delete(state.translations, translationKey{arg.HotelID, arg.Locale})
+		return nil
+	})
+}
+
+func (q *queries) FindHotelTranslation(ctx context.Context, arg sqlc.FindHotelTranslationParams) (*sqlc.HotelTranslation, error) {
+	var found *sqlc.HotelTranslation
+	err := q.read(ctx, func(state *state) error {
+		translation, ok := state.translations[translationKey{arg.HotelID, arg.Locale}]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		found = cloneTranslation(translation)
+		return nil
+	})
+	return found, err
+}
+
+func (q *queries) ListAllHotelTranslations(ctx context.Context) ([]*sqlc.HotelTranslation, error) {
+	var translations []*sqlc.HotelTranslation
+	err := q.read(ctx, func(state *state) error {
+		for _, translation := range state.translations {
+			translations = append(translations, cloneTranslation(translation))
+		}
+		return nil
+	})
+	sortTranslations(translations)
+	return translations, err
+}
+
+func (q *queries) ListHotelTranslations(ctx context.Context, hotelIds []string) ([]*sqlc.HotelTranslation, error) {
+	var translations []*sqlc.HotelTranslation
+	err := q.read(ctx, func(state *state) error {
+		for _, translation := range state.translations {
+			if slices.Contains(hotelIds, translation.HotelID) {
+				translations = append(translations, cloneTranslation(translation))
+			}
+		}
+		return nil
+	})
+	sortTranslations(translations)
+	return translations, err
+}
+
+func (q *queries) RestoreHotelTranslation(ctx context.Context, arg sqlc.RestoreHotelTranslationParams) error {
+	return q.write(ctx, func(state *state) error {
+		key := translationKey{arg.HotelID, arg.Locale}
+		if _, ok := state.translations[key]; ok {
+			return uniqueViolation("hotel_translations", "hotel_translations_pkey")
+		}
+
+		state.translations[key] = cloneTranslation(&sqlc.HotelTranslation{
+			HotelID:           arg.HotelID,
+			Locale:            arg.Locale,
+			Name:              arg.Name,
+			Description:       arg.Description,
+			AmenityLabels:     arg.AmenityLabels,
+			BookingConditions: arg.BookingConditions,
+			CreatedAt:         arg.CreatedAt,
+			UpdatedAt:         arg.UpdatedAt,
+		})
+		return nil
+	})
+}
+
+func (q *queries) UpsertHotelTranslation(ctx context.Context, arg sqlc.UpsertHotelTranslationParams) error {
+	return q.write(ctx, func(state *state) error {
+		now := q.now()
+		key := translationKey{arg.HotelID, arg.Locale}
+		translation, ok := state.translations[key]
+		if !ok {
+			translation = &sqlc.HotelTranslation{HotelID: arg.HotelID, Locale: arg.Locale, CreatedAt: now}
+		}
+		translation.Name = clonePointer(arg.Name)
+		translation.Description = clonePointer(arg.Description)
+		translation.AmenityLabels = maps.Clone(arg.AmenityLabels)
+		translation.BookingConditions = slices.Clone(arg.BookingConditions)
+		translation.UpdatedAt = now
+
+		state.translations[key] = cloneTranslation(translation)
+		return nil
+	})
+}
+
+// sortTranslations orders translations by hotel id, then locale.
+func sortTranslations(translations []*sqlc.HotelTranslation) {
+	sort.Slice(translations, func(i, j int) bool {
+		if translations[i].HotelID != translations[j].HotelID {
+			return translations[i].HotelID < translations[j].HotelID
+		}
+		return translations[i].Locale < translations[j].Locale
+	})
+}
+
+func cloneTranslation(translation *sqlc.HotelTranslation) *sqlc.HotelTranslation {
+	cloned := *translation
+	cloned.Name = clonePointer(translation.Name)
+	cloned.Description = clonePointer(translation.Description)
+	cloned.AmenityLabels = maps.Clone(translation.AmenityLabels)
+	cloned.BookingConditions = slices.Clone(translation.BookingConditions)
+
+	return &cloned
+}
@@ -0,0 +1,186 @@
+// Package db embeds the SQL migrations so the binary can migrate and check the schema without the Atlas CLI.
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	migrationsDir    = "migrations"
+	downDir          = "down"
+	sumFile          = "atlas.sum"
+	sumHashPrefix    = "h1:"
+	migrationFileExt = ".sql"
+	versionSeparator = "_"
+)
+
+//go:embed migrations/*.sql migrations/atlas.sum migrations/down/*.sql
+var embedded embed.FS
+
+var ErrChecksumMismatch = errors.New("migration files do not match atlas.sum, run `atlas migrate hash` after editing migrations")
+
+type Migration struct {
+	Version     string
+	Description string
+	// Hash is the Atlas hash of the file, stored in the revisions table.
+	Hash string
+	Up   string
+	Down string
+}
+
+// Migrations returns the embedded migrations ordered by version, after checking them against atlas.sum.
+func Migrations() ([]Migration, error) {
+	dir, err := fs.Sub(embedded, migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadMigrations(dir)
+}
+
+// LatestVersion returns the version of the newest embedded migration.
+func LatestVersion() (string, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return "", err
+	}
+	if len(migrations) == 0 {
+		return "", nil
+	}
+
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// LoadMigrations reads a migration directory laid out like db/migrations: Atlas migration files,
+// their atlas.sum, and one down file per migration with the same name under down/.
+func LoadMigrations(dir fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(dir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), migrationFileExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	sum, err := fs.ReadFile(dir, sumFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", sumFile, err)
+	}
+	expected, err := parseSum(sum)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	files := make([]hashedFile, 0, len(names))
+	for _, name := range names {
+		up, err := fs.ReadFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		down, err := fs.ReadFile(dir, path.Join(downDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("could not read down migration of %s: %w", name, err)
+		}
+
+		version, description, _ := strings.Cut(strings.TrimSuffix(name, migrationFileExt), versionSeparator)
+		migrations = append(migrations, Migration{
+			Version:     version,
+			Description: description,
+			Up:          string(up),
+			Down:        string(down),
+		})
+		files = append(files, hashedFile{name: name, content: up})
+	}
+
+	actual := hashFiles(files)
+	if actual.total != expected.total || len(actual.files) != len(expected.files) {
+		return nil, ErrChecksumMismatch
+	}
+	for i, file := range actual.files {
+		if file != expected.files[i] {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, file.name)
+		}
+		migrations[i].Hash = file.hash
+	}
+
+	return migrations, nil
+}
+
+type hashedFile struct {
+	name    string
+	content []byte
+}
+
+type fileHash struct {
+	name string
+	hash string
+}
+
+type sumHashes struct {
+	total string
+	files []fileHash
+}
+
+// hashFiles computes atlas.sum hashes: each file hash chains the names and contents of all files up to it,
+// and the total hashes every name and file hash.
+func hashFiles(files []hashedFile) sumHashes {
+	var sum sumHashes
+
+	chain := sha256.New()
+	for _, file := range files {
+		chain.Write([]byte(file.name))
+		chain.Write(file.content)
+		sum.files = append(sum.files, fileHash{name: file.name, hash: base64.StdEncoding.EncodeToString(chain.Sum(nil))})
+	}
+
+	total := sha256.New()
+	for _, file := range sum.files {
+		total.Write([]byte(file.name))
+		total.Write([]byte(file.hash))
+	}
+	sum.total = base64.StdEncoding.EncodeToString(total.Sum(nil))
+
+	return sum
+}
+
+func parseSum(content []byte) (sumHashes, error) {
+	var sum sumHashes
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 0; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if line == 0 {
+			sum.total = strings.TrimPrefix(text, sumHashPrefix)
+			continue
+		}
+
+		name, hash, ok := strings.Cut(text, " ")
+		if !ok || !strings.HasPrefix(hash, sumHashPrefix) {
+			return sum, fmt.Errorf("malformed %s line %d", sumFile, line+1)
+		}
+		sum.files = append(sum.files, fileHash{name: name, hash: strings.TrimPrefix(hash, sumHashPrefix)})
+	}
+
+	return sum, scanner.Err()
+}
@@ -0,0 +1,2 @@
+-- Modify "hotels" table
+ALTER TABLE "hotels" ADD COLUMN "policies" jsonb NULL;
@@ -0,0 +1,4 @@
+-- Modify "hotels" table
+ALTER TABLE "hotels" ADD COLUMN "country_code" text NULL;
+-- Create index "idx_hotels_country_code" to table: "hotels"
+CREATE INDEX "idx_hotels_country_code" ON "hotels" ("country_code");
@@ -0,0 +1,26 @@
+-- Create "hotel_duplicates" table
+CREATE TABLE "hotel_duplicates" (
+  "id" serial NOT NULL,
+  "hotel_id" text NOT NULL,
+  "duplicate_hotel_id" text NOT NULL,
+  "destination_id" text NOT NULL,
+  "score" double precision NOT NULL,
+  "name_score" double precision NOT NULL,
+  "address_score" double precision NULL,
+  "distance_km" double precision NULL,
+  "status" text NOT NULL DEFAULT 'pending',
+  "canonical_hotel_id" text NULL,
+  "created_at" timestamptz NULL DEFAULT now(),
+  "updated_at" timestamptz NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "hotel_duplicates_hotel_id_duplicate_hotel_id_key" UNIQUE ("hotel_id", "duplicate_hotel_id")
+);
+-- Create index "idx_hotel_duplicates_status" to table: "hotel_duplicates"
+CREATE INDEX "idx_hotel_duplicates_status" ON "hotel_duplicates" ("status");
+-- Create "hotel_redirects" table
+CREATE TABLE "hotel_redirects" (
+  "hotel_id" text NOT NULL,
+  "canonical_hotel_id" text NOT NULL,
+  "created_at" timestamptz NULL DEFAULT now(),
+  PRIMARY KEY ("hotel_id")
+);
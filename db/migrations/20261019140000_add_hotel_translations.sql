@@ -0,0 +1,12 @@
+-- Create "hotel_translations" table
+CREATE TABLE "hotel_translations" (
+  "hotel_id" text NOT NULL,
+  "locale" text NOT NULL,
+  "name" text NULL,
+  "description" text NULL,
+  "amenity_labels" jsonb NOT NULL DEFAULT '{}',
+  "booking_conditions" text[] NULL,
+  "created_at" timestamptz NULL DEFAULT now(),
+  "updated_at" timestamptz NULL DEFAULT now(),
+  PRIMARY KEY ("hotel_id", "locale")
+);
@@ -0,0 +1,22 @@
+-- Create "destinations" table
+CREATE TABLE "destinations" (
+  "id" text NOT NULL,
+  "name" text NOT NULL,
+  "country_code" text NULL,
+  "latitude" double precision NULL,
+  "longitude" double precision NULL,
+  "timezone" text NULL,
+  "created_at" timestamptz NULL DEFAULT now(),
+  "updated_at" timestamptz NULL DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+-- Backfill the destinations of stored hotels
+INSERT INTO "destinations" ("id", "name", "country_code", "latitude", "longitude")
+SELECT
+  "destination_id",
+  COALESCE(MODE() WITHIN GROUP (ORDER BY "location"->>'city'), "destination_id"),
+  MODE() WITHIN GROUP (ORDER BY "country_code"),
+  AVG(("location"->>'latitude')::double precision) FILTER (WHERE "data_quality"->'location'->>'status' IN ('ok', 'repaired')),
+  AVG(("location"->>'longitude')::double precision) FILTER (WHERE "data_quality"->'location'->>'status' IN ('ok', 'repaired'))
+FROM "hotels"
+GROUP BY "destination_id";
@@ -0,0 +1,815 @@
+-- Backfill "country_code" of hotels stored before it was added, from the country of their location.
+-- Countries are matched on their alpha-2 code, alpha-3 code, name or alias folded as geo.Key folds them:
+-- diacritics removed, lowercased and other characters collapsed to single spaces.
+UPDATE "hotels" SET "country_code" = "countries"."code"
+FROM (VALUES
+  ('abw', 'AW'),
+  ('ad', 'AD'),
+  ('ae', 'AE'),
+  ('af', 'AF'),
+  ('afg', 'AF'),
+  ('afghanistan', 'AF'),
+  ('ag', 'AG'),
+  ('ago', 'AO'),
+  ('ai', 'AI'),
+  ('aia', 'AI'),
+  ('al', 'AL'),
+  ('ala', 'AX'),
+  ('aland islands', 'AX'),
+  ('alb', 'AL'),
+  ('albania', 'AL'),
+  ('algeria', 'DZ'),
+  ('am', 'AM'),
+  ('america', 'US'),
+  ('american samoa', 'AS'),
+  ('and', 'AD'),
+  ('andorra', 'AD'),
+  ('angola', 'AO'),
+  ('anguilla', 'AI'),
+  ('antarctica', 'AQ'),
+  ('antigua and barbuda', 'AG'),
+  ('antigua barbuda', 'AG'),
+  ('ao', 'AO'),
+  ('aq', 'AQ'),
+  ('ar', 'AR'),
+  ('are', 'AE'),
+  ('arg', 'AR'),
+  ('argentina', 'AR'),
+  ('arm', 'AM'),
+  ('armenia', 'AM'),
+  ('aruba', 'AW'),
+  ('as', 'AS'),
+  ('asm', 'AS'),
+  ('at', 'AT'),
+  ('ata', 'AQ'),
+  ('atf', 'TF'),
+  ('atg', 'AG'),
+  ('au', 'AU'),
+  ('aus', 'AU'),
+  ('australia', 'AU'),
+  ('austria', 'AT'),
+  ('aut', 'AT'),
+  ('aw', 'AW'),
+  ('ax', 'AX'),
+  ('az', 'AZ'),
+  ('aze', 'AZ'),
+  ('azerbaijan', 'AZ'),
+  ('ba', 'BA'),
+  ('bahamas', 'BS'),
+  ('bahrain', 'BH'),
+  ('bangladesh', 'BD'),
+  ('barbados', 'BB'),
+  ('bb', 'BB'),
+  ('bd', 'BD'),
+  ('bdi', 'BI'),
+  ('be', 'BE'),
+  ('bel', 'BE'),
+  ('belarus', 'BY'),
+  ('belgium', 'BE'),
+  ('belize', 'BZ'),
+  ('ben', 'BJ'),
+  ('benin', 'BJ'),
+  ('bermuda', 'BM'),
+  ('bes', 'BQ'),
+  ('bf', 'BF'),
+  ('bfa', 'BF'),
+  ('bg', 'BG'),
+  ('bgd', 'BD'),
+  ('bgr', 'BG'),
+  ('bh', 'BH'),
+  ('bhr', 'BH'),
+  ('bhs', 'BS'),
+  ('bhutan', 'BT'),
+  ('bi', 'BI'),
+  ('bih', 'BA'),
+  ('bj', 'BJ'),
+  ('bl', 'BL'),
+  ('blm', 'BL'),
+  ('blr', 'BY'),
+  ('blz', 'BZ'),
+  ('bm', 'BM'),
+  ('bmu', 'BM'),
+  ('bn', 'BN'),
+  ('bo', 'BO'),
+  ('bol', 'BO'),
+  ('bolivarian republic of venezuela', 'VE'),
+  ('bolivia', 'BO'),
+  ('bosnia and herzegovina', 'BA'),
+  ('bosnia herzegovina', 'BA'),
+  ('botswana', 'BW'),
+  ('bouvet island', 'BV'),
+  ('bq', 'BQ'),
+  ('br', 'BR'),
+  ('bra', 'BR'),
+  ('brazil', 'BR'),
+  ('brb', 'BB'),
+  ('britain', 'GB'),
+  ('british indian ocean territory', 'IO'),
+  ('british virgin islands', 'VG'),
+  ('brn', 'BN'),
+  ('brunei', 'BN'),
+  ('brunei darussalam', 'BN'),
+  ('bs', 'BS'),
+  ('bt', 'BT'),
+  ('btn', 'BT'),
+  ('bulgaria', 'BG'),
+  ('burkina faso', 'BF'),
+  ('burma', 'MM'),
+  ('burundi', 'BI'),
+  ('bv', 'BV'),
+  ('bvt', 'BV'),
+  ('bw', 'BW'),
+  ('bwa', 'BW'),
+  ('by', 'BY'),
+  ('bz', 'BZ'),
+  ('ca', 'CA'),
+  ('cabo verde', 'CV'),
+  ('caf', 'CF'),
+  ('cambodia', 'KH'),
+  ('cameroon', 'CM'),
+  ('can', 'CA'),
+  ('canada', 'CA'),
+  ('cape verde', 'CV'),
+  ('caribbean netherlands', 'BQ'),
+  ('cayman islands', 'KY'),
+  ('cc', 'CC'),
+  ('cck', 'CC'),
+  ('cd', 'CD'),
+  ('central african republic', 'CF'),
+  ('cf', 'CF'),
+  ('cg', 'CG'),
+  ('ch', 'CH'),
+  ('chad', 'TD'),
+  ('che', 'CH'),
+  ('chile', 'CL'),
+  ('china', 'CN'),
+  ('chl', 'CL'),
+  ('chn', 'CN'),
+  ('christmas island', 'CX'),
+  ('ci', 'CI'),
+  ('civ', 'CI'),
+  ('ck', 'CK'),
+  ('cl', 'CL'),
+  ('cm', 'CM'),
+  ('cmr', 'CM'),
+  ('cn', 'CN'),
+  ('co', 'CO'),
+  ('cocos keeling islands', 'CC'),
+  ('cod', 'CD'),
+  ('cog', 'CG'),
+  ('cok', 'CK'),
+  ('col', 'CO'),
+  ('colombia', 'CO'),
+  ('com', 'KM'),
+  ('comoros', 'KM'),
+  ('congo', 'CG'),
+  ('congo brazzaville', 'CG'),
+  ('congo kinshasa', 'CD'),
+  ('cook islands', 'CK'),
+  ('costa rica', 'CR'),
+  ('cote d ivoire', 'CI'),
+  ('cpv', 'CV'),
+  ('cr', 'CR'),
+  ('cri', 'CR'),
+  ('croatia', 'HR'),
+  ('cu', 'CU'),
+  ('cub', 'CU'),
+  ('cuba', 'CU'),
+  ('curacao', 'CW'),
+  ('cuw', 'CW'),
+  ('cv', 'CV'),
+  ('cw', 'CW'),
+  ('cx', 'CX'),
+  ('cxr', 'CX'),
+  ('cy', 'CY'),
+  ('cym', 'KY'),
+  ('cyp', 'CY'),
+  ('cyprus', 'CY'),
+  ('cz', 'CZ'),
+  ('cze', 'CZ'),
+  ('czech republic', 'CZ'),
+  ('czechia', 'CZ'),
+  ('de', 'DE'),
+  ('democratic people s republic of korea', 'KP'),
+  ('democratic republic of the congo', 'CD'),
+  ('denmark', 'DK'),
+  ('deu', 'DE'),
+  ('dj', 'DJ'),
+  ('dji', 'DJ'),
+  ('djibouti', 'DJ'),
+  ('dk', 'DK'),
+  ('dm', 'DM'),
+  ('dma', 'DM'),
+  ('dnk', 'DK'),
+  ('do', 'DO'),
+  ('dom', 'DO'),
+  ('dominica', 'DM'),
+  ('dominican republic', 'DO'),
+  ('dr congo', 'CD'),
+  ('drc', 'CD'),
+  ('dz', 'DZ'),
+  ('dza', 'DZ'),
+  ('ec', 'EC'),
+  ('ecu', 'EC'),
+  ('ecuador', 'EC'),
+  ('ee', 'EE'),
+  ('eg', 'EG'),
+  ('egy', 'EG'),
+  ('egypt', 'EG'),
+  ('eh', 'EH'),
+  ('el salvador', 'SV'),
+  ('emirates', 'AE'),
+  ('england', 'GB'),
+  ('equatorial guinea', 'GQ'),
+  ('er', 'ER'),
+  ('eri', 'ER'),
+  ('eritrea', 'ER'),
+  ('es', 'ES'),
+  ('esh', 'EH'),
+  ('esp', 'ES'),
+  ('est', 'EE'),
+  ('estonia', 'EE'),
+  ('eswatini', 'SZ'),
+  ('et', 'ET'),
+  ('eth', 'ET'),
+  ('ethiopia', 'ET'),
+  ('falkland islands', 'FK'),
+  ('faroe islands', 'FO'),
+  ('fi', 'FI'),
+  ('fiji', 'FJ'),
+  ('fin', 'FI'),
+  ('finland', 'FI'),
+  ('fj', 'FJ'),
+  ('fji', 'FJ'),
+  ('fk', 'FK'),
+  ('flk', 'FK'),
+  ('fm', 'FM'),
+  ('fo', 'FO'),
+  ('fr', 'FR'),
+  ('fra', 'FR'),
+  ('france', 'FR'),
+  ('french guiana', 'GF'),
+  ('french polynesia', 'PF'),
+  ('french southern territories', 'TF'),
+  ('fro', 'FO'),
+  ('fsm', 'FM'),
+  ('ga', 'GA'),
+  ('gab', 'GA'),
+  ('gabon', 'GA'),
+  ('gambia', 'GM'),
+  ('gb', 'GB'),
+  ('gbr', 'GB'),
+  ('gd', 'GD'),
+  ('ge', 'GE'),
+  ('geo', 'GE'),
+  ('georgia', 'GE'),
+  ('germany', 'DE'),
+  ('gf', 'GF'),
+  ('gg', 'GG'),
+  ('ggy', 'GG'),
+  ('gh', 'GH'),
+  ('gha', 'GH'),
+  ('ghana', 'GH'),
+  ('gi', 'GI'),
+  ('gib', 'GI'),
+  ('gibraltar', 'GI'),
+  ('gin', 'GN'),
+  ('gl', 'GL'),
+  ('glp', 'GP'),
+  ('gm', 'GM'),
+  ('gmb', 'GM'),
+  ('gn', 'GN'),
+  ('gnb', 'GW'),
+  ('gnq', 'GQ'),
+  ('gp', 'GP'),
+  ('gq', 'GQ'),
+  ('gr', 'GR'),
+  ('grc', 'GR'),
+  ('grd', 'GD'),
+  ('great britain', 'GB'),
+  ('greece', 'GR'),
+  ('greenland', 'GL'),
+  ('grenada', 'GD'),
+  ('grl', 'GL'),
+  ('gs', 'GS'),
+  ('gt', 'GT'),
+  ('gtm', 'GT'),
+  ('gu', 'GU'),
+  ('guadeloupe', 'GP'),
+  ('guam', 'GU'),
+  ('guatemala', 'GT'),
+  ('guernsey', 'GG'),
+  ('guf', 'GF'),
+  ('guinea', 'GN'),
+  ('guinea bissau', 'GW'),
+  ('gum', 'GU'),
+  ('guy', 'GY'),
+  ('guyana', 'GY'),
+  ('gw', 'GW'),
+  ('gy', 'GY'),
+  ('haiti', 'HT'),
+  ('heard island and mcdonald islands', 'HM'),
+  ('heard mcdonald islands', 'HM'),
+  ('hk', 'HK'),
+  ('hkg', 'HK'),
+  ('hm', 'HM'),
+  ('hmd', 'HM'),
+  ('hn', 'HN'),
+  ('hnd', 'HN'),
+  ('holland', 'NL'),
+  ('holy see', 'VA'),
+  ('honduras', 'HN'),
+  ('hong kong', 'HK'),
+  ('hong kong sar', 'HK'),
+  ('hr', 'HR'),
+  ('hrv', 'HR'),
+  ('ht', 'HT'),
+  ('hti', 'HT'),
+  ('hu', 'HU'),
+  ('hun', 'HU'),
+  ('hungary', 'HU'),
+  ('iceland', 'IS'),
+  ('id', 'ID'),
+  ('idn', 'ID'),
+  ('ie', 'IE'),
+  ('il', 'IL'),
+  ('im', 'IM'),
+  ('imn', 'IM'),
+  ('in', 'IN'),
+  ('ind', 'IN'),
+  ('india', 'IN'),
+  ('indonesia', 'ID'),
+  ('io', 'IO'),
+  ('iot', 'IO'),
+  ('iq', 'IQ'),
+  ('ir', 'IR'),
+  ('iran', 'IR'),
+  ('iraq', 'IQ'),
+  ('ireland', 'IE'),
+  ('irl', 'IE'),
+  ('irn', 'IR'),
+  ('irq', 'IQ'),
+  ('is', 'IS'),
+  ('isl', 'IS'),
+  ('islamic republic of iran', 'IR'),
+  ('isle of man', 'IM'),
+  ('isr', 'IL'),
+  ('israel', 'IL'),
+  ('it', 'IT'),
+  ('ita', 'IT'),
+  ('italy', 'IT'),
+  ('ivory coast', 'CI'),
+  ('jam', 'JM'),
+  ('jamaica', 'JM'),
+  ('japan', 'JP'),
+  ('je', 'JE'),
+  ('jersey', 'JE'),
+  ('jey', 'JE'),
+  ('jm', 'JM'),
+  ('jo', 'JO'),
+  ('jor', 'JO'),
+  ('jordan', 'JO'),
+  ('jp', 'JP'),
+  ('jpn', 'JP'),
+  ('kaz', 'KZ'),
+  ('kazakhstan', 'KZ'),
+  ('ke', 'KE'),
+  ('ken', 'KE'),
+  ('kenya', 'KE'),
+  ('kg', 'KG'),
+  ('kgz', 'KG'),
+  ('kh', 'KH'),
+  ('khm', 'KH'),
+  ('ki', 'KI'),
+  ('kir', 'KI'),
+  ('kiribati', 'KI'),
+  ('km', 'KM'),
+  ('kn', 'KN'),
+  ('kna', 'KN'),
+  ('kor', 'KR'),
+  ('korea', 'KR'),
+  ('kp', 'KP'),
+  ('kr', 'KR'),
+  ('kuwait', 'KW'),
+  ('kw', 'KW'),
+  ('kwt', 'KW'),
+  ('ky', 'KY'),
+  ('kyrgyzstan', 'KG'),
+  ('kz', 'KZ'),
+  ('la', 'LA'),
+  ('lao', 'LA'),
+  ('lao people s democratic republic', 'LA'),
+  ('laos', 'LA'),
+  ('latvia', 'LV'),
+  ('lb', 'LB'),
+  ('lbn', 'LB'),
+  ('lbr', 'LR'),
+  ('lby', 'LY'),
+  ('lc', 'LC'),
+  ('lca', 'LC'),
+  ('lebanon', 'LB'),
+  ('lesotho', 'LS'),
+  ('li', 'LI'),
+  ('liberia', 'LR'),
+  ('libya', 'LY'),
+  ('lie', 'LI'),
+  ('liechtenstein', 'LI'),
+  ('lithuania', 'LT'),
+  ('lk', 'LK'),
+  ('lka', 'LK'),
+  ('lr', 'LR'),
+  ('ls', 'LS'),
+  ('lso', 'LS'),
+  ('lt', 'LT'),
+  ('ltu', 'LT'),
+  ('lu', 'LU'),
+  ('lux', 'LU'),
+  ('luxembourg', 'LU'),
+  ('lv', 'LV'),
+  ('lva', 'LV'),
+  ('ly', 'LY'),
+  ('ma', 'MA'),
+  ('mac', 'MO'),
+  ('macao', 'MO'),
+  ('macau', 'MO'),
+  ('macedonia', 'MK'),
+  ('madagascar', 'MG'),
+  ('maf', 'MF'),
+  ('malawi', 'MW'),
+  ('malaysia', 'MY'),
+  ('maldives', 'MV'),
+  ('mali', 'ML'),
+  ('malta', 'MT'),
+  ('mar', 'MA'),
+  ('marshall islands', 'MH'),
+  ('martinique', 'MQ'),
+  ('mauritania', 'MR'),
+  ('mauritius', 'MU'),
+  ('mayotte', 'YT'),
+  ('mc', 'MC'),
+  ('mco', 'MC'),
+  ('md', 'MD'),
+  ('mda', 'MD'),
+  ('mdg', 'MG'),
+  ('mdv', 'MV'),
+  ('me', 'ME'),
+  ('mex', 'MX'),
+  ('mexico', 'MX'),
+  ('mf', 'MF'),
+  ('mg', 'MG'),
+  ('mh', 'MH'),
+  ('mhl', 'MH'),
+  ('micronesia', 'FM'),
+  ('mk', 'MK'),
+  ('mkd', 'MK'),
+  ('ml', 'ML'),
+  ('mli', 'ML'),
+  ('mlt', 'MT'),
+  ('mm', 'MM'),
+  ('mmr', 'MM'),
+  ('mn', 'MN'),
+  ('mne', 'ME'),
+  ('mng', 'MN'),
+  ('mnp', 'MP'),
+  ('mo', 'MO'),
+  ('moldova', 'MD'),
+  ('monaco', 'MC'),
+  ('mongolia', 'MN'),
+  ('montenegro', 'ME'),
+  ('montserrat', 'MS'),
+  ('morocco', 'MA'),
+  ('moz', 'MZ'),
+  ('mozambique', 'MZ'),
+  ('mp', 'MP'),
+  ('mq', 'MQ'),
+  ('mr', 'MR'),
+  ('mrt', 'MR'),
+  ('ms', 'MS'),
+  ('msr', 'MS'),
+  ('mt', 'MT'),
+  ('mtq', 'MQ'),
+  ('mu', 'MU'),
+  ('mus', 'MU'),
+  ('mv', 'MV'),
+  ('mw', 'MW'),
+  ('mwi', 'MW'),
+  ('mx', 'MX'),
+  ('my', 'MY'),
+  ('myanmar', 'MM'),
+  ('mys', 'MY'),
+  ('myt', 'YT'),
+  ('mz', 'MZ'),
+  ('na', 'NA'),
+  ('nam', 'NA'),
+  ('namibia', 'NA'),
+  ('nauru', 'NR'),
+  ('nc', 'NC'),
+  ('ncl', 'NC'),
+  ('ne', 'NE'),
+  ('nepal', 'NP'),
+  ('ner', 'NE'),
+  ('netherlands', 'NL'),
+  ('new caledonia', 'NC'),
+  ('new zealand', 'NZ'),
+  ('nf', 'NF'),
+  ('nfk', 'NF'),
+  ('ng', 'NG'),
+  ('nga', 'NG'),
+  ('ni', 'NI'),
+  ('nic', 'NI'),
+  ('nicaragua', 'NI'),
+  ('niger', 'NE'),
+  ('nigeria', 'NG'),
+  ('niu', 'NU'),
+  ('niue', 'NU'),
+  ('nl', 'NL'),
+  ('nld', 'NL'),
+  ('no', 'NO'),
+  ('nor', 'NO'),
+  ('norfolk island', 'NF'),
+  ('north korea', 'KP'),
+  ('north macedonia', 'MK'),
+  ('northern ireland', 'GB'),
+  ('northern mariana islands', 'MP'),
+  ('norway', 'NO'),
+  ('np', 'NP'),
+  ('npl', 'NP'),
+  ('nr', 'NR'),
+  ('nru', 'NR'),
+  ('nu', 'NU'),
+  ('nz', 'NZ'),
+  ('nzl', 'NZ'),
+  ('om', 'OM'),
+  ('oman', 'OM'),
+  ('omn', 'OM'),
+  ('pa', 'PA'),
+  ('pak', 'PK'),
+  ('pakistan', 'PK'),
+  ('palau', 'PW'),
+  ('palestine', 'PS'),
+  ('palestinian territories', 'PS'),
+  ('pan', 'PA'),
+  ('panama', 'PA'),
+  ('papua new guinea', 'PG'),
+  ('paraguay', 'PY'),
+  ('pcn', 'PN'),
+  ('pe', 'PE'),
+  ('per', 'PE'),
+  ('peru', 'PE'),
+  ('pf', 'PF'),
+  ('pg', 'PG'),
+  ('ph', 'PH'),
+  ('philippines', 'PH'),
+  ('phl', 'PH'),
+  ('pitcairn islands', 'PN'),
+  ('pk', 'PK'),
+  ('pl', 'PL'),
+  ('plurinational state of bolivia', 'BO'),
+  ('plw', 'PW'),
+  ('pm', 'PM'),
+  ('pn', 'PN'),
+  ('png', 'PG'),
+  ('pol', 'PL'),
+  ('poland', 'PL'),
+  ('portugal', 'PT'),
+  ('pr', 'PR'),
+  ('pri', 'PR'),
+  ('prk', 'KP'),
+  ('prt', 'PT'),
+  ('pry', 'PY'),
+  ('ps', 'PS'),
+  ('pse', 'PS'),
+  ('pt', 'PT'),
+  ('puerto rico', 'PR'),
+  ('pw', 'PW'),
+  ('py', 'PY'),
+  ('pyf', 'PF'),
+  ('qa', 'QA'),
+  ('qat', 'QA'),
+  ('qatar', 'QA'),
+  ('re', 'RE'),
+  ('republic of korea', 'KR'),
+  ('republic of moldova', 'MD'),
+  ('republic of the congo', 'CG'),
+  ('reu', 'RE'),
+  ('reunion', 'RE'),
+  ('ro', 'RO'),
+  ('romania', 'RO'),
+  ('rou', 'RO'),
+  ('rs', 'RS'),
+  ('ru', 'RU'),
+  ('rus', 'RU'),
+  ('russia', 'RU'),
+  ('russian federation', 'RU'),
+  ('rw', 'RW'),
+  ('rwa', 'RW'),
+  ('rwanda', 'RW'),
+  ('sa', 'SA'),
+  ('saint barthelemy', 'BL'),
+  ('saint helena', 'SH'),
+  ('saint kitts and nevis', 'KN'),
+  ('saint lucia', 'LC'),
+  ('saint martin', 'MF'),
+  ('saint pierre and miquelon', 'PM'),
+  ('saint vincent and the grenadines', 'VC'),
+  ('samoa', 'WS'),
+  ('san marino', 'SM'),
+  ('sao tome and principe', 'ST'),
+  ('sao tome principe', 'ST'),
+  ('sau', 'SA'),
+  ('saudi arabia', 'SA'),
+  ('sb', 'SB'),
+  ('sc', 'SC'),
+  ('scotland', 'GB'),
+  ('sd', 'SD'),
+  ('sdn', 'SD'),
+  ('se', 'SE'),
+  ('sen', 'SN'),
+  ('senegal', 'SN'),
+  ('serbia', 'RS'),
+  ('seychelles', 'SC'),
+  ('sg', 'SG'),
+  ('sgp', 'SG'),
+  ('sgs', 'GS'),
+  ('sh', 'SH'),
+  ('shn', 'SH'),
+  ('si', 'SI'),
+  ('sierra leone', 'SL'),
+  ('singapore', 'SG'),
+  ('sint maarten', 'SX'),
+  ('sj', 'SJ'),
+  ('sjm', 'SJ'),
+  ('sk', 'SK'),
+  ('sl', 'SL'),
+  ('slb', 'SB'),
+  ('sle', 'SL'),
+  ('slovakia', 'SK'),
+  ('slovenia', 'SI'),
+  ('slv', 'SV'),
+  ('sm', 'SM'),
+  ('smr', 'SM'),
+  ('sn', 'SN'),
+  ('so', 'SO'),
+  ('solomon islands', 'SB'),
+  ('som', 'SO'),
+  ('somalia', 'SO'),
+  ('south africa', 'ZA'),
+  ('south georgia and the south sandwich islands', 'GS'),
+  ('south georgia south sandwich islands', 'GS'),
+  ('south korea', 'KR'),
+  ('south sudan', 'SS'),
+  ('spain', 'ES'),
+  ('spm', 'PM'),
+  ('sr', 'SR'),
+  ('srb', 'RS'),
+  ('sri lanka', 'LK'),
+  ('ss', 'SS'),
+  ('ssd', 'SS'),
+  ('st', 'ST'),
+  ('st barthelemy', 'BL'),
+  ('st helena', 'SH'),
+  ('st kitts nevis', 'KN'),
+  ('st lucia', 'LC'),
+  ('st martin', 'MF'),
+  ('st pierre miquelon', 'PM'),
+  ('st vincent grenadines', 'VC'),
+  ('stp', 'ST'),
+  ('sudan', 'SD'),
+  ('sur', 'SR'),
+  ('suriname', 'SR'),
+  ('sv', 'SV'),
+  ('svalbard and jan mayen', 'SJ'),
+  ('svalbard jan mayen', 'SJ'),
+  ('svk', 'SK'),
+  ('svn', 'SI'),
+  ('swaziland', 'SZ'),
+  ('swe', 'SE'),
+  ('sweden', 'SE'),
+  ('switzerland', 'CH'),
+  ('swz', 'SZ'),
+  ('sx', 'SX'),
+  ('sxm', 'SX'),
+  ('sy', 'SY'),
+  ('syc', 'SC'),
+  ('syr', 'SY'),
+  ('syria', 'SY'),
+  ('syrian arab republic', 'SY'),
+  ('sz', 'SZ'),
+  ('taiwan', 'TW'),
+  ('taiwan province of china', 'TW'),
+  ('tajikistan', 'TJ'),
+  ('tanzania', 'TZ'),
+  ('tc', 'TC'),
+  ('tca', 'TC'),
+  ('tcd', 'TD'),
+  ('td', 'TD'),
+  ('tf', 'TF'),
+  ('tg', 'TG'),
+  ('tgo', 'TG'),
+  ('th', 'TH'),
+  ('tha', 'TH'),
+  ('thailand', 'TH'),
+  ('the netherlands', 'NL'),
+  ('timor leste', 'TL'),
+  ('tj', 'TJ'),
+  ('tjk', 'TJ'),
+  ('tk', 'TK'),
+  ('tkl', 'TK'),
+  ('tkm', 'TM'),
+  ('tl', 'TL'),
+  ('tls', 'TL'),
+  ('tm', 'TM'),
+  ('tn', 'TN'),
+  ('to', 'TO'),
+  ('togo', 'TG'),
+  ('tokelau', 'TK'),
+  ('ton', 'TO'),
+  ('tonga', 'TO'),
+  ('tr', 'TR'),
+  ('trinidad and tobago', 'TT'),
+  ('trinidad tobago', 'TT'),
+  ('tt', 'TT'),
+  ('tto', 'TT'),
+  ('tun', 'TN'),
+  ('tunisia', 'TN'),
+  ('tur', 'TR'),
+  ('turkey', 'TR'),
+  ('turkiye', 'TR'),
+  ('turkmenistan', 'TM'),
+  ('turks and caicos islands', 'TC'),
+  ('turks caicos islands', 'TC'),
+  ('tuv', 'TV'),
+  ('tuvalu', 'TV'),
+  ('tv', 'TV'),
+  ('tw', 'TW'),
+  ('twn', 'TW'),
+  ('tz', 'TZ'),
+  ('tza', 'TZ'),
+  ('u s outlying islands', 'UM'),
+  ('u s virgin islands', 'VI'),
+  ('ua', 'UA'),
+  ('uae', 'AE'),
+  ('ug', 'UG'),
+  ('uga', 'UG'),
+  ('uganda', 'UG'),
+  ('uk', 'GB'),
+  ('ukr', 'UA'),
+  ('ukraine', 'UA'),
+  ('um', 'UM'),
+  ('umi', 'UM'),
+  ('united arab emirates', 'AE'),
+  ('united kingdom', 'GB'),
+  ('united republic of tanzania', 'TZ'),
+  ('united states', 'US'),
+  ('united states of america', 'US'),
+  ('uruguay', 'UY'),
+  ('ury', 'UY'),
+  ('us', 'US'),
+  ('usa', 'US'),
+  ('uy', 'UY'),
+  ('uz', 'UZ'),
+  ('uzb', 'UZ'),
+  ('uzbekistan', 'UZ'),
+  ('va', 'VA'),
+  ('vanuatu', 'VU'),
+  ('vat', 'VA'),
+  ('vatican', 'VA'),
+  ('vatican city', 'VA'),
+  ('vc', 'VC'),
+  ('vct', 'VC'),
+  ('ve', 'VE'),
+  ('ven', 'VE'),
+  ('venezuela', 'VE'),
+  ('vg', 'VG'),
+  ('vgb', 'VG'),
+  ('vi', 'VI'),
+  ('viet nam', 'VN'),
+  ('vietnam', 'VN'),
+  ('vir', 'VI'),
+  ('vn', 'VN'),
+  ('vnm', 'VN'),
+  ('vu', 'VU'),
+  ('vut', 'VU'),
+  ('wales', 'GB'),
+  ('wallis and futuna', 'WF'),
+  ('wallis futuna', 'WF'),
+  ('western sahara', 'EH'),
+  ('wf', 'WF'),
+  ('wlf', 'WF'),
+  ('ws', 'WS'),
+  ('wsm', 'WS'),
+  ('ye', 'YE'),
+  ('yem', 'YE'),
+  ('yemen', 'YE'),
+  ('yt', 'YT'),
+  ('za', 'ZA'),
+  ('zaf', 'ZA'),
+  ('zambia', 'ZM'),
+  ('zimbabwe', 'ZW'),
+  ('zm', 'ZM'),
+  ('zmb', 'ZM'),
+  ('zw', 'ZW'),
+  ('zwe', 'ZW')
+) AS "countries" ("key", "code")
+WHERE "hotels"."country_code" IS NULL
+  AND btrim(regexp_replace(lower(translate("hotels"."location"->>'country', 'ÀÁÂÃÄÅÇÈÉÊËÌÍÎÏÑÒÓÔÕÖÙÚÛÜÝàáâãäåçèéêëìíîïñòóôõöùúûüýÿĀāĂăĄąĆćĈĉĊċČčĎďĒēĔĕĖėĘęĚěĜĝĞğĠġĢģĤĥĨĩĪīĬĭĮįİĴĵĶķĹĺĻļĽľŃńŅņŇňŌōŎŏŐőŔŕŖŗŘřŚśŜŝŞşŠšŢţŤťŨũŪūŬŭŮůŰűŲųŴŵŶŷŸŹźŻżŽžƠơƯưǍǎǏǐǑǒǓǔǕǖǗǘǙǚǛǜǞǟǠǡǦǧǨǩǪǫǬǭǰǴǵǸǹǺǻȀȁȂȃȄȅȆȇȈȉȊȋȌȍȎȏȐȑȒȓȔȕȖȗȘșȚțȞȟȦȧȨȩȪȫȬȭȮȯȰȱȲȳ', 'aaaaaaceeeeiiiinooooouuuuyaaaaaaceeeeiiiinooooouuuuyyaaaaaaccccccccddeeeeeeeeeegggggggghhiiiiiiiiijjkkllllllnnnnnnoooooorrrrrrssssssssttttuuuuuuuuuuuuwwyyyzzzzzzoouuaaiioouuuuuuuuuuaaaaggkkoooojggnnaaaaaaeeeeiiiioooorrrruuuusstthhaaeeooooooooyy')), '[^a-z0-9]+', ' ', 'g')) = "countries"."key";
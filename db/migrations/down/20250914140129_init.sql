@@ -0,0 +1,4 @@
+-- Drop index "idx_hotels_destination_id" from table: "hotels"
+DROP INDEX "idx_hotels_destination_id";
+-- Drop "hotels" table
+DROP TABLE "hotels";
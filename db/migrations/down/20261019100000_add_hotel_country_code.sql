@@ -0,0 +1,4 @@
+-- Drop index "idx_hotels_country_code" from table: "hotels"
+DROP INDEX "idx_hotels_country_code";
+-- Modify "hotels" table
+ALTER TABLE "hotels" DROP COLUMN "country_code";
@@ -0,0 +1,6 @@
+-- Drop "hotel_redirects" table
+DROP TABLE "hotel_redirects";
+-- Drop index "idx_hotel_duplicates_status" from table: "hotel_duplicates"
+DROP INDEX "idx_hotel_duplicates_status";
+-- Drop "hotel_duplicates" table
+DROP TABLE "hotel_duplicates";
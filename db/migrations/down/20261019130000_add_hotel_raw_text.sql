@@ -0,0 +1,2 @@
+-- Modify "hotels" table
+ALTER TABLE "hotels" DROP COLUMN "raw_text";
@@ -0,0 +1,2 @@
+-- Drop "hotel_translations" table
+DROP TABLE "hotel_translations";
@@ -0,0 +1,2 @@
+-- Drop "destinations" table
+DROP TABLE "destinations";
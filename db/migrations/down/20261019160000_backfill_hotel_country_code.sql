@@ -0,0 +1 @@
+-- The backfilled country codes are kept, as hotels stored since the backfill set them too.
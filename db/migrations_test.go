@@ -0,0 +1,56 @@
+package db_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/duylamasd/hotels-merge/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_MatchAtlasSum(t *testing.T) {
+	migrations, err := db.Migrations()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, "20250914140129", migrations[0].Version)
+	assert.Equal(t, "init", migrations[0].Description)
+	assert.Equal(t, "dCLUOLfpDIrs83Av3CCLjdzuEuUCLketMV2omYWvulQ=", migrations[0].Hash)
+	assert.NotEmpty(t, migrations[0].Down)
+}
+
+func TestLoadMigrations_RejectsEditedFiles(t *testing.T) {
+	migrations, err := db.Migrations()
+	require.NoError(t, err)
+
+	dir := fstest.MapFS{
+		"atlas.sum":                    {Data: []byte("h1:htE9d/foM/5RVTRKwy9rzNMlix60zuxAoZwkyfjwbpw=\n20250914140129_init.sql h1:dCLUOLfpDIrs83Av3CCLjdzuEuUCLketMV2omYWvulQ=\n")},
+		"20250914140129_init.sql":      {Data: []byte(migrations[0].Up + "\nDROP TABLE hotels;\n")},
+		"down/20250914140129_init.sql": {Data: []byte(migrations[0].Down)},
+	}
+
+	_, err = db.LoadMigrations(dir)
+
+	assert.ErrorIs(t, err, db.ErrChecksumMismatch)
+}
+
+func TestCountStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want int
+	}{
+		{name: "comments only", sql: "-- nothing;\n/* here; */", want: 0},
+		{name: "simple statements", sql: "CREATE TABLE a (id int);\n-- comment\nCREATE INDEX i ON a (id);\n", want: 2},
+		{name: "quoted semicolons", sql: "INSERT INTO a VALUES ('x;y');", want: 1},
+		{name: "dollar quoted body", sql: "CREATE FUNCTION f() RETURNS void AS $$ BEGIN PERFORM 1; END; $$ LANGUAGE plpgsql;", want: 1},
+		{name: "missing trailing semicolon", sql: "SELECT 1; SELECT 2", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, db.CountStatements(tt.sql))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+-- name: ListDestinations :many
+SELECT d.id, d.name, d.country_code, d.latitude, d.longitude, d.timezone, d.created_at, d.updated_at, COUNT(h.id) AS hotel_count
+FROM destinations d
+LEFT JOIN hotels h ON h.destination_id = d.id
+GROUP BY d.id
+ORDER BY d.name, d.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountDestinations :one
+SELECT COUNT(*)
+FROM destinations;
+
+-- name: FindDestination :one
+SELECT d.id, d.name, d.country_code, d.latitude, d.longitude, d.timezone, d.created_at, d.updated_at, COUNT(h.id) AS hotel_count
+FROM destinations d
+LEFT JOIN hotels h ON h.destination_id = d.id
+WHERE d.id = $1
+GROUP BY d.id;
+
+-- name: UpsertDestination :exec
+INSERT INTO destinations (id, name, country_code, latitude, longitude, timezone)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO UPDATE SET
+  name = EXCLUDED.name,
+  country_code = EXCLUDED.country_code,
+  latitude = EXCLUDED.latitude,
+  longitude = EXCLUDED.longitude,
+  timezone = EXCLUDED.timezone,
+  updated_at = NOW();
+
+-- name: InsertMissingDestinations :exec
+-- Destinations of hotels are named after their most common city, in their most common country, centered
+-- on their checked coordinates. Existing destinations are left alone.
+INSERT INTO destinations (id, name, country_code, latitude, longitude)
+SELECT
+  destination_id,
+  COALESCE(MODE() WITHIN GROUP (ORDER BY location->>'city'), destination_id),
+  MODE() WITHIN GROUP (ORDER BY country_code),
+  AVG((location->>'latitude')::DOUBLE PRECISION) FILTER (WHERE data_quality->'location'->>'status' IN ('ok', 'repaired')),
+  AVG((location->>'longitude')::DOUBLE PRECISION) FILTER (WHERE data_quality->'location'->>'status' IN ('ok', 'repaired'))
+FROM hotels
+GROUP BY destination_id
+ON CONFLICT (id) DO NOTHING;
+
+-- name: RestoreDestination :exec
+INSERT INTO destinations (id, name, country_code, latitude, longitude, timezone, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
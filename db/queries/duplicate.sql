@@ -0,0 +1,66 @@
+-- name: ListHotelDuplicates :many
+SELECT *
+FROM hotel_duplicates
+WHERE status = sqlc.arg('status')::TEXT
+ORDER BY score DESC, id;
+
+-- name: FindHotelDuplicate :one
+SELECT *
+FROM hotel_duplicates
+WHERE id = $1;
+
+-- name: DeletePendingHotelDuplicates :exec
+DELETE FROM hotel_duplicates
+WHERE status = 'pending';
+
+-- name: DeletePendingHotelDuplicatesOfHotel :exec
+DELETE FROM hotel_duplicates
+WHERE status = 'pending'
+  AND (hotel_id = sqlc.arg('hotel_id') OR duplicate_hotel_id = sqlc.arg('hotel_id'));
+
+-- name: UpsertHotelDuplicate :exec
+INSERT INTO hotel_duplicates (hotel_id, duplicate_hotel_id, destination_id, score, name_score, address_score, distance_km)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (hotel_id, duplicate_hotel_id) DO UPDATE
+SET destination_id = EXCLUDED.destination_id,
+  score = EXCLUDED.score,
+  name_score = EXCLUDED.name_score,
+  address_score = EXCLUDED.address_score,
+  distance_km = EXCLUDED.distance_km,
+  updated_at = NOW()
+WHERE hotel_duplicates.status = 'pending';
+
+-- name: DecideHotelDuplicate :exec
+UPDATE hotel_duplicates
+SET status = sqlc.arg('status'),
+  canonical_hotel_id = sqlc.narg('canonical_hotel_id'),
+  updated_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: ListHotelRedirects :many
+SELECT *
+FROM hotel_redirects
+ORDER BY hotel_id;
+
+-- name: UpsertHotelRedirect :exec
+INSERT INTO hotel_redirects (hotel_id, canonical_hotel_id)
+VALUES ($1, $2)
+ON CONFLICT (hotel_id) DO UPDATE
+SET canonical_hotel_id = EXCLUDED.canonical_hotel_id;
+
+-- name: RepointHotelRedirects :exec
+UPDATE hotel_redirects
+SET canonical_hotel_id = sqlc.arg('canonical_hotel_id')
+WHERE canonical_hotel_id = sqlc.arg('hotel_id');
+
+-- name: RestoreHotelDuplicate :exec
+INSERT INTO hotel_duplicates (id, hotel_id, duplicate_hotel_id, destination_id, score, name_score, address_score, distance_km, status, canonical_hotel_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: ResetHotelDuplicateIDSequence :exec
+SELECT setval(pg_get_serial_sequence('hotel_duplicates', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL)
+FROM hotel_duplicates;
+
+-- name: RestoreHotelRedirect :exec
+INSERT INTO hotel_redirects (hotel_id, canonical_hotel_id, created_at)
+VALUES ($1, $2, $3);
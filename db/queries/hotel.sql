@@ -1,7 +1,7 @@
 -- name: FindHotelByHotelID :one
 SELECT *
 FROM hotels
-WHERE hotel_id = $1;
+WHERE hotel_id = COALESCE((SELECT canonical_hotel_id FROM hotel_redirects WHERE hotel_redirects.hotel_id = $1), $1);
 
 -- name: FindHotelsByDestinationID :many
 SELECT *
@@ -11,10 +11,70 @@ WHERE destination_id = $1;
 -- name: FindHotelsByHotelIDs :many
 SELECT *
 FROM hotels
-WHERE hotel_id = ANY(sqlc.arg('hotel_ids')::TEXT[]);
+WHERE hotel_id IN (
+  SELECT COALESCE(hotel_redirects.canonical_hotel_id, requested.hotel_id)
+  FROM UNNEST(sqlc.arg('hotel_ids')::TEXT[]) AS requested(hotel_id)
+  LEFT JOIN hotel_redirects ON hotel_redirects.hotel_id = requested.hotel_id
+);
+
+-- name: FindHotelsByCountryCode :many
+SELECT *
+FROM hotels
+WHERE country_code = sqlc.arg('country_code')::TEXT;
 
 -- name: FindHotelsByDestinationAndHotelIDs :many
 SELECT *
 FROM hotels
 WHERE destination_id = sqlc.arg('destination_id')
-  AND hotel_id = ANY(sqlc.arg('hotel_ids')::TEXT[]);
+  AND hotel_id IN (
+    SELECT COALESCE(hotel_redirects.canonical_hotel_id, requested.hotel_id)
+    FROM UNNEST(sqlc.arg('hotel_ids')::TEXT[]) AS requested(hotel_id)
+    LEFT JOIN hotel_redirects ON hotel_redirects.hotel_id = requested.hotel_id
+  );
+
+-- name: GetLastSyncedAt :one
+SELECT MAX(updated_at)::TIMESTAMPTZ AS last_synced_at
+FROM hotels;
+
+-- name: CountHotelsByDestination :many
+SELECT destination_id, COUNT(*) AS hotel_count
+FROM hotels
+GROUP BY destination_id;
+
+-- name: ListHotels :many
+SELECT *
+FROM hotels
+ORDER BY hotel_id;
+
+-- name: DeleteHotel :exec
+DELETE FROM hotels
+WHERE hotel_id = $1;
+
+-- name: UpsertHotel :exec
+INSERT INTO hotels (hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, policies, country_code, data_quality, raw_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (hotel_id) DO UPDATE
+SET destination_id = EXCLUDED.destination_id,
+  name = EXCLUDED.name,
+  location = EXCLUDED.location,
+  description = EXCLUDED.description,
+  images = EXCLUDED.images,
+  amenities = EXCLUDED.amenities,
+  booking_conditions = EXCLUDED.booking_conditions,
+  policies = EXCLUDED.policies,
+  country_code = EXCLUDED.country_code,
+  data_quality = EXCLUDED.data_quality,
+  raw_text = EXCLUDED.raw_text,
+  updated_at = NOW();
+
+-- name: CountHotels :one
+SELECT COUNT(*)
+FROM hotels;
+
+-- name: RestoreHotel :exec
+INSERT INTO hotels (id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);
+
+-- name: ResetHotelIDSequence :exec
+SELECT setval(pg_get_serial_sequence('hotels', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL)
+FROM hotels;
@@ -0,0 +1,33 @@
+-- name: ListHotelTranslations :many
+SELECT *
+FROM hotel_translations
+WHERE hotel_id = ANY(sqlc.arg('hotel_ids')::TEXT[])
+ORDER BY hotel_id, locale;
+
+-- name: FindHotelTranslation :one
+SELECT *
+FROM hotel_translations
+WHERE hotel_id = $1 AND locale = $2;
+
+-- name: UpsertHotelTranslation :exec
+INSERT INTO hotel_translations (hotel_id, locale, name, description, amenity_labels, booking_conditions)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (hotel_id, locale) DO UPDATE
+SET name = EXCLUDED.name,
+  description = EXCLUDED.description,
+  amenity_labels = EXCLUDED.amenity_labels,
+  booking_conditions = EXCLUDED.booking_conditions,
+  updated_at = NOW();
+
+-- name: DeleteHotelTranslation :exec
+DELETE FROM hotel_translations
+WHERE hotel_id = $1 AND locale = $2;
+
+-- name: ListAllHotelTranslations :many
+SELECT *
+FROM hotel_translations
+ORDER BY hotel_id, locale;
+
+-- name: RestoreHotelTranslation :exec
+INSERT INTO hotel_translations (hotel_id, locale, name, description, amenity_labels, booking_conditions, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
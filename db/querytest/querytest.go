@@ -0,0 +1,584 @@
+// Package querytest is the contract every sqlc.Querier implementation must pass, so the memory backend keeps the semantics of db/queries.
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run runs the contract. newQuerier must return a querier over empty tables, isolated from the other subtests.
+func Run(t *testing.T, newQuerier func(t *testing.T) sqlc.Querier) {
+	ctx := context.Background()
+
+	t.Run("empty table", func(t *testing.T) {
+		queries := newQuerier(t)
+
+		count, err := queries.CountHotels(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		hotels, err := queries.ListHotels(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, hotels)
+
+		counts, err := queries.CountHotelsByDestination(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+
+		lastSyncedAt, err := queries.GetLastSyncedAt(ctx)
+		require.NoError(t, err)
+		assert.False(t, lastSyncedAt.Valid)
+
+		_, err = queries.FindHotelByHotelID(ctx, "missing")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("UpsertHotel round-trips every column", func(t *testing.T) {
+		queries := newQuerier(t)
+		params := fullHotel("a", "1")
+		require.NoError(t, queries.UpsertHotel(ctx, params))
+
+		hotel, err := queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, params, upsertParams(hotel))
+		assert.NotZero(t, hotel.ID)
+		assert.True(t, hotel.CreatedAt.Valid)
+		assert.True(t, hotel.UpdatedAt.Valid)
+
+		require.NoError(t, queries.UpsertHotel(ctx, sqlc.UpsertHotelParams{HotelID: "b", DestinationID: "1", Name: "Hotel B"}))
+		hotel, err = queries.FindHotelByHotelID(ctx, "b")
+		require.NoError(t, err)
+		assert.Nil(t, hotel.Location)
+		assert.Nil(t, hotel.Description)
+		assert.Nil(t, hotel.Images)
+		assert.Nil(t, hotel.Amenities)
+		assert.Nil(t, hotel.BookingConditions)
+		assert.Nil(t, hotel.Policies)
+	})
+
+	t.Run("UpsertHotel updates a hotel in place", func(t *testing.T) {
+		queries := newQuerier(t)
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("a", "1")))
+		inserted, err := queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+
+		updated := sqlc.UpsertHotelParams{HotelID: "a", DestinationID: "2", Name: "Renamed"}
+		require.NoError(t, queries.UpsertHotel(ctx, updated))
+
+		hotel, err := queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, updated, upsertParams(hotel))
+		assert.Equal(t, inserted.ID, hotel.ID)
+		assert.True(t, inserted.CreatedAt.Time.Equal(hotel.CreatedAt.Time))
+		assert.False(t, hotel.UpdatedAt.Time.Before(inserted.UpdatedAt.Time))
+
+		count, err := queries.CountHotels(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+
+	t.Run("returned hotels are copies", func(t *testing.T) {
+		queries := newQuerier(t)
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("a", "1")))
+
+		hotel, err := queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		hotel.Name = "Changed"
+		hotel.Images.Rooms[0].Link = "https://example.com/changed.jpg"
+		hotel.BookingConditions[0] = "Changed"
+
+		hotel, err = queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, fullHotel("a", "1"), upsertParams(hotel))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		queries := newQuerier(t)
+		japanese, unknown := fullHotel("d", "2"), fullHotel("e", "2")
+		japanese.CountryCode = pointer("JP")
+		unknown.CountryCode = nil
+		for _, hotel := range []sqlc.UpsertHotelParams{
+			fullHotel("a", "1"),
+			fullHotel("b", "1"),
+			fullHotel("c", "2"),
+			japanese,
+			unknown,
+		} {
+			require.NoError(t, queries.UpsertHotel(ctx, hotel))
+		}
+
+		hotels, err := queries.FindHotelsByDestinationID(ctx, "2")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"c", "d", "e"}, hotelIDs(hotels))
+
+		hotels, err = queries.FindHotelsByDestinationID(ctx, "3")
+		require.NoError(t, err)
+		assert.Empty(t, hotels)
+
+		hotels, err = queries.FindHotelsByCountryCode(ctx, "SG")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, hotelIDs(hotels))
+
+		hotels, err = queries.FindHotelsByCountryCode(ctx, "sg")
+		require.NoError(t, err)
+		assert.Empty(t, hotels)
+
+		hotels, err = queries.FindHotelsByHotelIDs(ctx, []string{"a", "d", "missing", "a"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a", "d"}, hotelIDs(hotels))
+
+		hotels, err = queries.FindHotelsByHotelIDs(ctx, []string{})
+		require.NoError(t, err)
+		assert.Empty(t, hotels)
+
+		hotels, err = queries.FindHotelsByDestinationAndHotelIDs(ctx, sqlc.FindHotelsByDestinationAndHotelIDsParams{
+			DestinationID: "2",
+			HotelIds:      []string{"a", "c", "e"},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"c", "e"}, hotelIDs(hotels))
+
+		_, err = queries.FindHotelByHotelID(ctx, "A")
+		assert.ErrorIs(t, err, pgx.ErrNoRows, "hotel ids are case sensitive")
+
+		counts, err := queries.CountHotelsByDestination(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*sqlc.CountHotelsByDestinationRow{
+			{DestinationID: "1", HotelCount: 2},
+			{DestinationID: "2", HotelCount: 3},
+		}, counts)
+	})
+
+	t.Run("ListHotels orders by hotel_id", func(t *testing.T) {
+		queries := newQuerier(t)
+		for _, hotelID := range []string{"c", "a", "b"} {
+			require.NoError(t, queries.UpsertHotel(ctx, fullHotel(hotelID, "1")))
+		}
+
+		hotels, err := queries.ListHotels(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, hotelIDs(hotels))
+	})
+
+	t.Run("GetLastSyncedAt is the latest updated_at", func(t *testing.T) {
+		queries := newQuerier(t)
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("a", "1")))
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("b", "1")))
+
+		hotels, err := queries.ListHotels(ctx)
+		require.NoError(t, err)
+		latest := hotels[0].UpdatedAt.Time
+		for _, hotel := range hotels {
+			if hotel.UpdatedAt.Time.After(latest) {
+				latest = hotel.UpdatedAt.Time
+			}
+		}
+
+		lastSyncedAt, err := queries.GetLastSyncedAt(ctx)
+		require.NoError(t, err)
+		assert.True(t, lastSyncedAt.Valid)
+		assert.True(t, latest.Equal(lastSyncedAt.Time))
+	})
+
+	t.Run("DeleteHotel", func(t *testing.T) {
+		queries := newQuerier(t)
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("a", "1")))
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("b", "1")))
+		require.NoError(t, queries.DeleteHotel(ctx, "a"))
+		require.NoError(t, queries.DeleteHotel(ctx, "missing"))
+
+		hotels, err := queries.ListHotels(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, hotelIDs(hotels))
+	})
+
+	t.Run("hotel id lookups follow redirects", func(t *testing.T) {
+		queries := newQuerier(t)
+		for _, hotel := range []sqlc.UpsertHotelParams{fullHotel("a", "1"), fullHotel("b", "1"), fullHotel("c", "2")} {
+			require.NoError(t, queries.UpsertHotel(ctx, hotel))
+		}
+		require.NoError(t, queries.UpsertHotelRedirect(ctx, sqlc.UpsertHotelRedirectParams{HotelID: "old", CanonicalHotelID: "a"}))
+		require.NoError(t, queries.UpsertHotelRedirect(ctx, sqlc.UpsertHotelRedirectParams{HotelID: "c", CanonicalHotelID: "b"}))
+
+		hotel, err := queries.FindHotelByHotelID(ctx, "old")
+		require.NoError(t, err)
+		assert.Equal(t, "a", hotel.HotelID)
+
+		hotel, err = queries.FindHotelByHotelID(ctx, "c")
+		require.NoError(t, err)
+		assert.Equal(t, "b", hotel.HotelID, "a redirect wins over a hotel of the same id")
+
+		hotels, err := queries.FindHotelsByHotelIDs(ctx, []string{"old", "a", "missing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, hotelIDs(hotels))
+
+		hotels, err = queries.FindHotelsByDestinationAndHotelIDs(ctx, sqlc.FindHotelsByDestinationAndHotelIDsParams{
+			DestinationID: "1",
+			HotelIds:      []string{"old", "c"},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a", "b"}, hotelIDs(hotels))
+
+		require.NoError(t, queries.RepointHotelRedirects(ctx, sqlc.RepointHotelRedirectsParams{HotelID: "a", CanonicalHotelID: "b"}))
+		require.NoError(t, queries.UpsertHotelRedirect(ctx, sqlc.UpsertHotelRedirectParams{HotelID: "a", CanonicalHotelID: "b"}))
+
+		redirects, err := queries.ListHotelRedirects(ctx)
+		require.NoError(t, err)
+		require.Len(t, redirects, 3)
+		for i, hotelID := range []string{"a", "c", "old"} {
+			assert.Equal(t, hotelID, redirects[i].HotelID)
+			assert.Equal(t, "b", redirects[i].CanonicalHotelID)
+			assert.True(t, redirects[i].CreatedAt.Valid)
+		}
+	})
+
+	t.Run("duplicate candidates", func(t *testing.T) {
+		queries := newQuerier(t)
+		candidate := func(hotelID, duplicateHotelID string, score float64) sqlc.UpsertHotelDuplicateParams {
+			return sqlc.UpsertHotelDuplicateParams{
+				HotelID:          hotelID,
+				DuplicateHotelID: duplicateHotelID,
+				DestinationID:    "1",
+				Score:            score,
+				NameScore:        score,
+				AddressScore:     pointer(0.9),
+				DistanceKm:       pointer(0.012),
+			}
+		}
+		for _, params := range []sqlc.UpsertHotelDuplicateParams{candidate("a", "b", 0.8), candidate("a", "c", 0.95), candidate("b", "c", 0.9)} {
+			require.NoError(t, queries.UpsertHotelDuplicate(ctx, params))
+		}
+
+		pending, err := queries.ListHotelDuplicates(ctx, "pending")
+		require.NoError(t, err)
+		require.Len(t, pending, 3)
+		assert.Equal(t, []float64{0.95, 0.9, 0.8}, []float64{pending[0].Score, pending[1].Score, pending[2].Score})
+		assert.Equal(t, "c", pending[0].DuplicateHotelID)
+		assert.Equal(t, 0.9, *pending[0].AddressScore)
+		assert.Equal(t, 0.012, *pending[0].DistanceKm)
+		assert.Nil(t, pending[0].CanonicalHotelID)
+
+		rejected, confirmed := pending[2], pending[1]
+		require.NoError(t, queries.DecideHotelDuplicate(ctx, sqlc.DecideHotelDuplicateParams{ID: rejected.ID, Status: "rejected"}))
+		require.NoError(t, queries.DecideHotelDuplicate(ctx, sqlc.DecideHotelDuplicateParams{ID: confirmed.ID, Status: "confirmed", CanonicalHotelID: pointer("b")}))
+
+		found, err := queries.FindHotelDuplicate(ctx, confirmed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", found.Status)
+		assert.Equal(t, "b", *found.CanonicalHotelID)
+
+		require.NoError(t, queries.UpsertHotelDuplicate(ctx, candidate("a", "b", 0.99)))
+		found, err = queries.FindHotelDuplicate(ctx, rejected.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "rejected", found.Status, "decided candidates are not proposed again")
+		assert.Equal(t, 0.8, found.Score)
+
+		require.NoError(t, queries.DeletePendingHotelDuplicatesOfHotel(ctx, "b"))
+		pending, err = queries.ListHotelDuplicates(ctx, "pending")
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+
+		require.NoError(t, queries.DeletePendingHotelDuplicates(ctx))
+		pending, err = queries.ListHotelDuplicates(ctx, "pending")
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		decided, err := queries.ListHotelDuplicates(ctx, "rejected")
+		require.NoError(t, err)
+		assert.Len(t, decided, 1)
+
+		_, err = queries.FindHotelDuplicate(ctx, 0)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("hotel translations", func(t *testing.T) {
+		queries := newQuerier(t)
+		translation := sqlc.UpsertHotelTranslationParams{
+			HotelID:           "a",
+			Locale:            "ja",
+			Name:              pointer("ビーチヴィラ シンガポール"),
+			AmenityLabels:     dto.AmenityLabels{"outdoor_pool": "屋外プール"},
+			BookingConditions: []string{"ペット不可。"},
+		}
+		require.NoError(t, queries.UpsertHotelTranslation(ctx, translation))
+		require.NoError(t, queries.UpsertHotelTranslation(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "a", Locale: "zh-Hant", AmenityLabels: dto.AmenityLabels{}}))
+		require.NoError(t, queries.UpsertHotelTranslation(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "c", Locale: "ja", AmenityLabels: dto.AmenityLabels{}}))
+
+		translation.Description = pointer("<p>熱帯の庭園に囲まれた</p>")
+		require.NoError(t, queries.UpsertHotelTranslation(ctx, translation))
+
+		found, err := queries.FindHotelTranslation(ctx, sqlc.FindHotelTranslationParams{HotelID: "a", Locale: "ja"})
+		require.NoError(t, err)
+		assert.Equal(t, "ビーチヴィラ シンガポール", *found.Name)
+		assert.Equal(t, "<p>熱帯の庭園に囲まれた</p>", *found.Description)
+		assert.Equal(t, dto.AmenityLabels{"outdoor_pool": "屋外プール"}, found.AmenityLabels)
+		assert.Equal(t, []string{"ペット不可。"}, found.BookingConditions)
+
+		translations, err := queries.ListHotelTranslations(ctx, []string{"a", "b"})
+		require.NoError(t, err)
+		require.Len(t, translations, 2)
+		assert.Equal(t, []string{"ja", "zh-Hant"}, []string{translations[0].Locale, translations[1].Locale})
+
+		require.NoError(t, queries.DeleteHotelTranslation(ctx, sqlc.DeleteHotelTranslationParams{HotelID: "a", Locale: "ja"}))
+		_, err = queries.FindHotelTranslation(ctx, sqlc.FindHotelTranslationParams{HotelID: "a", Locale: "ja"})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("destinations", func(t *testing.T) {
+		queries := newQuerier(t)
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("a", "1")))
+		second := fullHotel("b", "1")
+		second.Location.Latitude, second.Location.Longitude = pointer(1.284751), pointer(103.844006)
+		require.NoError(t, queries.UpsertHotel(ctx, second))
+		suspect := fullHotel("c", "1")
+		suspect.Location.City, suspect.Location.Latitude = pointer("Sentosa"), pointer(10.0)
+		suspect.DataQuality.Location.Status = dto.LocationSuspect
+		require.NoError(t, queries.UpsertHotel(ctx, suspect))
+		require.NoError(t, queries.UpsertHotel(ctx, sqlc.UpsertHotelParams{HotelID: "d", DestinationID: "2", Name: "Hotel d"}))
+		require.NoError(t, queries.UpsertDestination(ctx, sqlc.UpsertDestinationParams{ID: "2", Name: "Tokyo", Timezone: pointer("Asia/Tokyo")}))
+
+		require.NoError(t, queries.InsertMissingDestinations(ctx))
+		require.NoError(t, queries.UpsertHotel(ctx, sqlc.UpsertHotelParams{HotelID: "e", DestinationID: "3", Name: "Hotel e"}))
+		require.NoError(t, queries.InsertMissingDestinations(ctx))
+
+		count, err := queries.CountDestinations(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+
+		derived, err := queries.FindDestination(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "Singapore", derived.Name, "the name should be the most common city")
+		assert.Equal(t, "SG", *derived.CountryCode)
+		assert.InDelta(t, 1.274751, *derived.Latitude, 1e-9, "the centroid should leave out unchecked coordinates")
+		assert.InDelta(t, 103.834006, *derived.Longitude, 1e-9)
+		assert.Nil(t, derived.Timezone)
+		assert.EqualValues(t, 3, derived.HotelCount)
+
+		kept, err := queries.FindDestination(ctx, "2")
+		require.NoError(t, err)
+		assert.Equal(t, "Tokyo", kept.Name, "existing destinations should be left alone")
+		assert.Equal(t, "Asia/Tokyo", *kept.Timezone)
+		assert.EqualValues(t, 1, kept.HotelCount)
+
+		unnamed, err := queries.FindDestination(ctx, "3")
+		require.NoError(t, err)
+		assert.Equal(t, "3", unnamed.Name, "a destination without cities should be named after its id")
+		assert.Nil(t, unnamed.CountryCode)
+		assert.Nil(t, unnamed.Latitude)
+
+		require.NoError(t, queries.UpsertDestination(ctx, sqlc.UpsertDestinationParams{ID: "4", Name: "Atlantis"}))
+		page, err := queries.ListDestinations(ctx, sqlc.ListDestinationsParams{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, []string{"Atlantis", "Singapore"}, []string{page[0].Name, page[1].Name})
+		assert.Equal(t, []int64{0, 3}, []int64{page[0].HotelCount, page[1].HotelCount})
+
+		page, err = queries.ListDestinations(ctx, sqlc.ListDestinationsParams{Limit: 10, Offset: 3})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "Tokyo", page[0].Name)
+
+		_, err = queries.FindDestination(ctx, "unknown")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("RestoreHotel keeps ids and timestamps", func(t *testing.T) {
+		queries := newQuerier(t)
+		createdAt := time.Date(2025, 9, 14, 14, 1, 29, 123456000, time.UTC)
+		require.NoError(t, queries.RestoreHotel(ctx, restoreParams(fullHotel("a", "1"), 100, createdAt)))
+
+		hotel, err := queries.FindHotelByHotelID(ctx, "a")
+		require.NoError(t, err)
+		assert.EqualValues(t, 100, hotel.ID)
+		assert.True(t, createdAt.Equal(hotel.CreatedAt.Time))
+		assert.True(t, createdAt.Add(time.Hour).Equal(hotel.UpdatedAt.Time))
+
+		require.NoError(t, queries.ResetHotelIDSequence(ctx))
+		require.NoError(t, queries.UpsertHotel(ctx, fullHotel("b", "1")))
+		hotel, err = queries.FindHotelByHotelID(ctx, "b")
+		require.NoError(t, err)
+		assert.EqualValues(t, 101, hotel.ID)
+	})
+
+	t.Run("restoring the catalogue tables keeps ids and timestamps", func(t *testing.T) {
+		queries := newQuerier(t)
+		createdAt := pgtype.Timestamptz{Time: time.Date(2025, 9, 14, 14, 1, 29, 123456000, time.UTC), Valid: true}
+		updatedAt := pgtype.Timestamptz{Time: createdAt.Time.Add(time.Hour), Valid: true}
+
+		duplicate := sqlc.RestoreHotelDuplicateParams{
+			ID: 40, HotelID: "a", DuplicateHotelID: "b", DestinationID: "1", Score: 0.9, NameScore: 0.8, AddressScore: pointer(0.7),
+			Status: "confirmed", CanonicalHotelID: pointer("a"), CreatedAt: createdAt, UpdatedAt: updatedAt,
+		}
+		require.NoError(t, queries.RestoreHotelDuplicate(ctx, duplicate))
+		require.NoError(t, queries.ResetHotelDuplicateIDSequence(ctx))
+		foundDuplicate, err := queries.FindHotelDuplicate(ctx, 40)
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", foundDuplicate.Status)
+		assert.Equal(t, "a", *foundDuplicate.CanonicalHotelID)
+		assert.True(t, updatedAt.Time.Equal(foundDuplicate.UpdatedAt.Time))
+
+		require.NoError(t, queries.UpsertHotelDuplicate(ctx, sqlc.UpsertHotelDuplicateParams{HotelID: "c", DuplicateHotelID: "d", DestinationID: "1"}))
+		pending, err := queries.ListHotelDuplicates(ctx, "pending")
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.EqualValues(t, 41, pending[0].ID)
+
+		require.NoError(t, queries.RestoreHotelRedirect(ctx, sqlc.RestoreHotelRedirectParams{HotelID: "b", CanonicalHotelID: "a", CreatedAt: createdAt}))
+		redirects, err := queries.ListHotelRedirects(ctx)
+		require.NoError(t, err)
+		require.Len(t, redirects, 1)
+		assert.True(t, createdAt.Time.Equal(redirects[0].CreatedAt.Time))
+
+		translation := sqlc.RestoreHotelTranslationParams{
+			HotelID: "b", Locale: "ja", Name: pointer("ビーチヴィラ"), AmenityLabels: dto.AmenityLabels{"outdoor_pool": "屋外プール"},
+			BookingConditions: []string{"ペット不可。"}, CreatedAt: createdAt, UpdatedAt: updatedAt,
+		}
+		require.NoError(t, queries.RestoreHotelTranslation(ctx, translation))
+		require.NoError(t, queries.UpsertHotelTranslation(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "a", Locale: "ja", AmenityLabels: dto.AmenityLabels{}}))
+		translations, err := queries.ListAllHotelTranslations(ctx)
+		require.NoError(t, err)
+		require.Len(t, translations, 2)
+		assert.Equal(t, []string{"a", "b"}, []string{translations[0].HotelID, translations[1].HotelID})
+		assert.Equal(t, translation.AmenityLabels, translations[1].AmenityLabels)
+		assert.True(t, createdAt.Time.Equal(translations[1].CreatedAt.Time))
+
+		require.NoError(t, queries.RestoreDestination(ctx, sqlc.RestoreDestinationParams{
+			ID: "1", Name: "Singapore", CountryCode: pointer("SG"), Timezone: pointer("Asia/Singapore"), CreatedAt: createdAt, UpdatedAt: updatedAt,
+		}))
+		destination, err := queries.FindDestination(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Singapore", *destination.Timezone)
+		assert.True(t, updatedAt.Time.Equal(destination.UpdatedAt.Time))
+
+		err = queries.RestoreHotelDuplicate(ctx, duplicate)
+		assertUniqueViolation(t, err)
+	})
+
+	// A unique violation aborts a Postgres transaction, so each one ends its subtest.
+	t.Run("RestoreHotel rejects a duplicate hotel_id", func(t *testing.T) {
+		queries := newQuerier(t)
+		createdAt := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, queries.RestoreHotel(ctx, restoreParams(fullHotel("a", "1"), 100, createdAt)))
+
+		err := queries.RestoreHotel(ctx, restoreParams(fullHotel("a", "2"), 101, createdAt))
+		assertUniqueViolation(t, err)
+	})
+
+	t.Run("RestoreHotel rejects a duplicate id", func(t *testing.T) {
+		queries := newQuerier(t)
+		createdAt := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, queries.RestoreHotel(ctx, restoreParams(fullHotel("a", "1"), 100, createdAt)))
+
+		err := queries.RestoreHotel(ctx, restoreParams(fullHotel("b", "1"), 100, createdAt))
+		assertUniqueViolation(t, err)
+	})
+}
+
+func fullHotel(hotelID string, destinationID string) sqlc.UpsertHotelParams {
+	return sqlc.UpsertHotelParams{
+		HotelID:       hotelID,
+		DestinationID: destinationID,
+		Name:          "Hotel " + hotelID,
+		Location: &dto.HotelLocation{
+			Latitude:   pointer(1.264751),
+			Longitude:  pointer(103.824006),
+			Address:    pointer("8 Sentosa Gateway, Beach Villas, 098269"),
+			City:       pointer("Singapore"),
+			Country:    pointer("Singapore"),
+			PostalCode: pointer("098269"),
+		},
+		CountryCode: pointer("SG"),
+		DataQuality: &dto.DataQuality{
+			Location:  dto.LocationQuality{Status: dto.LocationOK, Issues: []string{}, DistanceKm: pointer(2.4)},
+			Score:     pointer(87),
+			Missing:   []string{"amenities"},
+			Suppliers: []string{"acme", "paperflies"},
+		},
+		Description: pointer("Surrounded by tropical gardens"),
+		RawText: &dto.RawText{
+			Description:       pointer("Surrounded by tropical&nbsp;gardens"),
+			BookingConditions: []string{"All children are welcome."},
+		},
+		Images: &dto.HotelImages{
+			Rooms:     []dto.HotelImage{{Link: "https://example.com/" + hotelID + "/room.jpg", Description: "Double room"}},
+			Site:      []dto.HotelImage{{Link: "https://example.com/" + hotelID + "/site.jpg", Description: "Front"}},
+			Amenities: []dto.HotelImage{},
+		},
+		Amenities: &dto.HotelAmenities{
+			General: []string{"outdoor pool", "indoor pool"},
+			Room:    []string{"aircon", "tv"},
+		},
+		BookingConditions: []string{"All children are welcome."},
+		Policies: &dto.HotelPolicies{
+			Wifi:     pointer(dto.PolicyFree),
+			Children: dto.ChildPolicy{Allowed: pointer(true), FreeUnderAge: pointer(12)},
+		},
+	}
+}
+
+func restoreParams(hotel sqlc.UpsertHotelParams, id int32, createdAt time.Time) sqlc.RestoreHotelParams {
+	return sqlc.RestoreHotelParams{
+		ID:                id,
+		HotelID:           hotel.HotelID,
+		DestinationID:     hotel.DestinationID,
+		Name:              hotel.Name,
+		Location:          hotel.Location,
+		Description:       hotel.Description,
+		Images:            hotel.Images,
+		Amenities:         hotel.Amenities,
+		BookingConditions: hotel.BookingConditions,
+		Policies:          hotel.Policies,
+		CountryCode:       hotel.CountryCode,
+		DataQuality:       hotel.DataQuality,
+		RawText:           hotel.RawText,
+		CreatedAt:         pgtype.Timestamptz{Time: createdAt, Valid: true},
+		UpdatedAt:         pgtype.Timestamptz{Time: createdAt.Add(time.Hour), Valid: true},
+	}
+}
+
+func upsertParams(hotel *sqlc.Hotel) sqlc.UpsertHotelParams {
+	return sqlc.UpsertHotelParams{
+		HotelID:           hotel.HotelID,
+		DestinationID:     hotel.DestinationID,
+		Name:              hotel.Name,
+		Location:          hotel.Location,
+		Description:       hotel.Description,
+		Images:            hotel.Images,
+		Amenities:         hotel.Amenities,
+		BookingConditions: hotel.BookingConditions,
+		Policies:          hotel.Policies,
+		CountryCode:       hotel.CountryCode,
+		DataQuality:       hotel.DataQuality,
+		RawText:           hotel.RawText,
+	}
+}
+
+func assertUniqueViolation(t *testing.T, err error) {
+	t.Helper()
+
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr), "expected a PgError, got %v", err)
+	assert.Equal(t, "23505", pgErr.Code)
+}
+
+func hotelIDs(hotels []*sqlc.Hotel) []string {
+	ids := make([]string, 0, len(hotels))
+	for _, hotel := range hotels {
+		ids = append(ids, hotel.HotelID)
+	}
+	return ids
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
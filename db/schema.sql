@@ -9,7 +9,59 @@ CREATE TABLE IF NOT EXISTS hotels (
   amenities JSONB,
   booking_conditions TEXT[],
   created_at TIMESTAMPTZ DEFAULT NOW(),
-  updated_at TIMESTAMPTZ DEFAULT NOW()
+  updated_at TIMESTAMPTZ DEFAULT NOW(),
+  policies JSONB,
+  country_code TEXT,
+  data_quality JSONB,
+  raw_text JSONB
 );
 
 CREATE INDEX IF NOT EXISTS idx_hotels_destination_id ON hotels(destination_id);
+CREATE INDEX IF NOT EXISTS idx_hotels_country_code ON hotels(country_code);
+
+CREATE TABLE IF NOT EXISTS hotel_duplicates (
+  id SERIAL PRIMARY KEY,
+  hotel_id TEXT NOT NULL,
+  duplicate_hotel_id TEXT NOT NULL,
+  destination_id TEXT NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  name_score DOUBLE PRECISION NOT NULL,
+  address_score DOUBLE PRECISION,
+  distance_km DOUBLE PRECISION,
+  status TEXT NOT NULL DEFAULT 'pending',
+  canonical_hotel_id TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW(),
+  UNIQUE (hotel_id, duplicate_hotel_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_hotel_duplicates_status ON hotel_duplicates(status);
+
+CREATE TABLE IF NOT EXISTS hotel_redirects (
+  hotel_id TEXT PRIMARY KEY,
+  canonical_hotel_id TEXT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS hotel_translations (
+  hotel_id TEXT NOT NULL,
+  locale TEXT NOT NULL,
+  name TEXT,
+  description TEXT,
+  amenity_labels JSONB NOT NULL DEFAULT '{}',
+  booking_conditions TEXT[],
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW(),
+  PRIMARY KEY (hotel_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS destinations (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  country_code TEXT,
+  latitude DOUBLE PRECISION,
+  longitude DOUBLE PRECISION,
+  timezone TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
@@ -0,0 +1,66 @@
+package db
+
+import "strings"
+
+// CountStatements counts the SQL statements of a migration the way Atlas records them in the revisions table,
+// ignoring semicolons inside comments, quoted strings and dollar-quoted bodies.
+func CountStatements(sql string) int {
+	count := 0
+	pending := false
+
+	for i := 0; i < len(sql); i++ {
+		switch {
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+		case sql[i] == '\'' || sql[i] == '"':
+			end := strings.IndexByte(sql[i+1:], sql[i])
+			pending = true
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 1
+			}
+		case sql[i] == '$':
+			pending = true
+			tagEnd := strings.IndexByte(sql[i+1:], '$')
+			if tagEnd < 0 {
+				continue
+			}
+			tag := sql[i : i+tagEnd+2]
+			if strings.ContainsAny(tag[1:len(tag)-1], " \t\n;") {
+				continue
+			}
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += len(tag) + end + len(tag) - 1
+			}
+		case sql[i] == ';':
+			if pending {
+				count++
+			}
+			pending = false
+		case sql[i] != ' ' && sql[i] != '\t' && sql[i] != '\n' && sql[i] != '\r':
+			pending = true
+		}
+	}
+
+	if pending {
+		count++
+	}
+
+	return count
+}
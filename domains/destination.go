@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"context"
+	"errors"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+// ErrInvalidDestination is returned for a destination with an unknown country or timezone.
+var ErrInvalidDestination = errors.New("invalid destination")
+
+// DestinationPage is a page of destinations ordered by name, with the number of destinations in all pages.
+type DestinationPage struct {
+	Destinations []*sqlc.ListDestinationsRow `json:"destinations"`
+	Total        int64                       `json:"total"`
+	Limit        int32                       `json:"limit"`
+	Offset       int32                       `json:"offset"`
+}
+
+// DestinationService describes the destinations hotels are grouped by. Destinations are derived from their
+// hotels when hotels are stored, and can then be edited.
+type DestinationService interface {
+	List(ctx context.Context, limit int32, offset int32) (*DestinationPage, error)
+	Find(ctx context.Context, id string) (*sqlc.FindDestinationRow, error)
+	Upsert(ctx context.Context, destination sqlc.UpsertDestinationParams) (*sqlc.FindDestinationRow, error)
+}
@@ -0,0 +1,40 @@
+package domains
+
+import (
+	"context"
+	"errors"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+// Statuses of duplicate candidates.
+const (
+	DuplicatePending   = "pending"
+	DuplicateConfirmed = "confirmed"
+	DuplicateRejected  = "rejected"
+)
+
+var (
+	// ErrDuplicateDecided is returned when confirming or rejecting a candidate that is no longer pending.
+	ErrDuplicateDecided = errors.New("duplicate candidate is already decided")
+	// ErrCanonicalHotel is returned when the canonical hotel of a merge is not one of its candidate pair.
+	ErrCanonicalHotel = errors.New("canonical hotel is not part of the duplicate candidate")
+)
+
+type DetectDuplicatesResult struct {
+	Candidates int `json:"candidates"`
+	// Clusters groups the hotel ids linked by any candidate pair.
+	Clusters [][]string `json:"clusters"`
+}
+
+// DuplicateService finds hotels listed under several hotel ids and merges them once confirmed.
+type DuplicateService interface {
+	// Detect replaces the pending candidates with the pairs found among the stored hotels. Decided pairs are kept
+	// and not proposed again.
+	Detect(ctx context.Context) (*DetectDuplicatesResult, error)
+	List(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error)
+	// Confirm keeps the canonical hotel of a pending pair, deletes the other one and redirects its hotel id,
+	// and the ids already redirected to it, to the canonical hotel.
+	Confirm(ctx context.Context, id int32, canonicalHotelID string) (*sqlc.HotelDuplicate, error)
+	Reject(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error)
+}
@@ -0,0 +1,73 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusFail     = "fail"
+	HealthStatusDraining = "draining"
+)
+
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type ReadinessReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+func (r *ReadinessReport) Ready() bool {
+	return r.Status == HealthStatusOK
+}
+
+type PoolStats struct {
+	TotalConns           int32         `json:"total_conns"`
+	IdleConns            int32         `json:"idle_conns"`
+	AcquiredConns        int32         `json:"acquired_conns"`
+	ConstructingConns    int32         `json:"constructing_conns"`
+	MaxConns             int32         `json:"max_conns"`
+	AcquireCount         int64         `json:"acquire_count"`
+	EmptyAcquireCount    int64         `json:"empty_acquire_count"`
+	CanceledAcquireCount int64         `json:"canceled_acquire_count"`
+	AcquireDuration      time.Duration `json:"acquire_duration_ns"`
+}
+
+type ReplicaStatus struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	LagSeconds float64    `json:"lag_seconds"`
+	Detail     string     `json:"detail,omitempty"`
+	CheckedAt  *time.Time `json:"checked_at"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+type StatusReport struct {
+	Status           string          `json:"status"`
+	Build            BuildInfo       `json:"build"`
+	StartedAt        time.Time       `json:"started_at"`
+	UptimeSeconds    float64         `json:"uptime_seconds"`
+	MigrationVersion *string         `json:"migration_version"`
+	LastSyncedAt     *time.Time      `json:"last_synced_at"`
+	Pool             *PoolStats      `json:"pool"`
+	Replicas         []ReplicaStatus `json:"replicas"`
+	Checks           []HealthCheck   `json:"checks"`
+}
+
+type HealthService interface {
+	Readiness(ctx context.Context) *ReadinessReport
+	Status(ctx context.Context) *StatusReport
+	MarkDraining()
+	IsDraining() bool
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
 )
 
 type HotelService interface {
@@ -11,4 +12,56 @@ type HotelService interface {
 	FindByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error)
 	FindByHotelIDs(ctx context.Context, hotelIDs []string) ([]*sqlc.Hotel, error)
 	FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error)
+	FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error)
+}
+
+// HotelFilter narrows hotels found by destination or hotel ids. Nil fields do not filter, and hotels
+// whose policies do not state a filtered field never match it.
+type HotelFilter struct {
+	CountryCode        *string
+	MinQuality         *int
+	PetsAllowed        *bool
+	FreeWifi           *bool
+	FreeParking        *bool
+	ChildrenAllowed    *bool
+	PrepaymentRequired *bool
+}
+
+// Apply returns the hotels matching every set field, in their order.
+func (f HotelFilter) Apply(hotels []*sqlc.Hotel) []*sqlc.Hotel {
+	if f == (HotelFilter{}) {
+		return hotels
+	}
+
+	matching := []*sqlc.Hotel{}
+	for _, hotel := range hotels {
+		if f.Matches(hotel) {
+			matching = append(matching, hotel)
+		}
+	}
+
+	return matching
+}
+
+func (f HotelFilter) Matches(hotel *sqlc.Hotel) bool {
+	policies := hotel.Policies
+	if policies == nil {
+		policies = &dto.HotelPolicies{}
+	}
+
+	return (f.CountryCode == nil || (hotel.CountryCode != nil && *hotel.CountryCode == *f.CountryCode)) &&
+		(f.MinQuality == nil || (hotel.DataQuality != nil && hotel.DataQuality.Score != nil && *hotel.DataQuality.Score >= *f.MinQuality)) &&
+		matchBool(f.PetsAllowed, policies.PetsAllowed) &&
+		matchFree(f.FreeWifi, policies.Wifi) &&
+		matchFree(f.FreeParking, policies.Parking) &&
+		matchBool(f.ChildrenAllowed, policies.Children.Allowed) &&
+		matchBool(f.PrepaymentRequired, policies.PrepaymentRequired)
+}
+
+func matchBool(want *bool, value *bool) bool {
+	return want == nil || (value != nil && *value == *want)
+}
+
+func matchFree(want *bool, value *string) bool {
+	return want == nil || (value != nil && (*value == dto.PolicyFree) == *want)
 }
@@ -0,0 +1,87 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// DataIssue is a problem found in a hotel record. Line is the 1-based NDJSON line, 0 for records read from the database.
+type DataIssue struct {
+	HotelID string `json:"hotel_id,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i DataIssue) String() string {
+	var location string
+	if i.Line > 0 {
+		location = fmt.Sprintf("line %d: ", i.Line)
+	}
+	if i.HotelID != "" {
+		location += fmt.Sprintf("hotel %s: ", i.HotelID)
+	}
+	if i.Field != "" {
+		location += i.Field + ": "
+	}
+
+	return location + i.Message
+}
+
+type IngestResult struct {
+	Fetched map[string]int `json:"fetched"`
+	Stored  int            `json:"stored"`
+	// Removed counts the stored supplier hotels that no supplier returned anymore.
+	Removed int         `json:"removed"`
+	Skipped []DataIssue `json:"skipped"`
+	// UnmappedAmenities counts the supplier hotels using each amenity term missing from the taxonomy.
+	UnmappedAmenities map[string]int `json:"unmapped_amenities"`
+}
+
+type ImportResult struct {
+	Imported int         `json:"imported"`
+	Inserted int         `json:"inserted"`
+	Updated  int         `json:"updated"`
+	Errors   []DataIssue `json:"errors"`
+}
+
+// UnknownSupplier groups the hotels of a quality report that do not record their suppliers, such as imported hotels.
+const UnknownSupplier = "unknown"
+
+// QualityStats aggregates the data quality of a group of hotels.
+type QualityStats struct {
+	Hotels       int     `json:"hotels"`
+	AverageScore float64 `json:"average_score"`
+	MinScore     int     `json:"min_score"`
+	MaxScore     int     `json:"max_score"`
+	// Missing counts the hotels missing each field.
+	Missing map[string]int `json:"missing"`
+	// Locations counts the hotels by location status.
+	Locations map[string]int `json:"locations"`
+}
+
+// QualityReport aggregates the data quality of every hotel, overall, by destination and by supplier.
+// A hotel merged from several suppliers counts for each of them.
+type QualityReport struct {
+	Overall      QualityStats             `json:"overall"`
+	Destinations map[string]*QualityStats `json:"destinations"`
+	Suppliers    map[string]*QualityStats `json:"suppliers"`
+}
+
+// IngestService fetches hotels from the suppliers, merges them and updates the stored hotels.
+type IngestService interface {
+	Ingest(ctx context.Context) (*IngestResult, error)
+}
+
+// HotelDataService moves hotels in and out of the database as NDJSON, one hotel per line.
+type HotelDataService interface {
+	Export(ctx context.Context, w io.Writer) (int, error)
+	// Import validates every hotel of r, copies them to a staging table and merges it into hotels in one transaction.
+	// Nothing is written when any line is invalid.
+	Import(ctx context.Context, r io.Reader) (*ImportResult, error)
+	// Validate checks the stored hotels against the rules applied on import.
+	Validate(ctx context.Context) ([]DataIssue, error)
+	// Quality aggregates the data quality of the stored hotels.
+	Quality(ctx context.Context) (*QualityReport, error)
+}
@@ -0,0 +1,45 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	MigrationStateApplied = "applied"
+	MigrationStatePending = "pending"
+	// MigrationStatePartial is a migration that failed midway, which needs manual repair.
+	MigrationStatePartial = "partial"
+	// MigrationStateModified is an applied migration whose file changed since it was applied.
+	MigrationStateModified = "modified"
+	// MigrationStateUnknown is an applied migration that this binary does not know, from a newer release.
+	MigrationStateUnknown = "unknown"
+)
+
+var ErrSchemaBehind = errors.New("database schema is behind the application")
+
+type MigrationInfo struct {
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	ExecutedAt  *time.Time `json:"executed_at,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type MigrationStatus struct {
+	CurrentVersion string          `json:"current_version"`
+	LatestVersion  string          `json:"latest_version"`
+	Pending        int             `json:"pending"`
+	Migrations     []MigrationInfo `json:"migrations"`
+}
+
+type MigrationService interface {
+	Status(ctx context.Context) (*MigrationStatus, error)
+	// Up applies up to count pending migrations, all of them when count is 0.
+	Up(ctx context.Context, count int) ([]MigrationInfo, error)
+	// Down reverts the count most recently applied migrations.
+	Down(ctx context.Context, count int) ([]MigrationInfo, error)
+	// CheckSchema returns ErrSchemaBehind when migrations embedded in the binary are not applied.
+	CheckSchema(ctx context.Context) error
+}
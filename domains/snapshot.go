@@ -0,0 +1,39 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// SnapshotFormatVersion is bumped whenever the archive layout changes incompatibly.
+const SnapshotFormatVersion = 2
+
+var (
+	ErrSnapshotCorrupted = errors.New("snapshot is corrupted")
+	ErrSnapshotMismatch  = errors.New("snapshot does not match the database")
+)
+
+type SnapshotFile struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// SnapshotManifest describes a snapshot archive. It is stored as manifest.json, first in the archive.
+type SnapshotManifest struct {
+	FormatVersion int            `json:"format_version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	AppVersion    string         `json:"app_version"`
+	SchemaVersion string         `json:"schema_version"`
+	Files         []SnapshotFile `json:"files"`
+}
+
+type SnapshotService interface {
+	// Export writes the catalogue and the applied schema revisions to w as a gzipped tar archive.
+	Export(ctx context.Context, w io.Writer) (*SnapshotManifest, error)
+	// Restore verifies the archive checksums and loads it into an empty database migrated to the same schema version.
+	Restore(ctx context.Context, r io.Reader) (*SnapshotManifest, error)
+}
@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"context"
+	"errors"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+var (
+	// ErrInvalidLocale is returned for a translation locale that is not a BCP 47 language tag, or is the
+	// language of the untranslated content.
+	ErrInvalidLocale = errors.New("locale must be a BCP 47 language tag other than the default locale")
+	// ErrUnknownAmenity is returned for an amenity label whose code is not in the amenity taxonomy.
+	ErrUnknownAmenity = errors.New("unknown amenity code")
+)
+
+// TranslationService manages the per-locale translations of hotel content.
+type TranslationService interface {
+	// Localize returns the hotels in the first locale of chain each has a translation for, and the locales
+	// served, once each in the order of the hotels.
+	Localize(ctx context.Context, hotels []*sqlc.Hotel, chain []string) ([]*sqlc.Hotel, []string, error)
+	List(ctx context.Context, hotelID string) ([]*sqlc.HotelTranslation, error)
+	// Upsert sanitizes and stores a translation of the hotel, following a merged hotel id to its canonical hotel.
+	Upsert(ctx context.Context, translation sqlc.UpsertHotelTranslationParams) (*sqlc.HotelTranslation, error)
+	Delete(ctx context.Context, hotelID string, locale string) error
+}
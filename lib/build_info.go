@@ -0,0 +1,41 @@
+package lib
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/duylamasd/hotels-merge/domains"
+)
+
+// Version, Commit and BuildTime are set at build time with -ldflags "-X".
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+func NewBuildInfo() *domains.BuildInfo {
+	info := &domains.BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
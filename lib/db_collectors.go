@@ -0,0 +1,162 @@
+package lib
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	hotelsPerDestinationTimeout = 2 * time.Second
+	// hotelsPerDestinationTTL is how long hotel counts are reused across scrapes, as counting groups the whole
+	// hotels table.
+	hotelsPerDestinationTTL = time.Minute
+)
+
+var (
+	poolTotalConnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "total_conns"),
+		"Number of connections currently in the pool.", nil, nil,
+	)
+	poolIdleConnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "idle_conns"),
+		"Number of idle connections in the pool.", nil, nil,
+	)
+	poolAcquiredConnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "acquired_conns"),
+		"Number of connections currently acquired from the pool.", nil, nil,
+	)
+	poolConstructingConnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "constructing_conns"),
+		"Number of connections being established.", nil, nil,
+	)
+	poolMaxConnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "max_conns"),
+		"Maximum size of the pool.", nil, nil,
+	)
+	poolAcquireCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "acquires_total"),
+		"Number of successful connection acquires.", nil, nil,
+	)
+	poolEmptyAcquireCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "empty_acquires_total"),
+		"Number of acquires that had to wait for a connection.", nil, nil,
+	)
+	poolCanceledAcquireCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "canceled_acquires_total"),
+		"Number of acquires canceled by their context.", nil, nil,
+	)
+	poolAcquireDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "db_pool", "acquire_duration_seconds_total"),
+		"Total time spent acquiring connections.", nil, nil,
+	)
+	hotelsPerDestinationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "hotels", "per_destination"),
+		"Number of merged hotels stored per destination.", []string{"destination_id"}, nil,
+	)
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	return &poolCollector{pool: pool}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolTotalConnsDesc
+	ch <- poolIdleConnsDesc
+	ch <- poolAcquiredConnsDesc
+	ch <- poolConstructingConnsDesc
+	ch <- poolMaxConnsDesc
+	ch <- poolAcquireCountDesc
+	ch <- poolEmptyAcquireCountDesc
+	ch <- poolCanceledAcquireCountDesc
+	ch <- poolAcquireDurationDesc
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolTotalConnsDesc, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConnsDesc, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConnsDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolConstructingConnsDesc, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConnsDesc, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireCountDesc, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquireCountDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquireCountDesc, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireDurationDesc, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+type hotelsPerDestinationCollector struct {
+	logger  *zap.Logger
+	queries sqlc.Querier
+	ttl     time.Duration
+
+	mu          sync.Mutex
+	counts      []*sqlc.CountHotelsByDestinationRow
+	collectedAt time.Time
+}
+
+// NewHotelsPerDestinationCollector reports the hotels stored per destination, counted at most once per ttl.
+func NewHotelsPerDestinationCollector(logger *zap.Logger, queries sqlc.Querier, ttl time.Duration) prometheus.Collector {
+	return &hotelsPerDestinationCollector{
+		logger:  logger,
+		queries: queries,
+		ttl:     ttl,
+	}
+}
+
+func (c *hotelsPerDestinationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- hotelsPerDestinationDesc
+}
+
+func (c *hotelsPerDestinationCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, count := range c.count() {
+		ch <- prometheus.MustNewConstMetric(hotelsPerDestinationDesc, prometheus.GaugeValue, float64(count.HotelCount), count.DestinationID)
+	}
+}
+
+// count returns the last counts while they are fresh, and counts again otherwise. The last counts are kept
+// when counting fails.
+func (c *hotelsPerDestinationCollector) count() []*sqlc.CountHotelsByDestinationRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts != nil && time.Since(c.collectedAt) < c.ttl {
+		return c.counts
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hotelsPerDestinationTimeout)
+	defer cancel()
+
+	counts, err := c.queries.CountHotelsByDestination(ctx)
+	if err != nil {
+		c.logger.Warn("Could not collect hotels per destination", zap.Error(err))
+		return c.counts
+	}
+	if counts == nil {
+		counts = []*sqlc.CountHotelsByDestinationRow{}
+	}
+	c.counts, c.collectedAt = counts, time.Now()
+
+	return counts
+}
+
+func RegisterDBCollectors(metrics *Metrics, logger *zap.Logger, db *config.DBStore) error {
+	if db.ConnPool != nil {
+		if err := metrics.Registry.Register(NewPoolCollector(db.ConnPool)); err != nil {
+			return err
+		}
+	}
+
+	return metrics.Registry.Register(NewHotelsPerDestinationCollector(logger, db.Queries, hotelsPerDestinationTTL))
+}
@@ -0,0 +1,45 @@
+package lib_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestHotelsPerDestinationCollector(t *testing.T) {
+	counts := []*sqlc.CountHotelsByDestinationRow{{DestinationID: "5432", HotelCount: 3}, {DestinationID: "1122", HotelCount: 1}}
+
+	t.Run("should count hotels once per ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		queries := mocks.NewMockQuerier(ctrl)
+		collector := lib.NewHotelsPerDestinationCollector(zap.NewNop(), queries, time.Hour)
+
+		queries.EXPECT().CountHotelsByDestination(gomock.Any()).Return(counts, nil).Times(1)
+
+		for range 3 {
+			assert.Equal(t, 2, testutil.CollectAndCount(collector))
+		}
+	})
+
+	t.Run("should count again once stale and keep the last counts on errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		queries := mocks.NewMockQuerier(ctrl)
+		collector := lib.NewHotelsPerDestinationCollector(zap.NewNop(), queries, 0)
+
+		gomock.InOrder(
+			queries.EXPECT().CountHotelsByDestination(gomock.Any()).Return(counts, nil),
+			queries.EXPECT().CountHotelsByDestination(gomock.Any()).Return(nil, errors.New("connection refused")),
+		)
+
+		assert.Equal(t, 2, testutil.CollectAndCount(collector))
+		assert.Equal(t, 2, testutil.CollectAndCount(collector))
+	})
+}
@@ -3,5 +3,11 @@ package lib
 import "go.uber.org/fx"
 
 var Module = fx.Options(
+	fx.Provide(NewLogLevel),
 	fx.Provide(NewLogger),
+	fx.Provide(NewBuildInfo),
+	fx.Provide(NewMetrics),
+	fx.Provide(NewTracerProvider),
+	fx.Invoke(RegisterDBCollectors),
+	fx.Invoke(SubscribeLogLevel),
 )
@@ -5,7 +5,11 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewLogger(config *config.Config) (*zap.Logger, error) {
+func NewLogLevel(config *config.Config) (zap.AtomicLevel, error) {
+	return zap.ParseAtomicLevel(config.Log.Level)
+}
+
+func NewLogger(config *config.Config, level zap.AtomicLevel) (*zap.Logger, error) {
 	var zapConfig zap.Config
 
 	if config.Env == "production" {
@@ -14,11 +18,14 @@ func NewLogger(config *config.Config) (*zap.Logger, error) {
 		zapConfig = zap.NewDevelopmentConfig()
 	}
 
-	level, err := zap.ParseAtomicLevel(config.LogLevel)
-	if err != nil {
-		return nil, err
-	}
 	zapConfig.Level = level
 
 	return zapConfig.Build()
 }
+
+// SubscribeLogLevel changes the level of every logger built from level when log.level is reloaded.
+func SubscribeLogLevel(watcher *config.Watcher, level zap.AtomicLevel) {
+	watcher.Subscribe("log.level", func(config *config.Config) error {
+		return level.UnmarshalText([]byte(config.Log.Level))
+	})
+}
@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const metricsNamespace = "hotels_merge"
+
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	HTTPRequestsTotal   *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+
+	ServiceCallDuration *prometheus.HistogramVec
+	ServiceErrorsTotal  *prometheus.CounterVec
+
+	HotelsResultSize *prometheus.HistogramVec
+}
+
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		Registry: registry,
+		HTTPRequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		ServiceCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "service",
+			Name:      "call_duration_seconds",
+			Help:      "Latency of service method calls.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+		ServiceErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "service",
+			Name:      "errors_total",
+			Help:      "Number of service method calls that returned an error.",
+		}, []string{"service", "method"}),
+		HotelsResultSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "hotels",
+			Name:      "result_size",
+			Help:      "Number of hotels returned per lookup.",
+			Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250, 500},
+		}, []string{"method"}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequestsTotal,
+		m.HTTPRequestDuration,
+		m.ServiceCallDuration,
+		m.ServiceErrorsTotal,
+		m.HotelsResultSize,
+	)
+
+	return m
+}
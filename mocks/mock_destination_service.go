@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: DestinationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_destination_service.go -package=mocks ./domains DestinationService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/duylamasd/hotels-merge/domains"
+	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDestinationService is a mock of DestinationService interface.
+type MockDestinationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestinationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDestinationServiceMockRecorder is the mock recorder for MockDestinationService.
+type MockDestinationServiceMockRecorder struct {
+	mock *MockDestinationService
+}
+
+// NewMockDestinationService creates a new mock instance.
+func NewMockDestinationService(ctrl *gomock.Controller) *MockDestinationService {
+	mock := &MockDestinationService{ctrl: ctrl}
+	mock.recorder = &MockDestinationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestinationService) EXPECT() *MockDestinationServiceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockDestinationService) Find(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*sqlc.FindDestinationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDestinationServiceMockRecorder) Find(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDestinationService)(nil).Find), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockDestinationService) List(ctx context.Context, limit, offset int32) (*domains.DestinationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].(*domains.DestinationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDestinationServiceMockRecorder) List(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDestinationService)(nil).List), ctx, limit, offset)
+}
+
+// Upsert mocks base method.
+func (m *MockDestinationService) Upsert(ctx context.Context, destination sqlc.UpsertDestinationParams) (*sqlc.FindDestinationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, destination)
+	ret0, _ := ret[0].(*sqlc.FindDestinationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockDestinationServiceMockRecorder) Upsert(ctx, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockDestinationService)(nil).Upsert), ctx, destination)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: DuplicateService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_duplicate_service.go -package=mocks ./domains DuplicateService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/duylamasd/hotels-merge/domains"
+	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDuplicateService is a mock of DuplicateService interface.
+type MockDuplicateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateServiceMockRecorder is the mock recorder for MockDuplicateService.
+type MockDuplicateServiceMockRecorder struct {
+	mock *MockDuplicateService
+}
+
+// NewMockDuplicateService creates a new mock instance.
+func NewMockDuplicateService(ctrl *gomock.Controller) *MockDuplicateService {
+	mock := &MockDuplicateService{ctrl: ctrl}
+	mock.recorder = &MockDuplicateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateService) EXPECT() *MockDuplicateServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockDuplicateService) Confirm(ctx context.Context, id int32, canonicalHotelID string) (*sqlc.HotelDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id, canonicalHotelID)
+	ret0, _ := ret[0].(*sqlc.HotelDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockDuplicateServiceMockRecorder) Confirm(ctx, id, canonicalHotelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockDuplicateService)(nil).Confirm), ctx, id, canonicalHotelID)
+}
+
+// Detect mocks base method.
+func (m *MockDuplicateService) Detect(ctx context.Context) (*domains.DetectDuplicatesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", ctx)
+	ret0, _ := ret[0].(*domains.DetectDuplicatesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockDuplicateServiceMockRecorder) Detect(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockDuplicateService)(nil).Detect), ctx)
+}
+
+// List mocks base method.
+func (m *MockDuplicateService) List(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, status)
+	ret0, _ := ret[0].([]*sqlc.HotelDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDuplicateServiceMockRecorder) List(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDuplicateService)(nil).List), ctx, status)
+}
+
+// Reject mocks base method.
+func (m *MockDuplicateService) Reject(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id)
+	ret0, _ := ret[0].(*sqlc.HotelDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockDuplicateServiceMockRecorder) Reject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockDuplicateService)(nil).Reject), ctx, id)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: HealthService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_health_service.go -package=mocks ./domains HealthService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/duylamasd/hotels-merge/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthService is a mock of HealthService interface.
+type MockHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthServiceMockRecorder is the mock recorder for MockHealthService.
+type MockHealthServiceMockRecorder struct {
+	mock *MockHealthService
+}
+
+// NewMockHealthService creates a new mock instance.
+func NewMockHealthService(ctrl *gomock.Controller) *MockHealthService {
+	mock := &MockHealthService{ctrl: ctrl}
+	mock.recorder = &MockHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthService) EXPECT() *MockHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// IsDraining mocks base method.
+func (m *MockHealthService) IsDraining() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDraining")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDraining indicates an expected call of IsDraining.
+func (mr *MockHealthServiceMockRecorder) IsDraining() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDraining", reflect.TypeOf((*MockHealthService)(nil).IsDraining))
+}
+
+// MarkDraining mocks base method.
+func (m *MockHealthService) MarkDraining() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkDraining")
+}
+
+// MarkDraining indicates an expected call of MarkDraining.
+func (mr *MockHealthServiceMockRecorder) MarkDraining() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDraining", reflect.TypeOf((*MockHealthService)(nil).MarkDraining))
+}
+
+// Readiness mocks base method.
+func (m *MockHealthService) Readiness(ctx context.Context) *domains.ReadinessReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readiness", ctx)
+	ret0, _ := ret[0].(*domains.ReadinessReport)
+	return ret0
+}
+
+// Readiness indicates an expected call of Readiness.
+func (mr *MockHealthServiceMockRecorder) Readiness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readiness", reflect.TypeOf((*MockHealthService)(nil).Readiness), ctx)
+}
+
+// Status mocks base method.
+func (m *MockHealthService) Status(ctx context.Context) *domains.StatusReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(*domains.StatusReport)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockHealthServiceMockRecorder) Status(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockHealthService)(nil).Status), ctx)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: HotelDataService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_hotel_data_service.go -package=mocks ./domains HotelDataService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domains "github.com/duylamasd/hotels-merge/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHotelDataService is a mock of HotelDataService interface.
+type MockHotelDataService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHotelDataServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHotelDataServiceMockRecorder is the mock recorder for MockHotelDataService.
+type MockHotelDataServiceMockRecorder struct {
+	mock *MockHotelDataService
+}
+
+// NewMockHotelDataService creates a new mock instance.
+func NewMockHotelDataService(ctrl *gomock.Controller) *MockHotelDataService {
+	mock := &MockHotelDataService{ctrl: ctrl}
+	mock.recorder = &MockHotelDataServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHotelDataService) EXPECT() *MockHotelDataServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockHotelDataService) Export(ctx context.Context, w io.Writer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockHotelDataServiceMockRecorder) Export(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockHotelDataService)(nil).Export), ctx, w)
+}
+
+// Import mocks base method.
+func (m *MockHotelDataService) Import(ctx context.Context, r io.Reader) (*domains.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r)
+	ret0, _ := ret[0].(*domains.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockHotelDataServiceMockRecorder) Import(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockHotelDataService)(nil).Import), ctx, r)
+}
+
+// Quality mocks base method.
+func (m *MockHotelDataService) Quality(ctx context.Context) (*domains.QualityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quality", ctx)
+	ret0, _ := ret[0].(*domains.QualityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quality indicates an expected call of Quality.
+func (mr *MockHotelDataServiceMockRecorder) Quality(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quality", reflect.TypeOf((*MockHotelDataService)(nil).Quality), ctx)
+}
+
+// Validate mocks base method.
+func (m *MockHotelDataService) Validate(ctx context.Context) ([]domains.DataIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx)
+	ret0, _ := ret[0].([]domains.DataIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockHotelDataServiceMockRecorder) Validate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockHotelDataService)(nil).Validate), ctx)
+}
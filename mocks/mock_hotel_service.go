@@ -41,6 +41,21 @@ func (m *MockHotelService) EXPECT() *MockHotelServiceMockRecorder {
 	return m.recorder
 }
 
+// FindByCountryCode mocks base method.
+func (m *MockHotelService) FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCountryCode", ctx, countryCode)
+	ret0, _ := ret[0].([]*sqlc.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCountryCode indicates an expected call of FindByCountryCode.
+func (mr *MockHotelServiceMockRecorder) FindByCountryCode(ctx, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCountryCode", reflect.TypeOf((*MockHotelService)(nil).FindByCountryCode), ctx, countryCode)
+}
+
 // FindByDestinationAndHotelIDs mocks base method.
 func (m *MockHotelService) FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error) {
 	m.ctrl.T.Helper()
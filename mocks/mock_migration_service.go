@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: MigrationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_migration_service.go -package=mocks ./domains MigrationService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/duylamasd/hotels-merge/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMigrationService is a mock of MigrationService interface.
+type MockMigrationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMigrationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMigrationServiceMockRecorder is the mock recorder for MockMigrationService.
+type MockMigrationServiceMockRecorder struct {
+	mock *MockMigrationService
+}
+
+// NewMockMigrationService creates a new mock instance.
+func NewMockMigrationService(ctrl *gomock.Controller) *MockMigrationService {
+	mock := &MockMigrationService{ctrl: ctrl}
+	mock.recorder = &MockMigrationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMigrationService) EXPECT() *MockMigrationServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckSchema mocks base method.
+func (m *MockMigrationService) CheckSchema(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSchema", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSchema indicates an expected call of CheckSchema.
+func (mr *MockMigrationServiceMockRecorder) CheckSchema(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSchema", reflect.TypeOf((*MockMigrationService)(nil).CheckSchema), ctx)
+}
+
+// Down mocks base method.
+func (m *MockMigrationService) Down(ctx context.Context, count int) ([]domains.MigrationInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", ctx, count)
+	ret0, _ := ret[0].([]domains.MigrationInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockMigrationServiceMockRecorder) Down(ctx, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*MockMigrationService)(nil).Down), ctx, count)
+}
+
+// Status mocks base method.
+func (m *MockMigrationService) Status(ctx context.Context) (*domains.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(*domains.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockMigrationServiceMockRecorder) Status(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockMigrationService)(nil).Status), ctx)
+}
+
+// Up mocks base method.
+func (m *MockMigrationService) Up(ctx context.Context, count int) ([]domains.MigrationInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up", ctx, count)
+	ret0, _ := ret[0].([]domains.MigrationInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockMigrationServiceMockRecorder) Up(ctx, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockMigrationService)(nil).Up), ctx, count)
+}
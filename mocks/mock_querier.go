@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,136 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// CountDestinations mocks base method.
+func (m *MockQuerier) CountDestinations(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDestinations", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDestinations indicates an expected call of CountDestinations.
+func (mr *MockQuerierMockRecorder) CountDestinations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDestinations", reflect.TypeOf((*MockQuerier)(nil).CountDestinations), ctx)
+}
+
+// CountHotels mocks base method.
+func (m *MockQuerier) CountHotels(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHotels", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHotels indicates an expected call of CountHotels.
+func (mr *MockQuerierMockRecorder) CountHotels(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHotels", reflect.TypeOf((*MockQuerier)(nil).CountHotels), ctx)
+}
+
+// CountHotelsByDestination mocks base method.
+func (m *MockQuerier) CountHotelsByDestination(ctx context.Context) ([]*sqlc.CountHotelsByDestinationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHotelsByDestination", ctx)
+	ret0, _ := ret[0].([]*sqlc.CountHotelsByDestinationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHotelsByDestination indicates an expected call of CountHotelsByDestination.
+func (mr *MockQuerierMockRecorder) CountHotelsByDestination(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHotelsByDestination", reflect.TypeOf((*MockQuerier)(nil).CountHotelsByDestination), ctx)
+}
+
+// DecideHotelDuplicate mocks base method.
+func (m *MockQuerier) DecideHotelDuplicate(ctx context.Context, arg sqlc.DecideHotelDuplicateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideHotelDuplicate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecideHotelDuplicate indicates an expected call of DecideHotelDuplicate.
+func (mr *MockQuerierMockRecorder) DecideHotelDuplicate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideHotelDuplicate", reflect.TypeOf((*MockQuerier)(nil).DecideHotelDuplicate), ctx, arg)
+}
+
+// DeleteHotel mocks base method.
+func (m *MockQuerier) DeleteHotel(ctx context.Context, hotelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHotel", ctx, hotelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHotel indicates an expected call of DeleteHotel.
+func (mr *MockQuerierMockRecorder) DeleteHotel(ctx, hotelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHotel", reflect.TypeOf((*MockQuerier)(nil).DeleteHotel), ctx, hotelID)
+}
+
+// DeleteHotelTranslation mocks base method.
+func (m *MockQuerier) DeleteHotelTranslation(ctx context.Context, arg sqlc.DeleteHotelTranslationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHotelTranslation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHotelTranslation indicates an expected call of DeleteHotelTranslation.
+func (mr *MockQuerierMockRecorder) DeleteHotelTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHotelTranslation", reflect.TypeOf((*MockQuerier)(nil).DeleteHotelTranslation), ctx, arg)
+}
+
+// DeletePendingHotelDuplicates mocks base method.
+func (m *MockQuerier) DeletePendingHotelDuplicates(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingHotelDuplicates", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingHotelDuplicates indicates an expected call of DeletePendingHotelDuplicates.
+func (mr *MockQuerierMockRecorder) DeletePendingHotelDuplicates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingHotelDuplicates", reflect.TypeOf((*MockQuerier)(nil).DeletePendingHotelDuplicates), ctx)
+}
+
+// DeletePendingHotelDuplicatesOfHotel mocks base method.
+func (m *MockQuerier) DeletePendingHotelDuplicatesOfHotel(ctx context.Context, hotelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingHotelDuplicatesOfHotel", ctx, hotelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingHotelDuplicatesOfHotel indicates an expected call of DeletePendingHotelDuplicatesOfHotel.
+func (mr *MockQuerierMockRecorder) DeletePendingHotelDuplicatesOfHotel(ctx, hotelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingHotelDuplicatesOfHotel", reflect.TypeOf((*MockQuerier)(nil).DeletePendingHotelDuplicatesOfHotel), ctx, hotelID)
+}
+
+// FindDestination mocks base method.
+func (m *MockQuerier) FindDestination(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDestination", ctx, id)
+	ret0, _ := ret[0].(*sqlc.FindDestinationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDestination indicates an expected call of FindDestination.
+func (mr *MockQuerierMockRecorder) FindDestination(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDestination", reflect.TypeOf((*MockQuerier)(nil).FindDestination), ctx, id)
+}
+
 // FindHotelByHotelID mocks base method.
 func (m *MockQuerier) FindHotelByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +187,51 @@ func (mr *MockQuerierMockRecorder) FindHotelByHotelID(ctx, hotelID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHotelByHotelID", reflect.TypeOf((*MockQuerier)(nil).FindHotelByHotelID), ctx, hotelID)
 }
 
+// FindHotelDuplicate mocks base method.
+func (m *MockQuerier) FindHotelDuplicate(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHotelDuplicate", ctx, id)
+	ret0, _ := ret[0].(*sqlc.HotelDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHotelDuplicate indicates an expected call of FindHotelDuplicate.
+func (mr *MockQuerierMockRecorder) FindHotelDuplicate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHotelDuplicate", reflect.TypeOf((*MockQuerier)(nil).FindHotelDuplicate), ctx, id)
+}
+
+// FindHotelTranslation mocks base method.
+func (m *MockQuerier) FindHotelTranslation(ctx context.Context, arg sqlc.FindHotelTranslationParams) (*sqlc.HotelTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHotelTranslation", ctx, arg)
+	ret0, _ := ret[0].(*sqlc.HotelTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHotelTranslation indicates an expected call of FindHotelTranslation.
+func (mr *MockQuerierMockRecorder) FindHotelTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHotelTranslation", reflect.TypeOf((*MockQuerier)(nil).FindHotelTranslation), ctx, arg)
+}
+
+// FindHotelsByCountryCode mocks base method.
+func (m *MockQuerier) FindHotelsByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHotelsByCountryCode", ctx, countryCode)
+	ret0, _ := ret[0].([]*sqlc.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHotelsByCountryCode indicates an expected call of FindHotelsByCountryCode.
+func (mr *MockQuerierMockRecorder) FindHotelsByCountryCode(ctx, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHotelsByCountryCode", reflect.TypeOf((*MockQuerier)(nil).FindHotelsByCountryCode), ctx, countryCode)
+}
+
 // FindHotelsByDestinationAndHotelIDs mocks base method.
 func (m *MockQuerier) FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg sqlc.FindHotelsByDestinationAndHotelIDsParams) ([]*sqlc.Hotel, error) {
 	m.ctrl.T.Helper()
@@ -100,3 +276,304 @@ func (mr *MockQuerierMockRecorder) FindHotelsByHotelIDs(ctx, hotelIds any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHotelsByHotelIDs", reflect.TypeOf((*MockQuerier)(nil).FindHotelsByHotelIDs), ctx, hotelIds)
 }
+
+// GetLastSyncedAt mocks base method.
+func (m *MockQuerier) GetLastSyncedAt(ctx context.Context) (pgtype.Timestamptz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncedAt", ctx)
+	ret0, _ := ret[0].(pgtype.Timestamptz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncedAt indicates an expected call of GetLastSyncedAt.
+func (mr *MockQuerierMockRecorder) GetLastSyncedAt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncedAt", reflect.TypeOf((*MockQuerier)(nil).GetLastSyncedAt), ctx)
+}
+
+// InsertMissingDestinations mocks base method.
+func (m *MockQuerier) InsertMissingDestinations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMissingDestinations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertMissingDestinations indicates an expected call of InsertMissingDestinations.
+func (mr *MockQuerierMockRecorder) InsertMissingDestinations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMissingDestinations", reflect.TypeOf((*MockQuerier)(nil).InsertMissingDestinations), ctx)
+}
+
+// ListAllHotelTranslations mocks base method.
+func (m *MockQuerier) ListAllHotelTranslations(ctx context.Context) ([]*sqlc.HotelTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllHotelTranslations", ctx)
+	ret0, _ := ret[0].([]*sqlc.HotelTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllHotelTranslations indicates an expected call of ListAllHotelTranslations.
+func (mr *MockQuerierMockRecorder) ListAllHotelTranslations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllHotelTranslations", reflect.TypeOf((*MockQuerier)(nil).ListAllHotelTranslations), ctx)
+}
+
+// ListDestinations mocks base method.
+func (m *MockQuerier) ListDestinations(ctx context.Context, arg sqlc.ListDestinationsParams) ([]*sqlc.ListDestinationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDestinations", ctx, arg)
+	ret0, _ := ret[0].([]*sqlc.ListDestinationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDestinations indicates an expected call of ListDestinations.
+func (mr *MockQuerierMockRecorder) ListDestinations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDestinations", reflect.TypeOf((*MockQuerier)(nil).ListDestinations), ctx, arg)
+}
+
+// ListHotelDuplicates mocks base method.
+func (m *MockQuerier) ListHotelDuplicates(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHotelDuplicates", ctx, status)
+	ret0, _ := ret[0].([]*sqlc.HotelDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHotelDuplicates indicates an expected call of ListHotelDuplicates.
+func (mr *MockQuerierMockRecorder) ListHotelDuplicates(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHotelDuplicates", reflect.TypeOf((*MockQuerier)(nil).ListHotelDuplicates), ctx, status)
+}
+
+// ListHotelRedirects mocks base method.
+func (m *MockQuerier) ListHotelRedirects(ctx context.Context) ([]*sqlc.HotelRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHotelRedirects", ctx)
+	ret0, _ := ret[0].([]*sqlc.HotelRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHotelRedirects indicates an expected call of ListHotelRedirects.
+func (mr *MockQuerierMockRecorder) ListHotelRedirects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHotelRedirects", reflect.TypeOf((*MockQuerier)(nil).ListHotelRedirects), ctx)
+}
+
+// ListHotelTranslations mocks base method.
+func (m *MockQuerier) ListHotelTranslations(ctx context.Context, hotelIds []string) ([]*sqlc.HotelTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHotelTranslations", ctx, hotelIds)
+	ret0, _ := ret[0].([]*sqlc.HotelTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHotelTranslations indicates an expected call of ListHotelTranslations.
+func (mr *MockQuerierMockRecorder) ListHotelTranslations(ctx, hotelIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHotelTranslations", reflect.TypeOf((*MockQuerier)(nil).ListHotelTranslations), ctx, hotelIds)
+}
+
+// ListHotels mocks base method.
+func (m *MockQuerier) ListHotels(ctx context.Context) ([]*sqlc.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHotels", ctx)
+	ret0, _ := ret[0].([]*sqlc.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHotels indicates an expected call of ListHotels.
+func (mr *MockQuerierMockRecorder) ListHotels(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHotels", reflect.TypeOf((*MockQuerier)(nil).ListHotels), ctx)
+}
+
+// RepointHotelRedirects mocks base method.
+func (m *MockQuerier) RepointHotelRedirects(ctx context.Context, arg sqlc.RepointHotelRedirectsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepointHotelRedirects", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepointHotelRedirects indicates an expected call of RepointHotelRedirects.
+func (mr *MockQuerierMockRecorder) RepointHotelRedirects(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepointHotelRedirects", reflect.TypeOf((*MockQuerier)(nil).RepointHotelRedirects), ctx, arg)
+}
+
+// ResetHotelDuplicateIDSequence mocks base method.
+func (m *MockQuerier) ResetHotelDuplicateIDSequence(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetHotelDuplicateIDSequence", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetHotelDuplicateIDSequence indicates an expected call of ResetHotelDuplicateIDSequence.
+func (mr *MockQuerierMockRecorder) ResetHotelDuplicateIDSequence(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetHotelDuplicateIDSequence", reflect.TypeOf((*MockQuerier)(nil).ResetHotelDuplicateIDSequence), ctx)
+}
+
+// ResetHotelIDSequence mocks base method.
+func (m *MockQuerier) ResetHotelIDSequence(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetHotelIDSequence", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetHotelIDSequence indicates an expected call of ResetHotelIDSequence.
+func (mr *MockQuerierMockRecorder) ResetHotelIDSequence(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetHotelIDSequence", reflect.TypeOf((*MockQuerier)(nil).ResetHotelIDSequence), ctx)
+}
+
+// RestoreDestination mocks base method.
+func (m *MockQuerier) RestoreDestination(ctx context.Context, arg sqlc.RestoreDestinationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDestination", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDestination indicates an expected call of RestoreDestination.
+func (mr *MockQuerierMockRecorder) RestoreDestination(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDestination", reflect.TypeOf((*MockQuerier)(nil).RestoreDestination), ctx, arg)
+}
+
+// RestoreHotel mocks base method.
+func (m *MockQuerier) RestoreHotel(ctx context.Context, arg sqlc.RestoreHotelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHotel", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreHotel indicates an expected call of RestoreHotel.
+func (mr *MockQuerierMockRecorder) RestoreHotel(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHotel", reflect.TypeOf((*MockQuerier)(nil).RestoreHotel), ctx, arg)
+}
+
+// RestoreHotelDuplicate mocks base method.
+func (m *MockQuerier) RestoreHotelDuplicate(ctx context.Context, arg sqlc.RestoreHotelDuplicateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHotelDuplicate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreHotelDuplicate indicates an expected call of RestoreHotelDuplicate.
+func (mr *MockQuerierMockRecorder) RestoreHotelDuplicate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHotelDuplicate", reflect.TypeOf((*MockQuerier)(nil).RestoreHotelDuplicate), ctx, arg)
+}
+
+// RestoreHotelRedirect mocks base method.
+func (m *MockQuerier) RestoreHotelRedirect(ctx context.Context, arg sqlc.RestoreHotelRedirectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHotelRedirect", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreHotelRedirect indicates an expected call of RestoreHotelRedirect.
+func (mr *MockQuerierMockRecorder) RestoreHotelRedirect(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHotelRedirect", reflect.TypeOf((*MockQuerier)(nil).RestoreHotelRedirect), ctx, arg)
+}
+
+// RestoreHotelTranslation mocks base method.
+func (m *MockQuerier) RestoreHotelTranslation(ctx context.Context, arg sqlc.RestoreHotelTranslationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHotelTranslation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreHotelTranslation indicates an expected call of RestoreHotelTranslation.
+func (mr *MockQuerierMockRecorder) RestoreHotelTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHotelTranslation", reflect.TypeOf((*MockQuerier)(nil).RestoreHotelTranslation), ctx, arg)
+}
+
+// UpsertDestination mocks base method.
+func (m *MockQuerier) UpsertDestination(ctx context.Context, arg sqlc.UpsertDestinationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDestination", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDestination indicates an expected call of UpsertDestination.
+func (mr *MockQuerierMockRecorder) UpsertDestination(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDestination", reflect.TypeOf((*MockQuerier)(nil).UpsertDestination), ctx, arg)
+}
+
+// UpsertHotel mocks base method.
+func (m *MockQuerier) UpsertHotel(ctx context.Context, arg sqlc.UpsertHotelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertHotel", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertHotel indicates an expected call of UpsertHotel.
+func (mr *MockQuerierMockRecorder) UpsertHotel(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertHotel", reflect.TypeOf((*MockQuerier)(nil).UpsertHotel), ctx, arg)
+}
+
+// UpsertHotelDuplicate mocks base method.
+func (m *MockQuerier) UpsertHotelDuplicate(ctx context.Context, arg sqlc.UpsertHotelDuplicateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertHotelDuplicate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertHotelDuplicate indicates an expected call of UpsertHotelDuplicate.
+func (mr *MockQuerierMockRecorder) UpsertHotelDuplicate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertHotelDuplicate", reflect.TypeOf((*MockQuerier)(nil).UpsertHotelDuplicate), ctx, arg)
+}
+
+// UpsertHotelRedirect mocks base method.
+func (m *MockQuerier) UpsertHotelRedirect(ctx context.Context, arg sqlc.UpsertHotelRedirectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertHotelRedirect", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertHotelRedirect indicates an expected call of UpsertHotelRedirect.
+func (mr *MockQuerierMockRecorder) UpsertHotelRedirect(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertHotelRedirect", reflect.TypeOf((*MockQuerier)(nil).UpsertHotelRedirect), ctx, arg)
+}
+
+// UpsertHotelTranslation mocks base method.
+func (m *MockQuerier) UpsertHotelTranslation(ctx context.Context, arg sqlc.UpsertHotelTranslationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertHotelTranslation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertHotelTranslation indicates an expected call of UpsertHotelTranslation.
+func (mr *MockQuerierMockRecorder) UpsertHotelTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertHotelTranslation", reflect.TypeOf((*MockQuerier)(nil).UpsertHotelTranslation), ctx, arg)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domains (interfaces: TranslationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_translation_service.go -package=mocks ./domains TranslationService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sqlc "github.com/duylamasd/hotels-merge/sqlc"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTranslationService is a mock of TranslationService interface.
+type MockTranslationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTranslationServiceMockRecorder is the mock recorder for MockTranslationService.
+type MockTranslationServiceMockRecorder struct {
+	mock *MockTranslationService
+}
+
+// NewMockTranslationService creates a new mock instance.
+func NewMockTranslationService(ctrl *gomock.Controller) *MockTranslationService {
+	mock := &MockTranslationService{ctrl: ctrl}
+	mock.recorder = &MockTranslationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationService) EXPECT() *MockTranslationServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTranslationService) Delete(ctx context.Context, hotelID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, hotelID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTranslationServiceMockRecorder) Delete(ctx, hotelID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTranslationService)(nil).Delete), ctx, hotelID, locale)
+}
+
+// List mocks base method.
+func (m *MockTranslationService) List(ctx context.Context, hotelID string) ([]*sqlc.HotelTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, hotelID)
+	ret0, _ := ret[0].([]*sqlc.HotelTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTranslationServiceMockRecorder) List(ctx, hotelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTranslationService)(nil).List), ctx, hotelID)
+}
+
+// Localize mocks base method.
+func (m *MockTranslationService) Localize(ctx context.Context, hotels []*sqlc.Hotel, chain []string) ([]*sqlc.Hotel, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Localize", ctx, hotels, chain)
+	ret0, _ := ret[0].([]*sqlc.Hotel)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Localize indicates an expected call of Localize.
+func (mr *MockTranslationServiceMockRecorder) Localize(ctx, hotels, chain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localize", reflect.TypeOf((*MockTranslationService)(nil).Localize), ctx, hotels, chain)
+}
+
+// Upsert mocks base method.
+func (m *MockTranslationService) Upsert(ctx context.Context, translation sqlc.UpsertHotelTranslationParams) (*sqlc.HotelTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, translation)
+	ret0, _ := ret[0].(*sqlc.HotelTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockTranslationServiceMockRecorder) Upsert(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockTranslationService)(nil).Upsert), ctx, translation)
+}
@@ -0,0 +1,156 @@
+// Package amenities maps free text supplier amenities onto a versioned taxonomy of canonical codes.
+package amenities
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+//go:embed taxonomy.json
+var taxonomyFile []byte
+
+var (
+	defaultTaxonomy = MustParse(taxonomyFile)
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type Amenity struct {
+	Code     string   `json:"code"`
+	Label    string   `json:"label"`
+	Category string   `json:"category"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// Taxonomy is bumped to a new version whenever a code is added, removed or changes meaning.
+type Taxonomy struct {
+	Version   int       `json:"version"`
+	Amenities []Amenity `json:"amenities"`
+
+	byTerm map[string]int
+}
+
+// Default returns the taxonomy bundled in the binary.
+func Default() *Taxonomy {
+	return defaultTaxonomy
+}
+
+// Parse reads a taxonomy and checks that codes are unique and that every term names one amenity.
+func Parse(content []byte) (*Taxonomy, error) {
+	var taxonomy Taxonomy
+	if err := json.Unmarshal(content, &taxonomy); err != nil {
+		return nil, err
+	}
+	if taxonomy.Version < 1 {
+		return nil, fmt.Errorf("taxonomy version must be at least 1, got %d", taxonomy.Version)
+	}
+
+	taxonomy.byTerm = map[string]int{}
+	for i, amenity := range taxonomy.Amenities {
+		if amenity.Code == "" || amenity.Label == "" || amenity.Category == "" {
+			return nil, fmt.Errorf("amenity %d needs a code, a label and a category", i)
+		}
+
+		terms := append([]string{amenity.Code, amenity.Label}, amenity.Synonyms...)
+		for _, term := range terms {
+			key := Key(term)
+			if other, ok := taxonomy.byTerm[key]; ok && other != i {
+				return nil, fmt.Errorf("term %q maps to both %s and %s", term, taxonomy.Amenities[other].Code, amenity.Code)
+			}
+			taxonomy.byTerm[key] = i
+		}
+	}
+
+	return &taxonomy, nil
+}
+
+func MustParse(content []byte) *Taxonomy {
+	taxonomy, err := Parse(content)
+	if err != nil {
+		panic(fmt.Sprintf("invalid amenity taxonomy: %v", err))
+	}
+
+	return taxonomy
+}
+
+// Key is the form terms are matched in: lowercase words split on camel case and punctuation,
+// so "BusinessCenter", "business-center" and " Business  Center" match.
+func Key(term string) string {
+	var split strings.Builder
+	var previous rune
+	for i, r := range strings.TrimSpace(term) {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(previous) {
+			split.WriteRune(' ')
+		}
+		split.WriteRune(r)
+		previous = r
+	}
+
+	return strings.TrimSpace(nonAlphanumeric.ReplaceAllString(strings.ToLower(split.String()), " "))
+}
+
+// Lookup returns the amenity a term maps to.
+func (t *Taxonomy) Lookup(term string) (Amenity, bool) {
+	i, ok := t.byTerm[Key(term)]
+	if !ok {
+		return Amenity{}, false
+	}
+
+	return t.Amenities[i], true
+}
+
+// Classify maps terms onto the taxonomy, keeping each amenity once in the order of its first term.
+// Unmapped returns the keys of the terms matching no amenity, once each.
+func (t *Taxonomy) Classify(terms ...[]string) (canonical []dto.HotelAmenity, unmapped []string) {
+	canonical = []dto.HotelAmenity{}
+	seenKeys, seenCodes := map[string]bool{}, map[string]bool{}
+	for _, group := range terms {
+		for _, term := range group {
+			key := Key(term)
+			if key == "" || seenKeys[key] {
+				continue
+			}
+			seenKeys[key] = true
+
+			amenity, ok := t.Lookup(term)
+			if !ok {
+				unmapped = append(unmapped, key)
+				continue
+			}
+			if seenCodes[amenity.Code] {
+				continue
+			}
+			seenCodes[amenity.Code] = true
+			canonical = append(canonical, dto.HotelAmenity{Code: amenity.Code, Label: amenity.Label, Category: amenity.Category})
+		}
+	}
+
+	return canonical, unmapped
+}
+
+// Map maps the general and room amenities onto the taxonomy, replacing any canonical amenities.
+func (t *Taxonomy) Map(amenities *dto.HotelAmenities) *dto.HotelAmenities {
+	if amenities == nil {
+		return nil
+	}
+
+	mapped := *amenities
+	mapped.Canonical, _ = t.Classify(amenities.General, amenities.Room)
+	mapped.TaxonomyVersion = t.Version
+	return &mapped
+}
+
+// Normalize maps amenities mapped with another taxonomy version, or never. Amenities mapped with this
+// version are kept, as the merge also maps the terms of suppliers missing from general and room.
+func (t *Taxonomy) Normalize(amenities *dto.HotelAmenities) *dto.HotelAmenities {
+	if amenities == nil || amenities.TaxonomyVersion == t.Version {
+		return amenities
+	}
+
+	return t.Map(amenities)
+}
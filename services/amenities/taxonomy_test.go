@@ -0,0 +1,78 @@
+package amenities_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/amenities"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	for term, want := range map[string]string{
+		"wifi":              "wifi",
+		" WiFi ":            "wi fi",
+		"BusinessCenter":    "business center",
+		"business-center":   "business center",
+		"Business  Center":  "business center",
+		"24-hour reception": "24 hour reception",
+	} {
+		assert.Equal(t, want, amenities.Key(term), term)
+	}
+}
+
+func TestTaxonomy_Classify(t *testing.T) {
+	taxonomy := amenities.Default()
+
+	canonical, unmapped := taxonomy.Classify(
+		[]string{"wifi", "WiFi", "free wifi", "business center", "BusinessCenter", "Sauna"},
+		[]string{"Aircon", "sauna"},
+	)
+
+	assert.Equal(t, []dto.HotelAmenity{
+		{Code: "wifi", Label: "Wi-Fi", Category: "connectivity"},
+		{Code: "business_center", Label: "Business center", Category: "business"},
+		{Code: "air_conditioning", Label: "Air conditioning", Category: "room"},
+	}, canonical)
+	assert.Equal(t, []string{"sauna"}, unmapped)
+}
+
+func TestTaxonomy_Normalize(t *testing.T) {
+	taxonomy := amenities.Default()
+
+	t.Run("should map amenities of an older taxonomy version", func(t *testing.T) {
+		normalized := taxonomy.Normalize(&dto.HotelAmenities{General: []string{"wifi"}, Room: []string{"tv"}})
+
+		assert.Equal(t, taxonomy.Version, normalized.TaxonomyVersion)
+		assert.Len(t, normalized.Canonical, 2)
+	})
+
+	t.Run("should keep amenities of the current version", func(t *testing.T) {
+		current := &dto.HotelAmenities{
+			General:         []string{"wifi"},
+			Canonical:       []dto.HotelAmenity{{Code: "wifi"}, {Code: "pool"}},
+			TaxonomyVersion: taxonomy.Version,
+		}
+
+		assert.Same(t, current, taxonomy.Normalize(current))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should reject a term of two amenities", func(t *testing.T) {
+		_, err := amenities.Parse([]byte(`{"version": 1, "amenities": [
+			{"code": "bar", "label": "Bar", "category": "dining"},
+			{"code": "minibar", "label": "Minibar", "category": "room", "synonyms": ["Bar"]}
+		]}`))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `term "Bar" maps to both bar and minibar`)
+	})
+
+	t.Run("should reject a missing version", func(t *testing.T) {
+		_, err := amenities.Parse([]byte(`{"amenities": []}`))
+
+		assert.Error(t, err)
+	})
+}
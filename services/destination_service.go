@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	// Timezones are validated against the tz database embedded in the binary, as images may not have one.
+	_ "time/tzdata"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"go.uber.org/zap"
+)
+
+type destinationService struct {
+	logger       *zap.Logger
+	db           *config.DBStore
+	queryTimeout time.Duration
+}
+
+func NewDestinationService(logger *zap.Logger, config *config.Config, db *config.DBStore) domains.DestinationService {
+	return &destinationService{
+		logger:       logger,
+		db:           db,
+		queryTimeout: config.Database.QueryTimeout,
+	}
+}
+
+func (s *destinationService) List(ctx context.Context, limit int32, offset int32) (*domains.DestinationPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	queries := s.db.Reader()
+	total, err := queries.CountDestinations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := queries.ListDestinations(ctx, sqlc.ListDestinationsParams{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	if destinations == nil {
+		destinations = []*sqlc.ListDestinationsRow{}
+	}
+
+	return &domains.DestinationPage{
+		Destinations: destinations,
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+	}, nil
+}
+
+func (s *destinationService) Find(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return s.db.Reader().FindDestination(ctx, id)
+}
+
+func (s *destinationService) Upsert(ctx context.Context, destination sqlc.UpsertDestinationParams) (*sqlc.FindDestinationRow, error) {
+	destination.Name = strings.TrimSpace(destination.Name)
+	if destination.Name == "" {
+		return nil, fmt.Errorf("%w: name is blank", domains.ErrInvalidDestination)
+	}
+	if destination.CountryCode != nil {
+		code := strings.ToUpper(*destination.CountryCode)
+		if _, ok := geo.CountryName(code); !ok {
+			return nil, fmt.Errorf("%w: unknown country code %q", domains.ErrInvalidDestination, *destination.CountryCode)
+		}
+		destination.CountryCode = &code
+	}
+	if destination.Timezone != nil {
+		if _, err := time.LoadLocation(*destination.Timezone); err != nil || *destination.Timezone == "" || *destination.Timezone == "Local" {
+			return nil, fmt.Errorf("%w: unknown timezone %q", domains.ErrInvalidDestination, *destination.Timezone)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if err := s.db.Queries.UpsertDestination(ctx, destination); err != nil {
+		return nil, err
+	}
+	stored, err := s.db.Queries.FindDestination(ctx, destination.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lib.LoggerFromContext(ctx, s.logger).Info("Stored destination", zap.String("destination_id", stored.ID))
+	return stored, nil
+}
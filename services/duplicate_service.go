@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services/duplicates"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"go.uber.org/zap"
+)
+
+type duplicateService struct {
+	logger  *zap.Logger
+	db      *config.DBStore
+	changes *HotelChanges
+}
+
+func NewDuplicateService(logger *zap.Logger, db *config.DBStore, changes *HotelChanges) domains.DuplicateService {
+	return &duplicateService{
+		logger:  logger,
+		db:      db,
+		changes: changes,
+	}
+}
+
+func (s *duplicateService) Detect(ctx context.Context) (*domains.DetectDuplicatesResult, error) {
+	logger := lib.LoggerFromContext(ctx, s.logger)
+
+	var candidates []duplicates.Candidate
+	err := s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		hotels, err := queries.ListHotels(ctx)
+		if err != nil {
+			return err
+		}
+		cleanHotels(hotels...)
+
+		decided := map[[2]string]bool{}
+		for _, status := range []string{domains.DuplicateConfirmed, domains.DuplicateRejected} {
+			pairs, err := queries.ListHotelDuplicates(ctx, status)
+			if err != nil {
+				return err
+			}
+			for _, pair := range pairs {
+				decided[[2]string{pair.HotelID, pair.DuplicateHotelID}] = true
+			}
+		}
+
+		candidates = nil
+		for _, candidate := range duplicates.Detect(hotels) {
+			if !decided[[2]string{candidate.HotelID, candidate.DuplicateHotelID}] {
+				candidates = append(candidates, candidate)
+			}
+		}
+		if err := queries.DeletePendingHotelDuplicates(ctx); err != nil {
+			return err
+		}
+		for _, candidate := range candidates {
+			err := queries.UpsertHotelDuplicate(ctx, sqlc.UpsertHotelDuplicateParams{
+				HotelID:          candidate.HotelID,
+				DuplicateHotelID: candidate.DuplicateHotelID,
+				DestinationID:    candidate.DestinationID,
+				Score:            candidate.Score,
+				NameScore:        candidate.NameScore,
+				AddressScore:     candidate.AddressScore,
+				DistanceKm:       candidate.DistanceKm,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Could not detect duplicate hotels", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Detected duplicate hotels", zap.Int("candidates", len(candidates)))
+	return &domains.DetectDuplicatesResult{Candidates: len(candidates), Clusters: duplicates.Clusters(candidates)}, nil
+}
+
+func (s *duplicateService) List(ctx context.Context, status string) ([]*sqlc.HotelDuplicate, error) {
+	items, err := s.db.Queries.ListHotelDuplicates(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		return []*sqlc.HotelDuplicate{}, nil
+	}
+
+	return items, nil
+}
+
+func (s *duplicateService) Confirm(ctx context.Context, id int32, canonicalHotelID string) (*sqlc.HotelDuplicate, error) {
+	decided, err := s.decide(ctx, id, func(queries sqlc.Querier, duplicate *sqlc.HotelDuplicate) error {
+		removed := duplicate.HotelID
+		switch canonicalHotelID {
+		case duplicate.HotelID:
+			removed = duplicate.DuplicateHotelID
+		case duplicate.DuplicateHotelID:
+		default:
+			return domains.ErrCanonicalHotel
+		}
+
+		err := queries.DecideHotelDuplicate(ctx, sqlc.DecideHotelDuplicateParams{
+			ID:               id,
+			Status:           domains.DuplicateConfirmed,
+			CanonicalHotelID: &canonicalHotelID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := queries.DeleteHotel(ctx, removed); err != nil {
+			return err
+		}
+		if err := moveTranslations(ctx, queries, removed, canonicalHotelID); err != nil {
+			return err
+		}
+		if err := queries.DeletePendingHotelDuplicatesOfHotel(ctx, removed); err != nil {
+			return err
+		}
+		err = queries.RepointHotelRedirects(ctx, sqlc.RepointHotelRedirectsParams{HotelID: removed, CanonicalHotelID: canonicalHotelID})
+		if err != nil {
+			return err
+		}
+		return queries.UpsertHotelRedirect(ctx, sqlc.UpsertHotelRedirectParams{HotelID: removed, CanonicalHotelID: canonicalHotelID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.changes.Notify()
+	return decided, nil
+}
+
+func (s *duplicateService) Reject(ctx context.Context, id int32) (*sqlc.HotelDuplicate, error) {
+	return s.decide(ctx, id, func(queries sqlc.Querier, _ *sqlc.HotelDuplicate) error {
+		return queries.DecideHotelDuplicate(ctx, sqlc.DecideHotelDuplicateParams{ID: id, Status: domains.DuplicateRejected})
+	})
+}
+
+// moveTranslations re-keys the translations of the merged away hotel to the canonical hotel for the locales it has
+// none in, and deletes the others.
+func moveTranslations(ctx context.Context, queries sqlc.Querier, removed string, canonicalHotelID string) error {
+	translations, err := queries.ListHotelTranslations(ctx, []string{removed, canonicalHotelID})
+	if err != nil {
+		return err
+	}
+
+	translated := map[string]bool{}
+	for _, translation := range translations {
+		if translation.HotelID == canonicalHotelID {
+			translated[translation.Locale] = true
+		}
+	}
+
+	for _, translation := range translations {
+		if translation.HotelID != removed {
+			continue
+		}
+
+		err := queries.DeleteHotelTranslation(ctx, sqlc.DeleteHotelTranslationParams{HotelID: removed, Locale: translation.Locale})
+		if err != nil {
+			return err
+		}
+		if translated[translation.Locale] {
+			continue
+		}
+
+		moved := sqlc.RestoreHotelTranslationParams(*translation)
+		moved.HotelID = canonicalHotelID
+		if err := queries.RestoreHotelTranslation(ctx, moved); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decide runs fn on a pending candidate in a transaction and returns the candidate as decided.
+func (s *duplicateService) decide(ctx context.Context, id int32, fn func(queries sqlc.Querier, duplicate *sqlc.HotelDuplicate) error) (*sqlc.HotelDuplicate, error) {
+	var decided *sqlc.HotelDuplicate
+	err := s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		duplicate, err := queries.FindHotelDuplicate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if duplicate.Status != domains.DuplicatePending {
+			return domains.ErrDuplicateDecided
+		}
+		if err := fn(queries, duplicate); err != nil {
+			return err
+		}
+
+		decided, err = queries.FindHotelDuplicate(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lib.LoggerFromContext(ctx, s.logger).Info("Decided duplicate hotels",
+		zap.Int32("id", id),
+		zap.String("status", decided.Status),
+		zap.String("hotel_id", decided.HotelID),
+		zap.String("duplicate_hotel_id", decided.DuplicateHotelID),
+	)
+	return decided, nil
+}
@@ -0,0 +1,233 @@
+// Package duplicates finds hotels of a destination likely to be the same property under different hotel ids.
+package duplicates
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+const (
+	// Threshold is the lowest score of a candidate pair.
+	Threshold = 0.75
+	// MinNameScore is the lowest name similarity of a candidate pair, whatever its address and coordinates.
+	MinNameScore = 0.5
+	// ProximityKm is the distance from which coordinates no longer count toward a match.
+	ProximityKm = 0.5
+)
+
+// Signal weights. A signal missing from either hotel is left out and the others are scaled up.
+const (
+	nameWeight      = 0.5
+	addressWeight   = 0.25
+	proximityWeight = 0.25
+)
+
+// genericWords are left out of names, as "Hotel" or "The" say nothing about which property it is.
+var genericWords = map[string]bool{
+	"the": true, "hotel": true, "hotels": true, "and": true, "by": true, "at": true, "of": true,
+}
+
+// Candidate is a pair of hotels of a destination, ordered by hotel id, likely to be the same property.
+type Candidate struct {
+	HotelID          string
+	DuplicateHotelID string
+	DestinationID    string
+	Score            float64
+	NameScore        float64
+	// AddressScore is nil when either hotel has no address.
+	AddressScore *float64
+	// DistanceKm is nil when either hotel has no coordinates.
+	DistanceKm *float64
+}
+
+type profile struct {
+	hotel   *sqlc.Hotel
+	name    string
+	address string
+}
+
+// Detect compares the hotels of each destination pairwise and returns the candidate pairs scoring at least
+// Threshold, ordered by destination, highest score first.
+func Detect(hotels []*sqlc.Hotel) []Candidate {
+	byDestination := map[string][]profile{}
+	for _, hotel := range hotels {
+		byDestination[hotel.DestinationID] = append(byDestination[hotel.DestinationID], newProfile(hotel))
+	}
+
+	candidates := []Candidate{}
+	for _, profiles := range byDestination {
+		sort.Slice(profiles, func(i, j int) bool {
+			return profiles[i].hotel.HotelID < profiles[j].hotel.HotelID
+		})
+		for i := range profiles {
+			for j := i + 1; j < len(profiles); j++ {
+				if candidate, ok := compare(profiles[i], profiles[j]); ok {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.DestinationID != b.DestinationID {
+			return a.DestinationID < b.DestinationID
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.HotelID+"\x00"+a.DuplicateHotelID < b.HotelID+"\x00"+b.DuplicateHotelID
+	})
+
+	return candidates
+}
+
+// Clusters groups the hotel ids of candidates linked by any pair, each cluster and the clusters ordered by hotel id.
+func Clusters(candidates []Candidate) [][]string {
+	parent := map[string]string{}
+	var find func(hotelID string) string
+	find = func(hotelID string) string {
+		if parent[hotelID] == "" || parent[hotelID] == hotelID {
+			parent[hotelID] = hotelID
+			return hotelID
+		}
+		parent[hotelID] = find(parent[hotelID])
+		return parent[hotelID]
+	}
+
+	for _, candidate := range candidates {
+		a, b := find(candidate.HotelID), find(candidate.DuplicateHotelID)
+		if a != b {
+			parent[max(a, b)] = min(a, b)
+		}
+	}
+
+	groups := map[string][]string{}
+	for hotelID := range parent {
+		root := find(hotelID)
+		groups[root] = append(groups[root], hotelID)
+	}
+
+	clusters := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		slices.Sort(group)
+		clusters = append(clusters, group)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i][0] < clusters[j][0]
+	})
+
+	return clusters
+}
+
+func newProfile(hotel *sqlc.Hotel) profile {
+	p := profile{hotel: hotel, name: normalize(hotel.Name, genericWords)}
+	if hotel.Location != nil && hotel.Location.Address != nil {
+		p.address = normalize(*hotel.Location.Address, nil)
+	}
+
+	return p
+}
+
+func compare(a, b profile) (Candidate, bool) {
+	candidate := Candidate{
+		HotelID:          a.hotel.HotelID,
+		DuplicateHotelID: b.hotel.HotelID,
+		DestinationID:    a.hotel.DestinationID,
+		NameScore:        round(similarity(a.name, b.name), 100),
+	}
+	if candidate.NameScore < MinNameScore {
+		return candidate, false
+	}
+
+	total, weights := nameWeight*candidate.NameScore, nameWeight
+	if a.address != "" && b.address != "" {
+		score := round(similarity(a.address, b.address), 100)
+		candidate.AddressScore = &score
+		total, weights = total+addressWeight*score, weights+addressWeight
+	}
+	if latitudeA, longitudeA, ok := coordinates(a.hotel); ok {
+		if latitudeB, longitudeB, ok := coordinates(b.hotel); ok {
+			distance := geo.Distance(latitudeA, longitudeA, latitudeB, longitudeB)
+			rounded := round(distance, 1000)
+			candidate.DistanceKm = &rounded
+			total, weights = total+proximityWeight*max(0, 1-distance/ProximityKm), weights+proximityWeight
+		}
+	}
+
+	candidate.Score = round(total/weights, 100)
+	return candidate, candidate.Score >= Threshold
+}
+
+func coordinates(hotel *sqlc.Hotel) (float64, float64, bool) {
+	location := hotel.Location
+	if location == nil || location.Latitude == nil || location.Longitude == nil {
+		return 0, 0, false
+	}
+
+	return *location.Latitude, *location.Longitude, true
+}
+
+// normalize folds case and diacritics, drops punctuation and the skipped words, and sorts the words,
+// so that reordered names compare equal.
+func normalize(value string, skipped map[string]bool) string {
+	words := strings.Fields(geo.Key(value))
+	kept := words[:0]
+	for _, word := range words {
+		if !skipped[word] {
+			kept = append(kept, word)
+		}
+	}
+	slices.Sort(kept)
+
+	return strings.Join(kept, " ")
+}
+
+// similarity is the Sørensen–Dice coefficient of the character bigrams of a and b, from 0 to 1.
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	bigramsA, bigramsB := bigrams(a), bigrams(b)
+	if len(bigramsA) == 0 || len(bigramsB) == 0 {
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, bigram := range bigramsA {
+		counts[bigram]++
+	}
+	shared := 0
+	for _, bigram := range bigramsB {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(len(bigramsA)+len(bigramsB))
+}
+
+func bigrams(value string) []string {
+	runes := []rune(value)
+	if len(runes) < 2 {
+		return nil
+	}
+
+	pairs := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		pairs = append(pairs, string(runes[i:i+2]))
+	}
+
+	return pairs
+}
+
+func round(value float64, scale float64) float64 {
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,77 @@
+package duplicates_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/duplicates"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hotel(hotelID, destinationID, name, address string, latitude, longitude float64) *sqlc.Hotel {
+	location := &dto.HotelLocation{}
+	if address != "" {
+		location.Address = &address
+	}
+	if latitude != 0 || longitude != 0 {
+		location.Latitude, location.Longitude = &latitude, &longitude
+	}
+
+	return &sqlc.Hotel{HotelID: hotelID, DestinationID: destinationID, Name: name, Location: location}
+}
+
+func TestDetect(t *testing.T) {
+	hotels := []*sqlc.Hotel{
+		hotel("iJhz", "5432", "Beach Villas Singapore", "8 Sentosa Gateway, Beach Villas, 098269", 1.264751, 103.824006),
+		hotel("SjyX", "5432", "The Beach Villas, Singapore", "8 Sentosa Gateway, Beach Villas", 1.26475, 103.8241),
+		hotel("f8c9", "5432", "InterContinental Singapore Robertson Quay", "1 Nanson Road", 1.2893, 103.8400),
+		hotel("a1b2", "5433", "Beach Villas Singapore", "8 Sentosa Gateway, Beach Villas, 098269", 1.264751, 103.824006),
+		hotel("k3l4", "1122", "Hilton Shinjuku Tokyo", "", 0, 0),
+		hotel("m5n6", "1122", "Hilton Tokyo Shinjuku", "", 0, 0),
+	}
+
+	candidates := duplicates.Detect(hotels)
+
+	require.Len(t, candidates, 2)
+
+	tokyo := candidates[0]
+	assert.Equal(t, "k3l4", tokyo.HotelID)
+	assert.Equal(t, "m5n6", tokyo.DuplicateHotelID)
+	assert.Equal(t, 1.0, tokyo.Score, "reordered names should match")
+	assert.Nil(t, tokyo.AddressScore)
+	assert.Nil(t, tokyo.DistanceKm)
+
+	sentosa := candidates[1]
+	assert.Equal(t, "5432", sentosa.DestinationID)
+	assert.Equal(t, "SjyX", sentosa.HotelID)
+	assert.Equal(t, "iJhz", sentosa.DuplicateHotelID)
+	assert.Equal(t, 1.0, sentosa.NameScore)
+	require.NotNil(t, sentosa.AddressScore)
+	assert.Greater(t, *sentosa.AddressScore, 0.8)
+	require.NotNil(t, sentosa.DistanceKm)
+	assert.Less(t, *sentosa.DistanceKm, 0.02)
+	assert.GreaterOrEqual(t, sentosa.Score, duplicates.Threshold)
+}
+
+func TestDetect_DifferentProperties(t *testing.T) {
+	hotels := []*sqlc.Hotel{
+		hotel("a", "1", "Holiday Inn Express Singapore Orchard Road", "20 Bideford Road", 1.3027, 103.8352),
+		hotel("b", "1", "Holiday Inn Express Singapore Clarke Quay", "2 Magazine Road", 1.2889, 103.8424),
+		hotel("c", "1", "Beach Villas", "8 Sentosa Gateway", 1.264751, 103.824006),
+		hotel("d", "1", "Sentosa Beach Resort", "8 Sentosa Gateway", 1.264751, 103.824006),
+	}
+
+	assert.Empty(t, duplicates.Detect(hotels))
+}
+
+func TestClusters(t *testing.T) {
+	clusters := duplicates.Clusters([]duplicates.Candidate{
+		{HotelID: "c", DuplicateHotelID: "d"},
+		{HotelID: "a", DuplicateHotelID: "b"},
+		{HotelID: "b", DuplicateHotelID: "e"},
+	})
+
+	assert.Equal(t, [][]string{{"a", "b", "e"}, {"c", "d"}}, clusters)
+}
@@ -0,0 +1,210 @@
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+// CityRadiusKm is how far from the center of its city a hotel may be.
+const CityRadiusKm = 100
+
+const earthRadiusKm = 6371
+
+//go:embed cities.json
+var citiesFile []byte
+
+var cities = mustParseCities(citiesFile)
+
+// Area is a circle covering part of a country.
+type Area struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+// City is the center of a city of the country with the alpha-2 code Country.
+type City struct {
+	Name      string   `json:"name"`
+	Country   string   `json:"country"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Aliases   []string `json:"aliases"`
+}
+
+type cityIndex struct {
+	byKey map[string][]*City
+}
+
+func mustParseCities(content []byte) *cityIndex {
+	var list []*City
+	if err := json.Unmarshal(content, &list); err != nil {
+		panic(fmt.Sprintf("invalid city data: %v", err))
+	}
+
+	index := &cityIndex{byKey: map[string][]*City{}}
+	for _, city := range list {
+		if _, ok := countries.byCode[city.Country]; !ok || city.Name == "" {
+			panic(fmt.Sprintf("invalid city data: city %q needs a name and a known country, got %q", city.Name, city.Country))
+		}
+
+		for _, name := range append([]string{city.Name}, city.Aliases...) {
+			key := Key(name)
+			for _, other := range index.byKey[key] {
+				if other.Country == city.Country {
+					panic(fmt.Sprintf("invalid city data: %q names two cities of %s", name, city.Country))
+				}
+			}
+			index.byKey[key] = append(index.byKey[key], city)
+		}
+	}
+
+	return index
+}
+
+// LookupCity finds a city by name or alias in the country with the alpha-2 code. Without a code, the
+// city is found only when no other country has a city of that name.
+func LookupCity(name string, code string) (City, bool) {
+	matches := cities.byKey[Key(name)]
+	if code == "" {
+		if len(matches) != 1 {
+			return City{}, false
+		}
+		return *matches[0], true
+	}
+
+	for _, city := range matches {
+		if city.Country == code {
+			return *city, true
+		}
+	}
+
+	return City{}, false
+}
+
+// Distance returns the great-circle distance between two points in kilometers.
+func Distance(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	lat1, lat2 := radians(latitude1), radians(latitude2)
+	dLat, dLng := lat2-lat1, radians(longitude2-longitude1)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// Contains reports whether a point lies in one of the areas of the country.
+func (c Country) Contains(latitude, longitude float64) bool {
+	for _, area := range c.Areas {
+		if Distance(area.Latitude, area.Longitude, latitude, longitude) <= area.RadiusKm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reference is the place a location claims to be in: its city when the city is known, else its country.
+type reference struct {
+	city    *City
+	country *Country
+}
+
+func (r reference) known() bool {
+	return r.city != nil || r.country != nil
+}
+
+func (r reference) contains(latitude, longitude float64) bool {
+	if r.city != nil {
+		return Distance(r.city.Latitude, r.city.Longitude, latitude, longitude) <= CityRadiusKm
+	}
+
+	return r.country.Contains(latitude, longitude)
+}
+
+func (r reference) issue() string {
+	if r.city != nil {
+		return dto.IssueFarFromCity
+	}
+
+	return dto.IssueOutsideCountry
+}
+
+// Check validates the coordinates of location against their ranges, null island and the bundled city and
+// country reference data of the country with the alpha-2 code countryCode, which may be nil. It returns a
+// copy of location with swapped axes swapped back and unusable coordinates cleared, and the location
+// quality. Coordinates far from their city or country are kept and reported as suspect.
+func Check(location *dto.HotelLocation, countryCode *string) (*dto.HotelLocation, dto.LocationQuality) {
+	quality := dto.LocationQuality{Issues: []string{}}
+	if location == nil || (location.Latitude == nil && location.Longitude == nil) {
+		quality.Status = dto.LocationMissing
+		return location, quality
+	}
+
+	checked := *location
+	invalid := func(issue string) (*dto.HotelLocation, dto.LocationQuality) {
+		checked.Latitude, checked.Longitude = nil, nil
+		quality.Status = dto.LocationInvalid
+		quality.Issues = append(quality.Issues, issue)
+		return &checked, quality
+	}
+	if location.Latitude == nil || location.Longitude == nil {
+		return invalid(dto.IssuePartialCoordinates)
+	}
+
+	latitude, longitude := *location.Latitude, *location.Longitude
+	if latitude == 0 && longitude == 0 {
+		return invalid(dto.IssueNullIsland)
+	}
+
+	ref := referenceOf(location, countryCode)
+	inRange := validRange(latitude, longitude)
+	swappedInRange := latitude != longitude && validRange(longitude, latitude)
+
+	switch {
+	case inRange && (!ref.known() || ref.contains(latitude, longitude)):
+		quality.Status = dto.LocationOK
+		if !ref.known() {
+			quality.Status = dto.LocationUnverified
+		}
+	case swappedInRange && ((!ref.known() && !inRange) || (ref.known() && ref.contains(longitude, latitude))):
+		latitude, longitude = longitude, latitude
+		checked.Latitude, checked.Longitude = &latitude, &longitude
+		quality.Status = dto.LocationRepaired
+		quality.Issues = append(quality.Issues, dto.IssueSwappedAxes)
+	case !inRange:
+		return invalid(dto.IssueOutOfRange)
+	default:
+		quality.Status = dto.LocationSuspect
+		quality.Issues = append(quality.Issues, ref.issue())
+	}
+
+	if ref.city != nil {
+		distance := math.Round(Distance(ref.city.Latitude, ref.city.Longitude, latitude, longitude)*10) / 10
+		quality.DistanceKm = &distance
+	}
+
+	return &checked, quality
+}
+
+func referenceOf(location *dto.HotelLocation, countryCode *string) reference {
+	var ref reference
+	code := deref(countryCode)
+	if country, ok := countries.byCode[code]; ok {
+		ref.country = country
+	}
+	if city, ok := LookupCity(deref(location.City), code); ok {
+		ref.city = &city
+	}
+
+	return ref
+}
+
+func validRange(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
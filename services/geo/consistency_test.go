@@ -0,0 +1,167 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCity(t *testing.T) {
+	city, ok := geo.LookupCity("saigon", "VN")
+	require.True(t, ok)
+	assert.Equal(t, "Ho Chi Minh", city.Name)
+
+	_, ok = geo.LookupCity("Tokyo", "SG")
+	assert.False(t, ok)
+
+	city, ok = geo.LookupCity("Tokyo", "")
+	require.True(t, ok)
+	assert.Equal(t, "JP", city.Country)
+}
+
+func TestDistance(t *testing.T) {
+	assert.InDelta(t, 5320, geo.Distance(1.3521, 103.8198, 35.6762, 139.6503), 10)
+	assert.Zero(t, geo.Distance(1.3521, 103.8198, 1.3521, 103.8198))
+}
+
+func TestCheck(t *testing.T) {
+	location := func(latitude, longitude *float64, city, country string) *dto.HotelLocation {
+		return &dto.HotelLocation{Latitude: latitude, Longitude: longitude, City: &city, Country: &country}
+	}
+	code := func(value string) *string { return &value }
+	coordinate := func(value float64) *float64 { return &value }
+
+	for _, test := range []struct {
+		name        string
+		location    *dto.HotelLocation
+		countryCode *string
+		status      string
+		issues      []string
+		latitude    *float64
+		longitude   *float64
+	}{
+		{
+			name:        "coordinates in the stated city",
+			location:    location(coordinate(1.264751), coordinate(103.824006), "Singapore", "Singapore"),
+			countryCode: code("SG"),
+			status:      dto.LocationOK,
+			latitude:    coordinate(1.264751),
+			longitude:   coordinate(103.824006),
+		},
+		{
+			name:        "coordinates in the country of an unknown city",
+			location:    location(coordinate(34.39), coordinate(132.46), "Hiroshima", "Japan"),
+			countryCode: code("JP"),
+			status:      dto.LocationOK,
+			latitude:    coordinate(34.39),
+			longitude:   coordinate(132.46),
+		},
+		{
+			name:      "coordinates without a known city or country",
+			location:  location(coordinate(10), coordinate(20), "", "Atlantis"),
+			status:    dto.LocationUnverified,
+			latitude:  coordinate(10),
+			longitude: coordinate(20),
+		},
+		{
+			name:        "swapped axes",
+			location:    location(coordinate(139.690965), coordinate(35.6926), "Tokyo", "Japan"),
+			countryCode: code("JP"),
+			status:      dto.LocationRepaired,
+			issues:      []string{dto.IssueSwappedAxes},
+			latitude:    coordinate(35.6926),
+			longitude:   coordinate(139.690965),
+		},
+		{
+			name:        "swapped axes that are both in range",
+			location:    location(coordinate(103.8), coordinate(1.3), "Singapore", "Singapore"),
+			countryCode: code("SG"),
+			status:      dto.LocationRepaired,
+			issues:      []string{dto.IssueSwappedAxes},
+			latitude:    coordinate(1.3),
+			longitude:   coordinate(103.8),
+		},
+		{
+			name:      "swapped axes without a reference",
+			location:  location(coordinate(-122.4), coordinate(37.8), "", ""),
+			status:    dto.LocationRepaired,
+			issues:    []string{dto.IssueSwappedAxes},
+			latitude:  coordinate(37.8),
+			longitude: coordinate(-122.4),
+		},
+		{
+			name:        "null island",
+			location:    location(coordinate(0), coordinate(0), "Singapore", "Singapore"),
+			countryCode: code("SG"),
+			status:      dto.LocationInvalid,
+			issues:      []string{dto.IssueNullIsland},
+		},
+		{
+			name:     "out of range",
+			location: location(coordinate(95), coordinate(200), "", ""),
+			status:   dto.LocationInvalid,
+			issues:   []string{dto.IssueOutOfRange},
+		},
+		{
+			name:     "one coordinate",
+			location: location(coordinate(1.3), nil, "Singapore", "Singapore"),
+			status:   dto.LocationInvalid,
+			issues:   []string{dto.IssuePartialCoordinates},
+		},
+		{
+			name:        "coordinates far from the stated city",
+			location:    location(coordinate(1.3), coordinate(103.8), "Tokyo", "Japan"),
+			countryCode: code("JP"),
+			status:      dto.LocationSuspect,
+			issues:      []string{dto.IssueFarFromCity},
+			latitude:    coordinate(1.3),
+			longitude:   coordinate(103.8),
+		},
+		{
+			name:        "coordinates outside the stated country",
+			location:    location(coordinate(48.85), coordinate(2.35), "", "Japan"),
+			countryCode: code("JP"),
+			status:      dto.LocationSuspect,
+			issues:      []string{dto.IssueOutsideCountry},
+			latitude:    coordinate(48.85),
+			longitude:   coordinate(2.35),
+		},
+		{
+			name:     "no coordinates",
+			location: location(nil, nil, "Singapore", "Singapore"),
+			status:   dto.LocationMissing,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			original := *test.location
+
+			checked, quality := geo.Check(test.location, test.countryCode)
+
+			assert.Equal(t, test.status, quality.Status)
+			if test.issues == nil {
+				test.issues = []string{}
+			}
+			assert.Equal(t, test.issues, quality.Issues)
+			assert.Equal(t, test.latitude, checked.Latitude)
+			assert.Equal(t, test.longitude, checked.Longitude)
+			assert.Equal(t, original, *test.location, "the location should not change")
+		})
+	}
+
+	t.Run("should measure the distance from the stated city", func(t *testing.T) {
+		_, quality := geo.Check(location(coordinate(1.264751), coordinate(103.824006), "Singapore", "Singapore"), code("SG"))
+
+		require.NotNil(t, quality.DistanceKm)
+		assert.InDelta(t, 3.2, *quality.DistanceKm, 0.5)
+	})
+
+	t.Run("should report a missing location", func(t *testing.T) {
+		checked, quality := geo.Check(nil, nil)
+
+		assert.Nil(t, checked)
+		assert.Equal(t, dto.LocationMissing, quality.Status)
+	})
+}
@@ -0,0 +1,193 @@
+// Package geo normalizes hotel locations against the ISO 3166-1 reference data bundled in the binary.
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed countries.json
+var countriesFile []byte
+
+var (
+	countries       = mustParseCountries(countriesFile)
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+	// postalAffix is a postal code leading or trailing a city, such as "160-0023 Shinjuku".
+	postalAffix = regexp.MustCompile(`^[0-9][0-9 -]*[0-9]\s+|\s+[0-9][0-9 -]*[0-9]$`)
+)
+
+// Country is an ISO 3166-1 country. PostalCode is the pattern of its postal codes, if it has any, and
+// Areas are circles covering its territory.
+type Country struct {
+	Code       string   `json:"code"`
+	Alpha3     string   `json:"alpha3"`
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`
+	PostalCode string   `json:"postal_code"`
+	Areas      []Area   `json:"areas"`
+
+	postalCode *regexp.Regexp
+}
+
+type countryIndex struct {
+	byCode map[string]*Country
+	byKey  map[string]*Country
+}
+
+func mustParseCountries(content []byte) *countryIndex {
+	var list []*Country
+	if err := json.Unmarshal(content, &list); err != nil {
+		panic(fmt.Sprintf("invalid country data: %v", err))
+	}
+
+	index := &countryIndex{byCode: map[string]*Country{}, byKey: map[string]*Country{}}
+	for _, country := range list {
+		if len(country.Code) != 2 || len(country.Alpha3) != 3 || country.Name == "" || len(country.Areas) == 0 {
+			panic(fmt.Sprintf("invalid country data: country %q needs an alpha-2 code, an alpha-3 code, a name and an area", country.Code))
+		}
+		if country.PostalCode != "" {
+			country.postalCode = regexp.MustCompile(`\b` + country.PostalCode + `\b`)
+		}
+		index.byCode[country.Code] = country
+
+		for _, term := range append([]string{country.Code, country.Alpha3, country.Name}, country.Aliases...) {
+			key := Key(term)
+			if other, ok := index.byKey[key]; ok && other != country {
+				panic(fmt.Sprintf("invalid country data: %q names both %s and %s", term, other.Code, country.Code))
+			}
+			index.byKey[key] = country
+		}
+	}
+
+	return index
+}
+
+// Key folds a place name for lookups: diacritics are removed, letters lowercased and any other
+// characters collapsed to single spaces.
+func Key(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		folded = name
+	}
+
+	return strings.TrimSpace(nonAlphanumeric.ReplaceAllString(strings.ToLower(folded), " "))
+}
+
+// LookupCountry finds a country by its alpha-2 code, alpha-3 code, name or a common alias.
+func LookupCountry(value string) (Country, bool) {
+	country, ok := countries.byKey[Key(value)]
+	if !ok {
+		return Country{}, false
+	}
+
+	return *country, true
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of the country value names.
+func CountryCode(value string) (string, bool) {
+	country, ok := LookupCountry(value)
+	if !ok {
+		return "", false
+	}
+
+	return country.Code, true
+}
+
+// CountryName returns the name of the country with the alpha-2 code.
+func CountryName(code string) (string, bool) {
+	country, ok := countries.byCode[strings.ToUpper(code)]
+	if !ok {
+		return "", false
+	}
+
+	return country.Name, true
+}
+
+// NormalizeCity collapses whitespace, drops a postal code leading or trailing the name and title cases
+// names written in a single case. Names in mixed case are kept as written.
+func NormalizeCity(city string) string {
+	city = strings.Join(strings.Fields(city), " ")
+	city = strings.Trim(postalAffix.ReplaceAllString(city, ""), " ,")
+	if city != strings.ToUpper(city) && city != strings.ToLower(city) {
+		return city
+	}
+
+	capitalize := true
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			capitalize = r == ' ' || r == '-' || r == '.'
+			return r
+		}
+		if capitalize {
+			capitalize = false
+			return unicode.ToUpper(r)
+		}
+		return unicode.ToLower(r)
+	}, city)
+}
+
+// PostalCode extracts the postal code of the country with the alpha-2 code from address. Countries
+// without a known postal code format yield none.
+func PostalCode(address string, code string) (string, bool) {
+	country, ok := countries.byCode[strings.ToUpper(code)]
+	if !ok || country.postalCode == nil {
+		return "", false
+	}
+
+	postalCode := country.postalCode.FindString(strings.ToUpper(address))
+	return postalCode, postalCode != ""
+}
+
+// Normalize returns a copy of location with the country under its ISO name, the city normalized and the
+// postal code extracted from the address when it is missing, along with the alpha-2 code of the country.
+// A country that is not recognized is kept as written and has no code.
+func Normalize(location *dto.HotelLocation) (*dto.HotelLocation, *string) {
+	if location == nil {
+		return nil, nil
+	}
+
+	normalized := *location
+	normalized.Address = optional(strings.Join(strings.Fields(deref(location.Address)), " "))
+	normalized.City = optional(NormalizeCity(deref(location.City)))
+	normalized.Country = optional(strings.TrimSpace(deref(location.Country)))
+	normalized.PostalCode = optional(strings.TrimSpace(deref(location.PostalCode)))
+
+	country, ok := LookupCountry(deref(normalized.Country))
+	if !ok {
+		return &normalized, nil
+	}
+	name, code := country.Name, country.Code
+	normalized.Country = &name
+	if normalized.PostalCode == nil {
+		if postalCode, ok := PostalCode(deref(normalized.Address), code); ok {
+			normalized.PostalCode = &postalCode
+		}
+	}
+
+	return &normalized, &code
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,120 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryCode(t *testing.T) {
+	for value, code := range map[string]string{
+		"SG":                       "SG",
+		"sg":                       "SG",
+		"SGP":                      "SG",
+		"Singapore":                "SG",
+		" singapore ":              "SG",
+		"USA":                      "US",
+		"United States of America": "US",
+		"UK":                       "GB",
+		"Viet Nam":                 "VN",
+		"Cote d'Ivoire":            "CI",
+		"Côte d’Ivoire":            "CI",
+		"Turkey":                   "TR",
+	} {
+		actual, ok := geo.CountryCode(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, code, actual, value)
+	}
+
+	for _, value := range []string{"", "Atlantis", "XX", "Yugoslavia"} {
+		_, ok := geo.CountryCode(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestCountryName(t *testing.T) {
+	name, ok := geo.CountryName("jp")
+	assert.True(t, ok)
+	assert.Equal(t, "Japan", name)
+
+	_, ok = geo.CountryName("JPN")
+	assert.False(t, ok)
+}
+
+func TestNormalizeCity(t *testing.T) {
+	for city, expected := range map[string]string{
+		"Singapore":        "Singapore",
+		"  new   york ":    "New York",
+		"TOKYO":            "Tokyo",
+		"160-0023 Tokyo":   "Tokyo",
+		"Paris 75001":      "Paris",
+		"SAINT-DENIS":      "Saint-Denis",
+		"St. louis":        "St. louis",
+		"ho chi minh city": "Ho Chi Minh City",
+		"":                 "",
+	} {
+		assert.Equal(t, expected, geo.NormalizeCity(city), city)
+	}
+}
+
+func TestPostalCode(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		code    string
+		postal  string
+	}{
+		{"8 Sentosa Gateway, Beach Villas, 098269", "SG", "098269"},
+		{"160-0023, SHINJUKU-KU, 6-6-2 NISHI-SHINJUKU", "JP", "160-0023"},
+		{"350 Fifth Avenue, New York, NY 10118-0110", "US", "10118-0110"},
+		{"10 Downing St, London sw1a 2aa", "GB", "SW1A 2AA"},
+		{"Sheikh Zayed Road, Dubai", "AE", ""},
+		{"8 Sentosa Gateway", "SG", ""},
+	} {
+		postal, ok := geo.PostalCode(test.address, test.code)
+		assert.Equal(t, test.postal != "", ok, test.address)
+		assert.Equal(t, test.postal, postal, test.address)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("should code the country and extract the postal code", func(t *testing.T) {
+		address, city, country := " 8 Sentosa Gateway,  Beach Villas, 098269 ", "singapore", "SG"
+		location := &dto.HotelLocation{Address: &address, City: &city, Country: &country}
+
+		normalized, code := geo.Normalize(location)
+
+		require.NotNil(t, code)
+		assert.Equal(t, "SG", *code)
+		assert.Equal(t, "8 Sentosa Gateway, Beach Villas, 098269", *normalized.Address)
+		assert.Equal(t, "Singapore", *normalized.City)
+		assert.Equal(t, "Singapore", *normalized.Country)
+		assert.Equal(t, "098269", *normalized.PostalCode)
+		assert.Equal(t, "SG", country, "the location should not change")
+	})
+
+	t.Run("should keep a postal code that is set", func(t *testing.T) {
+		address, country, postal := "6-6-2 Nishi-Shinjuku 160-0023", "Japan", "163-8001"
+		normalized, _ := geo.Normalize(&dto.HotelLocation{Address: &address, Country: &country, PostalCode: &postal})
+
+		assert.Equal(t, "163-8001", *normalized.PostalCode)
+	})
+
+	t.Run("should keep a country that is not recognized", func(t *testing.T) {
+		country := " Atlantis "
+		normalized, code := geo.Normalize(&dto.HotelLocation{Country: &country})
+
+		assert.Nil(t, code)
+		assert.Equal(t, "Atlantis", *normalized.Country)
+		assert.Nil(t, normalized.City)
+	})
+
+	t.Run("should ignore a missing location", func(t *testing.T) {
+		normalized, code := geo.Normalize(nil)
+
+		assert.Nil(t, normalized)
+		assert.Nil(t, code)
+	})
+}
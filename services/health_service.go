@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"go.uber.org/zap"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// inMemoryDetail reports checks that do not apply to the memory backend, which has no database.
+const inMemoryDetail = "in-memory backend"
+
+const latestMigrationVersionQuery = `SELECT version
+FROM atlas_schema_revisions.atlas_schema_revisions
+WHERE applied = total
+ORDER BY version DESC
+LIMIT 1`
+
+type healthService struct {
+	logger    *zap.Logger
+	config    *config.Config
+	db        *config.DBStore
+	buildInfo *domains.BuildInfo
+	startedAt time.Time
+	draining  atomic.Bool
+}
+
+func NewHealthService(
+	logger *zap.Logger,
+	config *config.Config,
+	db *config.DBStore,
+	buildInfo *domains.BuildInfo,
+) domains.HealthService {
+	return &healthService{
+		logger:    logger,
+		config:    config,
+		db:        db,
+		buildInfo: buildInfo,
+		startedAt: time.Now(),
+	}
+}
+
+func (s *healthService) MarkDraining() {
+	s.draining.Store(true)
+}
+
+func (s *healthService) IsDraining() bool {
+	return s.draining.Load()
+}
+
+func (s *healthService) Readiness(ctx context.Context) *domains.ReadinessReport {
+	checks, _, _ := s.runChecks(ctx)
+
+	return &domains.ReadinessReport{
+		Status: s.overallStatus(checks),
+		Checks: checks,
+	}
+}
+
+func (s *healthService) Status(ctx context.Context) *domains.StatusReport {
+	checks, migrationVersion, lastSyncedAt := s.runChecks(ctx)
+
+	report := &domains.StatusReport{
+		Status:           s.overallStatus(checks),
+		Build:            *s.buildInfo,
+		StartedAt:        s.startedAt,
+		UptimeSeconds:    time.Since(s.startedAt).Seconds(),
+		MigrationVersion: migrationVersion,
+		LastSyncedAt:     lastSyncedAt,
+		Checks:           checks,
+		Replicas:         s.replicaStatuses(),
+	}
+
+	if s.db.ConnPool != nil {
+		stat := s.db.ConnPool.Stat()
+		report.Pool = &domains.PoolStats{
+			TotalConns:           stat.TotalConns(),
+			IdleConns:            stat.IdleConns(),
+			AcquiredConns:        stat.AcquiredConns(),
+			ConstructingConns:    stat.ConstructingConns(),
+			MaxConns:             stat.MaxConns(),
+			AcquireCount:         stat.AcquireCount(),
+			EmptyAcquireCount:    stat.EmptyAcquireCount(),
+			CanceledAcquireCount: stat.CanceledAcquireCount(),
+			AcquireDuration:      stat.AcquireDuration(),
+		}
+	}
+
+	return report
+}
+
+// replicaStatuses does not affect the overall status, as reads fall back to the primary when replicas fail.
+func (s *healthService) replicaStatuses() []domains.ReplicaStatus {
+	statuses := []domains.ReplicaStatus{}
+	for _, replica := range s.db.Replicas() {
+		healthy, lag, lastError, checkedAt := replica.State()
+
+		status := domains.ReplicaStatus{
+			Name:       replica.Name,
+			Status:     domains.HealthStatusOK,
+			LagSeconds: lag.Seconds(),
+			Detail:     lastError,
+		}
+		if !healthy {
+			status.Status = domains.HealthStatusFail
+		}
+		if !checkedAt.IsZero() {
+			status.CheckedAt = &checkedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func (s *healthService) overallStatus(checks []domains.HealthCheck) string {
+	if s.IsDraining() {
+		return domains.HealthStatusDraining
+	}
+
+	for _, check := range checks {
+		if check.Status != domains.HealthStatusOK {
+			return domains.HealthStatusFail
+		}
+	}
+
+	return domains.HealthStatusOK
+}
+
+func (s *healthService) runChecks(ctx context.Context) ([]domains.HealthCheck, *string, *time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	checks := []domains.HealthCheck{s.checkDatabase(ctx)}
+
+	migrationCheck, migrationVersion := s.checkMigration(ctx)
+	checks = append(checks, migrationCheck)
+
+	syncCheck, lastSyncedAt := s.checkLastSync(ctx)
+	checks = append(checks, syncCheck)
+
+	return checks, migrationVersion, lastSyncedAt
+}
+
+func (s *healthService) checkDatabase(ctx context.Context) domains.HealthCheck {
+	check := domains.HealthCheck{Name: "database", Status: domains.HealthStatusOK}
+
+	if s.db.InMemory() {
+		check.Detail = inMemoryDetail
+		return check
+	}
+
+	if s.db.ConnPool == nil {
+		check.Status = domains.HealthStatusFail
+		check.Detail = "connection pool is not configured"
+		return check
+	}
+
+	if err := s.db.ConnPool.Ping(ctx); err != nil {
+		s.logger.Warn("Database ping failed", zap.Error(err))
+		check.Status = domains.HealthStatusFail
+		check.Detail = err.Error()
+	}
+
+	return check
+}
+
+func (s *healthService) checkMigration(ctx context.Context) (domains.HealthCheck, *string) {
+	check := domains.HealthCheck{Name: "migration", Status: domains.HealthStatusOK}
+
+	if s.db.InMemory() {
+		check.Detail = inMemoryDetail
+		return check, nil
+	}
+
+	if s.db.ConnPool == nil {
+		check.Status = domains.HealthStatusFail
+		check.Detail = "connection pool is not configured"
+		return check, nil
+	}
+
+	var version string
+	if err := s.db.ConnPool.QueryRow(ctx, latestMigrationVersionQuery).Scan(&version); err != nil {
+		s.logger.Warn("Could not read migration version", zap.Error(err))
+		check.Status = domains.HealthStatusFail
+		check.Detail = err.Error()
+		return check, nil
+	}
+
+	check.Detail = version
+	return check, &version
+}
+
+func (s *healthService) checkLastSync(ctx context.Context) (domains.HealthCheck, *time.Time) {
+	check := domains.HealthCheck{Name: "last_sync", Status: domains.HealthStatusOK}
+
+	lastSyncedAt, err := s.db.Queries.GetLastSyncedAt(ctx)
+	if err != nil {
+		s.logger.Warn("Could not read last sync time", zap.Error(err))
+		check.Status = domains.HealthStatusFail
+		check.Detail = err.Error()
+		return check, nil
+	}
+
+	if !lastSyncedAt.Valid {
+		check.Detail = "no hotels have been synced yet"
+		if s.config.Readiness.MaxSyncAge > 0 {
+			check.Status = domains.HealthStatusFail
+		}
+		return check, nil
+	}
+
+	age := time.Since(lastSyncedAt.Time)
+	check.Detail = fmt.Sprintf("last sync was %s ago", age.Round(time.Second))
+	if s.config.Readiness.MaxSyncAge > 0 && age > s.config.Readiness.MaxSyncAge {
+		check.Status = domains.HealthStatusFail
+		check.Detail = fmt.Sprintf("last sync was %s ago, exceeding %s", age.Round(time.Second), s.config.Readiness.MaxSyncAge)
+	}
+
+	return check, &lastSyncedAt.Time
+}
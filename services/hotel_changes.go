@@ -0,0 +1,33 @@
+package services
+
+import "sync"
+
+// HotelChanges notifies subscribers after a write to the stored hotels committed,
+// such as the hotel cache, which would otherwise serve stale hotels until they expire.
+// Subscribers only run in the process that made the write.
+type HotelChanges struct {
+	mu          sync.Mutex
+	subscribers []func()
+}
+
+func NewHotelChanges() *HotelChanges {
+	return &HotelChanges{}
+}
+
+// Subscribe registers fn to be called after each write to the stored hotels.
+func (c *HotelChanges) Subscribe(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscribers = append(c.subscribers, fn)
+}
+
+// Notify calls the subscribers, after the transaction that changed hotels committed.
+func (c *HotelChanges) Notify() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, fn := range c.subscribers {
+		fn()
+	}
+}
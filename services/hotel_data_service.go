@@ -0,0 +1,372 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services/amenities"
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/services/images"
+	"github.com/duylamasd/hotels-merge/services/policies"
+	"github.com/duylamasd/hotels-merge/services/quality"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// maxRecordSize bounds a single NDJSON line.
+const maxRecordSize = 4 << 20
+
+const importStagingTable = "hotels_import"
+
+// The staging table lives for the import transaction only, so concurrent imports do not see each other.
+const createImportStagingQuery = `CREATE TEMPORARY TABLE hotels_import (
+  hotel_id TEXT NOT NULL,
+  destination_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  location JSONB,
+  description TEXT,
+  images JSONB,
+  amenities JSONB,
+  booking_conditions TEXT[],
+  policies JSONB,
+  country_code TEXT,
+  data_quality JSONB,
+  raw_text JSONB
+) ON COMMIT DROP`
+
+var importColumns = []string{
+	"hotel_id", "destination_id", "name", "location", "description", "images", "amenities", "booking_conditions", "policies", "country_code", "data_quality", "raw_text",
+}
+
+// mergeImportQuery upserts the staged hotels and returns whether each one was inserted rather than updated.
+const mergeImportQuery = `INSERT INTO hotels (hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, policies, country_code, data_quality, raw_text)
+SELECT hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, policies, country_code, data_quality, raw_text
+FROM hotels_import
+ON CONFLICT (hotel_id) DO UPDATE
+SET destination_id = EXCLUDED.destination_id,
+  name = EXCLUDED.name,
+  location = EXCLUDED.location,
+  description = EXCLUDED.description,
+  images = EXCLUDED.images,
+  amenities = EXCLUDED.amenities,
+  booking_conditions = EXCLUDED.booking_conditions,
+  policies = EXCLUDED.policies,
+  country_code = EXCLUDED.country_code,
+  data_quality = EXCLUDED.data_quality,
+  raw_text = EXCLUDED.raw_text,
+  updated_at = NOW()
+RETURNING xmax = 0`
+
+type hotelDataService struct {
+	logger  *zap.Logger
+	db      *config.DBStore
+	changes *HotelChanges
+}
+
+func NewHotelDataService(logger *zap.Logger, db *config.DBStore, changes *HotelChanges) domains.HotelDataService {
+	return &hotelDataService{
+		logger:  logger,
+		db:      db,
+		changes: changes,
+	}
+}
+
+func (s *hotelDataService) Export(ctx context.Context, w io.Writer) (int, error) {
+	hotels, err := s.db.Queries.ListHotels(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	for i, hotel := range hotels {
+		if err := encoder.Encode(hotelFromRow(hotel)); err != nil {
+			return i, err
+		}
+	}
+
+	s.logger.Info("Exported hotels", zap.Int("count", len(hotels)))
+	return len(hotels), nil
+}
+
+func (s *hotelDataService) Import(ctx context.Context, r io.Reader) (*domains.ImportResult, error) {
+	hotels, issues, err := ParseHotels(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domains.ImportResult{Errors: issues}
+	if len(issues) > 0 {
+		return result, nil
+	}
+
+	redirects, err := s.db.Queries.ListHotelRedirects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	canonical := make(map[string]string, len(redirects))
+	for _, redirect := range redirects {
+		canonical[redirect.HotelID] = redirect.CanonicalHotelID
+	}
+	for _, hotel := range hotels {
+		if canonicalHotelID, ok := canonical[hotel.HotelID]; ok {
+			result.Errors = append(result.Errors, domains.DataIssue{
+				HotelID: hotel.HotelID,
+				Field:   "hotel_id",
+				Message: "was merged into hotel " + canonicalHotelID + ", import it under that id",
+			})
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if s.db.InMemory() {
+		err = s.upsertHotels(ctx, hotels, result)
+	} else {
+		err = s.copyHotels(ctx, hotels, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.changes.Notify()
+
+	result.Imported = len(hotels)
+	s.logger.Info("Imported hotels", zap.Int("inserted", result.Inserted), zap.Int("updated", result.Updated))
+	return result, nil
+}
+
+// copyHotels copies hotels to a staging table and merges it into hotels.
+func (s *hotelDataService) copyHotels(ctx context.Context, hotels []sqlc.UpsertHotelParams, result *domains.ImportResult) error {
+	rows := make([][]any, len(hotels))
+	for i, hotel := range hotels {
+		rows[i] = []any{
+			hotel.HotelID, hotel.DestinationID, hotel.Name, hotel.Location, hotel.Description,
+			hotel.Images, hotel.Amenities, hotel.BookingConditions, hotel.Policies, hotel.CountryCode, hotel.DataQuality, hotel.RawText,
+		}
+	}
+
+	return s.db.WithTx(ctx, func(tx pgx.Tx) error {
+		result.Inserted, result.Updated = 0, 0
+
+		if _, err := tx.Exec(ctx, createImportStagingQuery); err != nil {
+			return fmt.Errorf("could not create the staging table: %w", err)
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{importStagingTable}, importColumns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("could not copy hotels to the staging table: %w", err)
+		}
+
+		merged, err := tx.Query(ctx, mergeImportQuery)
+		if err != nil {
+			return err
+		}
+		inserted, err := pgx.CollectRows(merged, pgx.RowTo[bool])
+		if err != nil {
+			return fmt.Errorf("could not merge the staging table: %w", err)
+		}
+
+		for _, isInsert := range inserted {
+			if isInsert {
+				result.Inserted++
+			} else {
+				result.Updated++
+			}
+		}
+		return sqlc.New(tx).InsertMissingDestinations(ctx)
+	})
+}
+
+// upsertHotels upserts hotels one by one, for the memory backend which has no COPY.
+func (s *hotelDataService) upsertHotels(ctx context.Context, hotels []sqlc.UpsertHotelParams, result *domains.ImportResult) error {
+	return s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		result.Inserted, result.Updated = 0, 0
+
+		for _, hotel := range hotels {
+			_, err := queries.FindHotelByHotelID(ctx, hotel.HotelID)
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				result.Inserted++
+			case err != nil:
+				return err
+			default:
+				result.Updated++
+			}
+
+			if err := queries.UpsertHotel(ctx, hotel); err != nil {
+				return err
+			}
+		}
+		return queries.InsertMissingDestinations(ctx)
+	})
+}
+
+func (s *hotelDataService) Validate(ctx context.Context) ([]domains.DataIssue, error) {
+	hotels, err := s.db.Queries.ListHotels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []domains.DataIssue{}
+	for _, hotel := range hotels {
+		issues = append(issues, ValidateHotel(hotelFromRow(hotel), 0)...)
+	}
+
+	return issues, nil
+}
+
+func (s *hotelDataService) Quality(ctx context.Context) (*domains.QualityReport, error) {
+	hotels, err := s.db.Reader().ListHotels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cleanHotels(hotels...)
+
+	report := &domains.QualityReport{
+		Overall:      newQualityStats(),
+		Destinations: map[string]*domains.QualityStats{},
+		Suppliers:    map[string]*domains.QualityStats{},
+	}
+	group := func(groups map[string]*domains.QualityStats, key string) *domains.QualityStats {
+		if groups[key] == nil {
+			stats := newQualityStats()
+			groups[key] = &stats
+		}
+		return groups[key]
+	}
+
+	for _, hotel := range hotels {
+		dataQuality := hotel.DataQuality
+		addQuality(&report.Overall, dataQuality)
+		addQuality(group(report.Destinations, hotel.DestinationID), dataQuality)
+
+		suppliers := dataQuality.Suppliers
+		if len(suppliers) == 0 {
+			suppliers = []string{domains.UnknownSupplier}
+		}
+		for _, supplier := range suppliers {
+			addQuality(group(report.Suppliers, supplier), dataQuality)
+		}
+	}
+
+	finishQuality(&report.Overall)
+	for _, stats := range report.Destinations {
+		finishQuality(stats)
+	}
+	for _, stats := range report.Suppliers {
+		finishQuality(stats)
+	}
+
+	return report, nil
+}
+
+func newQualityStats() domains.QualityStats {
+	return domains.QualityStats{Missing: map[string]int{}, Locations: map[string]int{}}
+}
+
+// addQuality counts a hotel in stats, summing its score into AverageScore until finishQuality divides it.
+func addQuality(stats *domains.QualityStats, dataQuality *dto.DataQuality) {
+	score := *dataQuality.Score
+	if stats.Hotels == 0 || score < stats.MinScore {
+		stats.MinScore = score
+	}
+	if score > stats.MaxScore {
+		stats.MaxScore = score
+	}
+	stats.Hotels++
+	stats.AverageScore += float64(score)
+	for _, field := range dataQuality.Missing {
+		stats.Missing[field]++
+	}
+	stats.Locations[dataQuality.Location.Status]++
+}
+
+func finishQuality(stats *domains.QualityStats) {
+	if stats.Hotels > 0 {
+		stats.AverageScore = math.Round(stats.AverageScore/float64(stats.Hotels)*10) / 10
+	}
+}
+
+// importRecord accepts hotels as served by the API, whose generated fields are ignored.
+type importRecord struct {
+	sqlc.UpsertHotelParams
+	ID        json.RawMessage `json:"id"`
+	CreatedAt json.RawMessage `json:"created_at"`
+	UpdatedAt json.RawMessage `json:"updated_at"`
+}
+
+// ParseHotels decodes and validates NDJSON hotels. Blank lines are skipped, and a hotel id repeated on a later
+// line is reported there. The error is only set when r cannot be read.
+func ParseHotels(r io.Reader) ([]sqlc.UpsertHotelParams, []domains.DataIssue, error) {
+	hotels := []sqlc.UpsertHotelParams{}
+	issues := []domains.DataIssue{}
+	seen := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var record importRecord
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			issues = append(issues, domains.DataIssue{Line: line, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		hotel := record.UpsertHotelParams
+
+		hotelIssues := ValidateHotel(hotel, line)
+		if first, ok := seen[hotel.HotelID]; ok && hotel.HotelID != "" {
+			hotelIssues = append(hotelIssues, domains.DataIssue{
+				HotelID: hotel.HotelID,
+				Line:    line,
+				Field:   "hotel_id",
+				Message: "duplicates line " + strconv.Itoa(first),
+			})
+		} else {
+			seen[hotel.HotelID] = line
+		}
+
+		if len(hotelIssues) > 0 {
+			issues = append(issues, hotelIssues...)
+			continue
+		}
+		hotel.Images = images.Normalize(hotel.Images)
+		hotel.Amenities = amenities.Default().Map(hotel.Amenities)
+		rawText := hotel.RawText
+		hotel.Description, hotel.BookingConditions, hotel.RawText = sanitize.Clean(hotel.Description, hotel.BookingConditions)
+		if rawText != nil {
+			hotel.RawText = rawText
+		}
+		hotel.Policies = policies.Parse(sanitize.Texts(hotel.BookingConditions))
+		location, countryCode := geo.Normalize(hotel.Location)
+		if countryCode != nil {
+			hotel.CountryCode = countryCode
+		}
+		location, locationQuality := geo.Check(location, hotel.CountryCode)
+		hotel.Location = location
+		var suppliers []string
+		if hotel.DataQuality != nil {
+			suppliers = hotel.DataQuality.Suppliers
+		}
+		hotel.DataQuality = &dto.DataQuality{Location: locationQuality, Suppliers: suppliers}
+		hotel.DataQuality = quality.Score(hotel)
+		hotels = append(hotels, hotel)
+	}
+
+	return hotels, issues, scanner.Err()
+}
@@ -2,32 +2,63 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/duylamasd/hotels-merge/config"
 	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services/amenities"
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/services/images"
+	"github.com/duylamasd/hotels-merge/services/policies"
+	"github.com/duylamasd/hotels-merge/services/quality"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
 	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
 	"go.uber.org/zap"
 )
 
 type hotelService struct {
-	logger *zap.Logger
-	db     *config.DBStore
+	logger       *zap.Logger
+	db           *config.DBStore
+	queryTimeout time.Duration
 }
 
-func NewHotelService(logger *zap.Logger, db *config.DBStore) domains.HotelService {
+func NewHotelService(logger *zap.Logger, config *config.Config, db *config.DBStore) domains.HotelService {
 	return &hotelService{
-		logger: logger,
-		db:     db,
+		logger:       logger,
+		db:           db,
+		queryTimeout: config.Database.QueryTimeout,
 	}
 }
 
 func (s *hotelService) FindByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
-	return s.db.Queries.FindHotelByHotelID(ctx, hotelID)
+	logger := lib.LoggerFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	logger.Debug("Querying hotel by hotel id", zap.String("hotel_id", hotelID))
+
+	hotel, err := s.db.Reader().FindHotelByHotelID(ctx, hotelID)
+	if err != nil {
+		logger.Debug("Could not query hotel by hotel id", zap.String("hotel_id", hotelID), zap.Error(err))
+		return nil, err
+	}
+
+	cleanHotels(hotel)
+	return hotel, nil
 }
 
 func (s *hotelService) FindByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
-	hotels, err := s.db.Queries.FindHotelsByDestinationID(ctx, destinationID)
+	logger := lib.LoggerFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	logger.Debug("Querying hotels by destination id", zap.String("destination_id", destinationID))
+
+	hotels, err := s.db.Reader().FindHotelsByDestinationID(ctx, destinationID)
 	if err != nil {
+		logger.Error("Could not query hotels by destination id", zap.String("destination_id", destinationID), zap.Error(err))
 		return nil, err
 	}
 
@@ -35,12 +66,20 @@ func (s *hotelService) FindByDestinationID(ctx context.Context, destinationID st
 		return []*sqlc.Hotel{}, nil
 	}
 
+	cleanHotels(hotels...)
 	return hotels, nil
 }
 
 func (s *hotelService) FindByHotelIDs(ctx context.Context, hotelIDs []string) ([]*sqlc.Hotel, error) {
-	hotels, err := s.db.Queries.FindHotelsByHotelIDs(ctx, hotelIDs)
+	logger := lib.LoggerFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	logger.Debug("Querying hotels by hotel ids", zap.Strings("hotel_ids", hotelIDs))
+
+	hotels, err := s.db.Reader().FindHotelsByHotelIDs(ctx, hotelIDs)
 	if err != nil {
+		logger.Error("Could not query hotels by hotel ids", zap.Strings("hotel_ids", hotelIDs), zap.Error(err))
 		return nil, err
 	}
 
@@ -48,15 +87,44 @@ func (s *hotelService) FindByHotelIDs(ctx context.Context, hotelIDs []string) ([
 		return []*sqlc.Hotel{}, nil
 	}
 
+	cleanHotels(hotels...)
 	return hotels, nil
 }
 
 func (s *hotelService) FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error) {
-	hotels, err := s.db.Queries.FindHotelsByDestinationAndHotelIDs(ctx, sqlc.FindHotelsByDestinationAndHotelIDsParams{
+	logger := lib.LoggerFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	logger.Debug("Querying hotels by destination id and hotel ids", zap.String("destination_id", destinationID), zap.Strings("hotel_ids", hotelIDs))
+
+	hotels, err := s.db.Reader().FindHotelsByDestinationAndHotelIDs(ctx, sqlc.FindHotelsByDestinationAndHotelIDsParams{
 		DestinationID: destinationID,
 		HotelIds:      hotelIDs,
 	})
 	if err != nil {
+		logger.Error("Could not query hotels by destination id and hotel ids", zap.String("destination_id", destinationID), zap.Strings("hotel_ids", hotelIDs), zap.Error(err))
+		return nil, err
+	}
+
+	if hotels == nil {
+		return []*sqlc.Hotel{}, nil
+	}
+
+	cleanHotels(hotels...)
+	return hotels, nil
+}
+
+func (s *hotelService) FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	logger := lib.LoggerFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	logger.Debug("Querying hotels by country code", zap.String("country_code", countryCode))
+
+	hotels, err := s.db.Reader().FindHotelsByCountryCode(ctx, countryCode)
+	if err != nil {
+		logger.Error("Could not query hotels by country code", zap.String("country_code", countryCode), zap.Error(err))
 		return nil, err
 	}
 
@@ -64,5 +132,37 @@ func (s *hotelService) FindByDestinationAndHotelIDs(ctx context.Context, destina
 		return []*sqlc.Hotel{}, nil
 	}
 
+	cleanHotels(hotels...)
 	return hotels, nil
 }
+
+// cleanHotels normalizes images, amenities and locations at read time, as hotels stored before
+// normalization or before the current amenity taxonomy may still hold duplicates or outdated codes.
+// Hotels stored before policies were parsed, countries were coded, locations were checked or quality
+// was scored get them from their booking conditions, location and fields.
+func cleanHotels(hotels ...*sqlc.Hotel) {
+	taxonomy := amenities.Default()
+	for _, hotel := range hotels {
+		hotel.Images = images.Normalize(hotel.Images)
+		hotel.Amenities = taxonomy.Normalize(hotel.Amenities)
+		if hotel.RawText == nil {
+			hotel.Description, hotel.BookingConditions, hotel.RawText = sanitize.Clean(hotel.Description, hotel.BookingConditions)
+		}
+		if hotel.Policies == nil {
+			hotel.Policies = policies.Parse(sanitize.Texts(hotel.BookingConditions))
+		}
+		location, countryCode := geo.Normalize(hotel.Location)
+		hotel.Location = location
+		if hotel.CountryCode == nil {
+			hotel.CountryCode = countryCode
+		}
+		if hotel.DataQuality == nil {
+			location, locationQuality := geo.Check(hotel.Location, hotel.CountryCode)
+			hotel.Location = location
+			hotel.DataQuality = &dto.DataQuality{Location: locationQuality}
+		}
+		if hotel.DataQuality.Score == nil {
+			hotel.DataQuality = quality.Score(hotelFromRow(hotel))
+		}
+	}
+}
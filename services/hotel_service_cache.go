@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+type hotelCacheEntry struct {
+	hotels    []*sqlc.Hotel
+	expiresAt time.Time
+}
+
+// HotelServiceCache caches successful HotelService lookups in memory for a TTL that can be updated at runtime.
+type HotelServiceCache struct {
+	next       domains.HotelService
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]hotelCacheEntry
+	now        func() time.Time
+}
+
+func NewHotelServiceCache(next domains.HotelService, ttl time.Duration, maxEntries int) *HotelServiceCache {
+	return &HotelServiceCache{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[string]hotelCacheEntry{},
+		now:        time.Now,
+	}
+}
+
+// SetTTL applies to entries cached from now on.
+func (s *HotelServiceCache) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ttl = ttl
+}
+
+// Clear drops every cached entry, so lookups see the hotels written since they were cached.
+func (s *HotelServiceCache) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.entries)
+}
+
+func (s *HotelServiceCache) get(key string) ([]*sqlc.Hotel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false
+	}
+
+	return entry.hotels, true
+}
+
+func (s *HotelServiceCache) set(key string, hotels []*sqlc.Hotel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.maxEntries {
+		s.evict(now)
+	}
+
+	s.entries[key] = hotelCacheEntry{hotels: hotels, expiresAt: now.Add(s.ttl)}
+}
+
+// evict drops expired entries, or the entry closest to expiry when none has expired.
+func (s *HotelServiceCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+
+	if len(s.entries) >= s.maxEntries {
+		delete(s.entries, oldestKey)
+	}
+}
+
+func (s *HotelServiceCache) lookup(key string, find func() ([]*sqlc.Hotel, error)) ([]*sqlc.Hotel, error) {
+	if hotels, ok := s.get(key); ok {
+		return hotels, nil
+	}
+
+	hotels, err := find()
+	if err == nil {
+		s.set(key, hotels)
+	}
+
+	return hotels, err
+}
+
+func cacheKey(method string, args ...string) string {
+	return method + "\x00" + strings.Join(args, "\x00")
+}
+
+func (s *HotelServiceCache) FindByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
+	hotels, err := s.lookup(cacheKey("FindByHotelID", hotelID), func() ([]*sqlc.Hotel, error) {
+		hotel, err := s.next.FindByHotelID(ctx, hotelID)
+		if err != nil || hotel == nil {
+			return nil, err
+		}
+		return []*sqlc.Hotel{hotel}, nil
+	})
+	if err != nil || len(hotels) == 0 {
+		return nil, err
+	}
+
+	return hotels[0], nil
+}
+
+func (s *HotelServiceCache) FindByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+	return s.lookup(cacheKey("FindByDestinationID", destinationID), func() ([]*sqlc.Hotel, error) {
+		return s.next.FindByDestinationID(ctx, destinationID)
+	})
+}
+
+func (s *HotelServiceCache) FindByHotelIDs(ctx context.Context, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	return s.lookup(cacheKey("FindByHotelIDs", hotelIDs...), func() ([]*sqlc.Hotel, error) {
+		return s.next.FindByHotelIDs(ctx, hotelIDs)
+	})
+}
+
+func (s *HotelServiceCache) FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	return s.lookup(cacheKey("FindByDestinationAndHotelIDs", append([]string{destinationID}, hotelIDs...)...), func() ([]*sqlc.Hotel, error) {
+		return s.next.FindByDestinationAndHotelIDs(ctx, destinationID, hotelIDs)
+	})
+}
+
+func (s *HotelServiceCache) FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	return s.lookup(cacheKey("FindByCountryCode", countryCode), func() ([]*sqlc.Hotel, error) {
+		return s.next.FindByCountryCode(ctx, countryCode)
+	})
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/sqlc"
+)
+
+const hotelServiceName = "hotel"
+
+type hotelServiceMetrics struct {
+	next    domains.HotelService
+	metrics *lib.Metrics
+}
+
+func NewHotelServiceMetrics(next domains.HotelService, metrics *lib.Metrics) domains.HotelService {
+	return &hotelServiceMetrics{
+		next:    next,
+		metrics: metrics,
+	}
+}
+
+func (s *hotelServiceMetrics) observe(method string, start time.Time, err error) {
+	s.metrics.ServiceCallDuration.WithLabelValues(hotelServiceName, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		s.metrics.ServiceErrorsTotal.WithLabelValues(hotelServiceName, method).Inc()
+	}
+}
+
+func (s *hotelServiceMetrics) observeResultSize(method string, hotels []*sqlc.Hotel, err error) {
+	if err == nil {
+		s.metrics.HotelsResultSize.WithLabelValues(method).Observe(float64(len(hotels)))
+	}
+}
+
+func (s *hotelServiceMetrics) FindByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
+	start := time.Now()
+	hotel, err := s.next.FindByHotelID(ctx, hotelID)
+	s.observe("FindByHotelID", start, err)
+
+	return hotel, err
+}
+
+func (s *hotelServiceMetrics) FindByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+	start := time.Now()
+	hotels, err := s.next.FindByDestinationID(ctx, destinationID)
+	s.observe("FindByDestinationID", start, err)
+	s.observeResultSize("FindByDestinationID", hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceMetrics) FindByHotelIDs(ctx context.Context, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	start := time.Now()
+	hotels, err := s.next.FindByHotelIDs(ctx, hotelIDs)
+	s.observe("FindByHotelIDs", start, err)
+	s.observeResultSize("FindByHotelIDs", hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceMetrics) FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	start := time.Now()
+	hotels, err := s.next.FindByDestinationAndHotelIDs(ctx, destinationID, hotelIDs)
+	s.observe("FindByDestinationAndHotelIDs", start, err)
+	s.observeResultSize("FindByDestinationAndHotelIDs", hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceMetrics) FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	start := time.Now()
+	hotels, err := s.next.FindByCountryCode(ctx, countryCode)
+	s.observe("FindByCountryCode", start, err)
+	s.observeResultSize("FindByCountryCode", hotels, err)
+
+	return hotels, err
+}
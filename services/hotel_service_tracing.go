@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceTracerName = "github.com/duylamasd/hotels-merge/services"
+
+type hotelServiceTracing struct {
+	next   domains.HotelService
+	tracer trace.Tracer
+}
+
+func NewHotelServiceTracing(next domains.HotelService, tracerProvider trace.TracerProvider) domains.HotelService {
+	return &hotelServiceTracing{
+		next:   next,
+		tracer: tracerProvider.Tracer(serviceTracerName),
+	}
+}
+
+func (s *hotelServiceTracing) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "HotelService."+method, trace.WithAttributes(attrs...))
+}
+
+func (s *hotelServiceTracing) end(span trace.Span, hotels []*sqlc.Hotel, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int("hotels.count", len(hotels)))
+}
+
+func (s *hotelServiceTracing) FindByHotelID(ctx context.Context, hotelID string) (*sqlc.Hotel, error) {
+	ctx, span := s.start(ctx, "FindByHotelID", attribute.String("hotel.id", hotelID))
+	hotel, err := s.next.FindByHotelID(ctx, hotelID)
+
+	var hotels []*sqlc.Hotel
+	if hotel != nil {
+		hotels = append(hotels, hotel)
+	}
+	s.end(span, hotels, err)
+
+	return hotel, err
+}
+
+func (s *hotelServiceTracing) FindByDestinationID(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+	ctx, span := s.start(ctx, "FindByDestinationID", attribute.String("destination.id", destinationID))
+	hotels, err := s.next.FindByDestinationID(ctx, destinationID)
+	s.end(span, hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceTracing) FindByHotelIDs(ctx context.Context, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	ctx, span := s.start(ctx, "FindByHotelIDs", attribute.StringSlice("hotel.ids", hotelIDs))
+	hotels, err := s.next.FindByHotelIDs(ctx, hotelIDs)
+	s.end(span, hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceTracing) FindByDestinationAndHotelIDs(ctx context.Context, destinationID string, hotelIDs []string) ([]*sqlc.Hotel, error) {
+	ctx, span := s.start(ctx, "FindByDestinationAndHotelIDs",
+		attribute.String("destination.id", destinationID),
+		attribute.StringSlice("hotel.ids", hotelIDs),
+	)
+	hotels, err := s.next.FindByDestinationAndHotelIDs(ctx, destinationID, hotelIDs)
+	s.end(span, hotels, err)
+
+	return hotels, err
+}
+
+func (s *hotelServiceTracing) FindByCountryCode(ctx context.Context, countryCode string) ([]*sqlc.Hotel, error) {
+	ctx, span := s.start(ctx, "FindByCountryCode", attribute.String("country.code", countryCode))
+	hotels, err := s.next.FindByCountryCode(ctx, countryCode)
+	s.end(span, hotels, err)
+
+	return hotels, err
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+// ValidateHotel returns the issues that prevent storing hotel. Line is copied to every issue.
+func ValidateHotel(hotel sqlc.UpsertHotelParams, line int) []domains.DataIssue {
+	var issues []domains.DataIssue
+	invalid := func(field, message string) {
+		issues = append(issues, domains.DataIssue{HotelID: hotel.HotelID, Line: line, Field: field, Message: message})
+	}
+
+	if hotel.HotelID == "" {
+		invalid("hotel_id", "is required")
+	}
+	if hotel.DestinationID == "" {
+		invalid("destination_id", "is required")
+	}
+	if hotel.Name == "" {
+		invalid("name", "is required")
+	}
+
+	if location := hotel.Location; location != nil {
+		if (location.Latitude == nil) != (location.Longitude == nil) {
+			invalid("location", "latitude and longitude must be set together")
+		}
+		if location.Latitude != nil && (*location.Latitude < -90 || *location.Latitude > 90) {
+			invalid("location.latitude", "must be between -90 and 90")
+		}
+		if location.Longitude != nil && (*location.Longitude < -180 || *location.Longitude > 180) {
+			invalid("location.longitude", "must be between -180 and 180")
+		}
+	}
+
+	if hotel.CountryCode != nil {
+		if _, ok := geo.CountryName(*hotel.CountryCode); !ok || *hotel.CountryCode != strings.ToUpper(*hotel.CountryCode) {
+			invalid("country_code", fmt.Sprintf("%q is not an ISO 3166-1 alpha-2 code", *hotel.CountryCode))
+		}
+	}
+
+	if images := hotel.Images; images != nil {
+		for _, group := range []struct {
+			field  string
+			images []dto.HotelImage
+		}{
+			{"images.rooms", images.Rooms},
+			{"images.site", images.Site},
+			{"images.amenities", images.Amenities},
+		} {
+			for _, image := range group.images {
+				if !isAbsoluteHTTPURL(image.Link) {
+					invalid(group.field, fmt.Sprintf("link %q is not an absolute http(s) URL", image.Link))
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// hotelFromRow converts a stored hotel to the record format of imports and exports.
+func hotelFromRow(hotel *sqlc.Hotel) sqlc.UpsertHotelParams {
+	return sqlc.UpsertHotelParams{
+		HotelID:           hotel.HotelID,
+		DestinationID:     hotel.DestinationID,
+		Name:              hotel.Name,
+		Location:          hotel.Location,
+		Description:       hotel.Description,
+		Images:            hotel.Images,
+		Amenities:         hotel.Amenities,
+		BookingConditions: hotel.BookingConditions,
+		Policies:          hotel.Policies,
+		CountryCode:       hotel.CountryCode,
+		DataQuality:       hotel.DataQuality,
+		RawText:           hotel.RawText,
+	}
+}
+
+func isAbsoluteHTTPURL(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
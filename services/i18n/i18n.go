@@ -0,0 +1,104 @@
+// Package i18n picks the locale of hotel content from the languages a client accepts and applies hotel translations.
+package i18n
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the language of supplier content, served when no translation matches.
+const DefaultLocale = "en"
+
+// Canonical returns the BCP 47 form of locale, such as "zh-Hant-TW" for "zh-hant-tw".
+func Canonical(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", err
+	}
+
+	return tag.String(), nil
+}
+
+// Chain returns the locales to try in order for lang, or for the Accept-Language header when lang is empty.
+// Each requested locale is followed by its shorter forms, so "zh-Hant-TW" falls back to "zh-Hant" then "zh",
+// and the chain ends with DefaultLocale. Invalid languages and wildcards are ignored.
+func Chain(lang, acceptLanguage string) []string {
+	var tags []language.Tag
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+		}
+	} else {
+		// Tags come sorted by quality, without those refused with q=0.
+		tags, _, _ = language.ParseAcceptLanguage(acceptLanguage)
+	}
+
+	chain := []string{}
+	for _, tag := range tags {
+		locale := tag.String()
+		if locale == "und" || locale == "mul" {
+			continue
+		}
+		for {
+			if locale == DefaultLocale {
+				return append(chain, DefaultLocale)
+			}
+			if !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+			i := strings.LastIndex(locale, "-")
+			if i < 0 {
+				break
+			}
+			locale = locale[:i]
+		}
+	}
+
+	return append(chain, DefaultLocale)
+}
+
+// Localize returns the hotel in the first locale of chain it has a translation for, and that locale.
+// Fields the translation leaves empty keep their content in DefaultLocale. The hotel is copied, as it may
+// be shared with the cache.
+func Localize(hotel *sqlc.Hotel, translations []*sqlc.HotelTranslation, chain []string) (*sqlc.Hotel, string) {
+	for _, locale := range chain {
+		if locale == DefaultLocale {
+			break
+		}
+		i := slices.IndexFunc(translations, func(translation *sqlc.HotelTranslation) bool {
+			return translation.Locale == locale
+		})
+		if i < 0 {
+			continue
+		}
+		translation := translations[i]
+
+		localized := *hotel
+		if translation.Name != nil {
+			localized.Name = *translation.Name
+		}
+		if translation.Description != nil {
+			localized.Description = translation.Description
+		}
+		if translation.BookingConditions != nil {
+			localized.BookingConditions = translation.BookingConditions
+		}
+		if hotel.Amenities != nil && len(translation.AmenityLabels) > 0 {
+			amenities := *hotel.Amenities
+			amenities.Canonical = slices.Clone(amenities.Canonical)
+			for i, amenity := range amenities.Canonical {
+				if label, ok := translation.AmenityLabels[amenity.Code]; ok {
+					amenities.Canonical[i].Label = label
+				}
+			}
+			localized.Amenities = &amenities
+		}
+
+		return &localized, locale
+	}
+
+	return hotel, DefaultLocale
+}
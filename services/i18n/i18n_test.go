@@ -0,0 +1,72 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/i18n"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func TestChain(t *testing.T) {
+	cases := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           []string
+	}{
+		{name: "should default without a language", want: []string{"en"}},
+		{name: "should fall back to shorter forms", acceptLanguage: "zh-hant-tw", want: []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{name: "should follow quality values", acceptLanguage: "fr;q=0, ko;q=0.5, ja-JP, *;q=0.1", want: []string{"ja-JP", "ja", "ko", "en"}},
+		{name: "should stop at the default locale", acceptLanguage: "en-SG, ja;q=0.8", want: []string{"en-SG", "en"}},
+		{name: "should prefer lang over the header", lang: "ko", acceptLanguage: "ja", want: []string{"ko", "en"}},
+		{name: "should ignore an invalid header", acceptLanguage: "not a language!", want: []string{"en"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, i18n.Chain(c.lang, c.acceptLanguage))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	hotel := &sqlc.Hotel{
+		HotelID:           "iJhz",
+		Name:              "Beach Villas Singapore",
+		Description:       pointer("Surrounded by tropical gardens"),
+		BookingConditions: []string{"Pets are not allowed."},
+		Amenities: &dto.HotelAmenities{Canonical: []dto.HotelAmenity{
+			{Code: "outdoor_pool", Label: "Outdoor pool", Category: "wellness"},
+			{Code: "tv", Label: "TV", Category: "room"},
+		}},
+	}
+	translations := []*sqlc.HotelTranslation{
+		{HotelID: "iJhz", Locale: "ja", Name: pointer("ビーチヴィラ シンガポール"), AmenityLabels: dto.AmenityLabels{"outdoor_pool": "屋外プール"}},
+		{HotelID: "iJhz", Locale: "zh", Description: pointer("被热带花园环绕")},
+	}
+
+	t.Run("should apply the first translation of the chain", func(t *testing.T) {
+		localized, locale := i18n.Localize(hotel, translations, []string{"ko", "ja-JP", "ja", "en"})
+
+		assert.Equal(t, "ja", locale)
+		assert.Equal(t, "ビーチヴィラ シンガポール", localized.Name)
+		assert.Equal(t, "Surrounded by tropical gardens", *localized.Description, "untranslated fields should fall back")
+		assert.Equal(t, []string{"Pets are not allowed."}, localized.BookingConditions)
+		assert.Equal(t, "屋外プール", localized.Amenities.Canonical[0].Label)
+		assert.Equal(t, "TV", localized.Amenities.Canonical[1].Label)
+		assert.Equal(t, "Outdoor pool", hotel.Amenities.Canonical[0].Label, "the hotel should not be changed")
+	})
+
+	t.Run("should serve the default locale without a matching translation", func(t *testing.T) {
+		localized, locale := i18n.Localize(hotel, translations, []string{"en", "zh"})
+
+		assert.Equal(t, "en", locale)
+		assert.Same(t, hotel, localized)
+	})
+}
@@ -0,0 +1,153 @@
+// Package images cleans hotel images: links are canonicalized and every image is kept once across categories.
+package images
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+var (
+	// genericDescriptions say nothing about the image, so any other description is preferred.
+	genericDescriptions = map[string]bool{
+		"image":      true,
+		"img":        true,
+		"photo":      true,
+		"picture":    true,
+		"pic":        true,
+		"untitled":   true,
+		"no caption": true,
+	}
+	percentEncoding = regexp.MustCompile(`%[0-9a-fA-F]{2}`)
+	fileNamePattern = regexp.MustCompile(`(?i)^[\w.-]+\.(jpe?g|png|gif|webp|avif|bmp)$`)
+)
+
+// Canonicalize returns the canonical form of an absolute http(s) link: lowercase scheme and host, no default
+// port, fragment or tracking parameters, a cleaned path and sorted query parameters. Protocol relative links
+// become https. Other links are only trimmed.
+func Canonicalize(link string) string {
+	link = strings.TrimSpace(link)
+	if strings.HasPrefix(link, "//") {
+		link = "https:" + link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return link
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	u.Fragment, u.RawFragment = "", ""
+
+	// The escaped path is cleaned, so an encoded slash stays part of a segment.
+	if escaped := u.EscapedPath(); escaped != "" {
+		cleaned := path.Clean(percentEncoding.ReplaceAllStringFunc(escaped, strings.ToUpper))
+		if strings.HasSuffix(escaped, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+		if unescaped, err := url.PathUnescape(cleaned); err == nil {
+			u.Path, u.RawPath = unescaped, cleaned
+		}
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery, u.ForceQuery = query.Encode(), false
+
+	return u.String()
+}
+
+// Normalize canonicalizes the links of images and keeps each image once, in the first category it appears
+// in, ordered rooms, site then amenities. Duplicates are merged keeping the best description, and an https
+// link over an http one. Empty links are dropped.
+func Normalize(images *dto.HotelImages) *dto.HotelImages {
+	if images == nil {
+		return nil
+	}
+
+	normalized := &dto.HotelImages{
+		Rooms:     []dto.HotelImage{},
+		Site:      []dto.HotelImage{},
+		Amenities: []dto.HotelImage{},
+	}
+
+	type position struct {
+		category *[]dto.HotelImage
+		index    int
+	}
+	seen := map[string]position{}
+	for _, group := range []struct {
+		from []dto.HotelImage
+		to   *[]dto.HotelImage
+	}{
+		{images.Rooms, &normalized.Rooms},
+		{images.Site, &normalized.Site},
+		{images.Amenities, &normalized.Amenities},
+	} {
+		for _, image := range group.from {
+			link := Canonicalize(image.Link)
+			if link == "" {
+				continue
+			}
+			description := strings.Join(strings.Fields(image.Description), " ")
+
+			key := identity(link)
+			if pos, ok := seen[key]; ok {
+				existing := &(*pos.category)[pos.index]
+				if strings.HasPrefix(link, "https:") {
+					existing.Link = link
+				}
+				if betterDescription(description, existing.Description) {
+					existing.Description = description
+				}
+				continue
+			}
+
+			seen[key] = position{category: group.to, index: len(*group.to)}
+			*group.to = append(*group.to, dto.HotelImage{Link: link, Description: description})
+		}
+	}
+
+	return normalized
+}
+
+// identity is the link without its scheme, so the http and https links of an image are duplicates.
+func identity(link string) string {
+	if _, rest, ok := strings.Cut(link, "://"); ok {
+		return rest
+	}
+
+	return link
+}
+
+// betterDescription reports whether candidate describes an image better than current: a meaningful
+// description beats a generic one, which beats none, and a longer one wins among meaningful ones.
+func betterDescription(candidate string, current string) bool {
+	candidateRank, currentRank := descriptionRank(candidate), descriptionRank(current)
+	if candidateRank != currentRank {
+		return candidateRank > currentRank
+	}
+
+	return candidateRank == 2 && len(candidate) > len(current)
+}
+
+func descriptionRank(description string) int {
+	switch {
+	case description == "":
+		return 0
+	case genericDescriptions[strings.ToLower(description)], fileNamePattern.MatchString(description):
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,68 @@
+package images_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/images"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{" https://example.com/a.jpg ", "https://example.com/a.jpg"},
+		{"HTTPS://Example.COM:443/a.jpg", "https://example.com/a.jpg"},
+		{"http://example.com:80/rooms//./a.jpg", "http://example.com/rooms/a.jpg"},
+		{"https://example.com:8443/a.jpg", "https://example.com:8443/a.jpg"},
+		{"//example.com/a.jpg", "https://example.com/a.jpg"},
+		{"https://example.com/a.jpg?w=200&utm_source=mail&h=100#top", "https://example.com/a.jpg?h=100&w=200"},
+		{"https://example.com/a%2fb.jpg", "https://example.com/a%2Fb.jpg"},
+		{"rooms/a.jpg", "rooms/a.jpg"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			assert.Equal(t, tt.want, images.Canonicalize(tt.link))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("should keep images once across categories with the best description", func(t *testing.T) {
+		normalized := images.Normalize(&dto.HotelImages{
+			Rooms: []dto.HotelImage{
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room"},
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/4.jpg", Description: "4.jpg"},
+				{Link: "https://D2EY9SQRVKQDFS.cloudfront.net/0qZF/2.jpg", Description: "Double  room with a view"},
+				{Link: " ", Description: "Nothing"},
+			},
+			Site: []dto.HotelImage{
+				{Link: "http://d2ey9sqrvkqdfs.cloudfront.net/0qZF/1.jpg", Description: "Front"},
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: ""},
+			},
+			Amenities: []dto.HotelImage{
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/4.jpg", Description: "Bathroom"},
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/1.jpg", Description: "Photo"},
+			},
+		})
+
+		assert.Equal(t, &dto.HotelImages{
+			Rooms: []dto.HotelImage{
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room with a view"},
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/4.jpg", Description: "Bathroom"},
+			},
+			Site: []dto.HotelImage{
+				{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/1.jpg", Description: "Front"},
+			},
+			Amenities: []dto.HotelImage{},
+		}, normalized)
+	})
+
+	t.Run("should keep nil images nil", func(t *testing.T) {
+		assert.Nil(t, images.Normalize(nil))
+	})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services/suppliers"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"go.uber.org/zap"
+)
+
+type ingestService struct {
+	logger  *zap.Logger
+	db      *config.DBStore
+	config  config.IngestConfig
+	client  *http.Client
+	changes *HotelChanges
+}
+
+func NewIngestService(logger *zap.Logger, cfg *config.Config, db *config.DBStore, changes *HotelChanges) domains.IngestService {
+	return &ingestService{
+		logger:  logger,
+		db:      db,
+		config:  cfg.Ingest,
+		client:  &http.Client{Timeout: cfg.Ingest.Timeout},
+		changes: changes,
+	}
+}
+
+// Ingest upserts the merged data of every supplier in one transaction, keeping the ids of stored hotels.
+// Stored hotels merged from suppliers that none of them returns anymore are removed, while hotels without
+// suppliers, such as imported ones, are kept. Merged hotels failing validation, or whose hotel id was merged
+// into another hotel, are skipped and reported.
+func (s *ingestService) Ingest(ctx context.Context) (*domains.IngestResult, error) {
+	urls := []struct{ supplier, url, key string }{
+		{suppliers.Acme, s.config.AcmeURL, "ingest.acme_url"},
+		{suppliers.Patagonia, s.config.PatagoniaURL, "ingest.patagonia_url"},
+		{suppliers.Paperflies, s.config.PaperfliesURL, "ingest.paperflies_url"},
+	}
+
+	// Merging a subset of the suppliers would overwrite hotels with part of their data.
+	var missing []string
+	for _, source := range urls {
+		if source.url == "" {
+			missing = append(missing, source.key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("every supplier is needed, set %s", strings.Join(missing, ", "))
+	}
+
+	result := &domains.IngestResult{Fetched: map[string]int{}, Skipped: []domains.DataIssue{}}
+	var data suppliers.Data
+	for _, source := range urls {
+		body, err := suppliers.Fetch(ctx, s.client, source.url)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch %s hotels: %w", source.supplier, err)
+		}
+
+		count, err := data.Parse(source.supplier, body)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s hotels: %w", source.supplier, err)
+		}
+
+		result.Fetched[source.supplier] = count
+		s.logger.Info("Fetched supplier hotels", zap.String("supplier", source.supplier), zap.Int("count", count))
+	}
+
+	result.UnmappedAmenities = data.UnmappedAmenities()
+	if len(result.UnmappedAmenities) > 0 {
+		s.logger.Warn("Supplier amenities missing from the taxonomy", zap.Any("terms", result.UnmappedAmenities))
+	}
+
+	// fetched holds every merged hotel id, so stored hotels failing validation this time are kept as they are.
+	fetched := map[string]bool{}
+	var hotels []sqlc.UpsertHotelParams
+	for _, hotel := range data.Merge() {
+		fetched[hotel.HotelID] = true
+		if issues := ValidateHotel(hotel, 0); len(issues) > 0 {
+			result.Skipped = append(result.Skipped, issues...)
+			continue
+		}
+		hotels = append(hotels, hotel)
+	}
+
+	var merged []domains.DataIssue
+	var removed int
+	err := s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		redirects, err := queries.ListHotelRedirects(ctx)
+		if err != nil {
+			return err
+		}
+		canonical := make(map[string]string, len(redirects))
+		for _, redirect := range redirects {
+			canonical[redirect.HotelID] = redirect.CanonicalHotelID
+		}
+
+		stored, err := queries.ListHotels(ctx)
+		if err != nil {
+			return err
+		}
+		removed = 0
+		for _, hotel := range stored {
+			if fetched[hotel.HotelID] || hotel.DataQuality == nil || len(hotel.DataQuality.Suppliers) == 0 {
+				continue
+			}
+			if err := queries.DeleteHotel(ctx, hotel.HotelID); err != nil {
+				return err
+			}
+			removed++
+		}
+
+		merged = nil
+		for _, hotel := range hotels {
+			if canonicalHotelID, ok := canonical[hotel.HotelID]; ok {
+				merged = append(merged, domains.DataIssue{
+					HotelID: hotel.HotelID,
+					Field:   "hotel_id",
+					Message: "was merged into hotel " + canonicalHotelID,
+				})
+				continue
+			}
+			if err := queries.UpsertHotel(ctx, hotel); err != nil {
+				return err
+			}
+		}
+		return queries.InsertMissingDestinations(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.changes.Notify()
+
+	result.Skipped = append(result.Skipped, merged...)
+	result.Stored = len(hotels) - len(merged)
+	result.Removed = removed
+	s.logger.Info("Ingested hotels", zap.Int("stored", result.Stored), zap.Int("removed", result.Removed), zap.Int("skipped", len(result.Skipped)))
+	return result, nil
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// migrationLockID serializes migrators across processes with a session advisory lock.
+const migrationLockID = 7223390134
+
+// revisionTypeExecute marks revisions applied by executing the file, as Atlas does.
+const revisionTypeExecute = 2
+
+// The revisions table is shared with the Atlas CLI, so both can migrate the same database.
+const createRevisionsTableQuery = `CREATE SCHEMA IF NOT EXISTS "atlas_schema_revisions";
+CREATE TABLE IF NOT EXISTS "atlas_schema_revisions"."atlas_schema_revisions" (
+  "version" character varying NOT NULL,
+  "description" character varying NOT NULL,
+  "type" bigint NOT NULL DEFAULT 2,
+  "applied" bigint NOT NULL DEFAULT 0,
+  "total" bigint NOT NULL DEFAULT 0,
+  "executed_at" timestamptz NOT NULL,
+  "execution_time" bigint NOT NULL,
+  "error" text NULL,
+  "error_stmt" text NULL,
+  "hash" character varying NOT NULL,
+  "partial_hashes" jsonb NULL,
+  "operator_version" character varying NOT NULL,
+  PRIMARY KEY ("version")
+)`
+
+const listRevisionsQuery = `SELECT version, description, applied, total, executed_at, COALESCE(error, ''), hash
+FROM atlas_schema_revisions.atlas_schema_revisions
+ORDER BY version`
+
+const insertRevisionQuery = `INSERT INTO atlas_schema_revisions.atlas_schema_revisions
+  (version, description, type, applied, total, executed_at, execution_time, hash, operator_version)
+VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8)`
+
+const deleteRevisionQuery = `DELETE FROM atlas_schema_revisions.atlas_schema_revisions WHERE version = $1`
+
+const (
+	undefinedTableCode  = "42P01"
+	undefinedSchemaCode = "3F000"
+)
+
+type revision struct {
+	version     string
+	description string
+	applied     int64
+	total       int64
+	executedAt  time.Time
+	err         string
+	hash        string
+}
+
+type migrationService struct {
+	logger *zap.Logger
+	db     *config.DBStore
+}
+
+func NewMigrationService(logger *zap.Logger, db *config.DBStore) domains.MigrationService {
+	return &migrationService{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (s *migrationService) Status(ctx context.Context) (*domains.MigrationStatus, error) {
+	migrations, err := db.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.db.ConnPool == nil {
+		return nil, errors.New("connection pool is not configured")
+	}
+
+	revisions, err := listRevisions(ctx, s.db.ConnPool)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMigrationStatus(migrations, revisions), nil
+}
+
+func (s *migrationService) CheckSchema(ctx context.Context) error {
+	status, err := s.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("could not check the database schema: %w", err)
+	}
+
+	for _, migration := range status.Migrations {
+		switch migration.State {
+		case domains.MigrationStatePartial:
+			return fmt.Errorf("%w: migration %s is partially applied: %s", domains.ErrSchemaBehind, migration.Version, migration.Error)
+		case domains.MigrationStateModified:
+			s.logger.Warn("Applied migration differs from the embedded one", zap.String("version", migration.Version))
+		case domains.MigrationStateUnknown:
+			s.logger.Warn("Database has a migration unknown to this binary, it is probably from a newer release", zap.String("version", migration.Version))
+		}
+	}
+
+	if status.Pending > 0 {
+		current := status.CurrentVersion
+		if current == "" {
+			current = "none"
+		}
+		return fmt.Errorf("%w: database is at version %s but the application needs %s, %d migration(s) pending, run `migrate up`",
+			domains.ErrSchemaBehind, current, status.LatestVersion, status.Pending)
+	}
+
+	return nil
+}
+
+func (s *migrationService) Up(ctx context.Context, count int) ([]domains.MigrationInfo, error) {
+	migrations, err := db.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []domains.MigrationInfo
+	err = s.withLock(ctx, func(conn *pgxpool.Conn) error {
+		if _, err := conn.Exec(ctx, createRevisionsTableQuery); err != nil {
+			return fmt.Errorf("could not create the revisions table: %w", err)
+		}
+
+		revisions, err := listRevisions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if count > 0 && len(applied) >= count {
+				break
+			}
+
+			if rev, ok := revisions[migration.Version]; ok {
+				if rev.applied < rev.total || rev.err != "" {
+					return fmt.Errorf("migration %s is partially applied and needs manual repair: %s", migration.Version, rev.err)
+				}
+				continue
+			}
+
+			start := time.Now()
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				// Migrations may legitimately run longer than the statement timeout set for API traffic.
+				if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+					return err
+				}
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				statements := db.CountStatements(migration.Up)
+				_, err := tx.Exec(ctx, insertRevisionQuery,
+					migration.Version, migration.Description, revisionTypeExecute, statements,
+					start, time.Since(start).Nanoseconds(), migration.Hash, operatorVersion())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("could not apply migration %s: %w", migration.Version, err)
+			}
+
+			executedAt := start
+			s.logger.Info("Applied migration", zap.String("version", migration.Version), zap.Duration("took", time.Since(start)))
+			applied = append(applied, domains.MigrationInfo{
+				Version:     migration.Version,
+				Description: migration.Description,
+				State:       domains.MigrationStateApplied,
+				ExecutedAt:  &executedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+func (s *migrationService) Down(ctx context.Context, count int) ([]domains.MigrationInfo, error) {
+	migrations, err := db.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]db.Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var reverted []domains.MigrationInfo
+	err = s.withLock(ctx, func(conn *pgxpool.Conn) error {
+		revisions, err := listRevisions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]string, 0, len(revisions))
+		for version := range revisions {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+		for _, version := range versions {
+			if len(reverted) >= count {
+				break
+			}
+
+			migration, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %s is unknown to this binary and cannot be reverted", version)
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+					return err
+				}
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, deleteRevisionQuery, version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("could not revert migration %s: %w", version, err)
+			}
+
+			s.logger.Info("Reverted migration", zap.String("version", version))
+			reverted = append(reverted, domains.MigrationInfo{
+				Version:     migration.Version,
+				Description: migration.Description,
+				State:       domains.MigrationStatePending,
+			})
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (s *migrationService) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	if s.db.ConnPool == nil {
+		return errors.New("connection pool is not configured")
+	}
+
+	conn, err := s.db.ConnPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("could not acquire the migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+	}()
+
+	return fn(conn)
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func listRevisions(ctx context.Context, q querier) (map[string]revision, error) {
+	rows, err := q.Query(ctx, listRevisionsQuery)
+	if isUndefinedRevisionsTable(err) {
+		return map[string]revision{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	revisions := map[string]revision{}
+	for rows.Next() {
+		var rev revision
+		if err := rows.Scan(&rev.version, &rev.description, &rev.applied, &rev.total, &rev.executedAt, &rev.err, &rev.hash); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		revisions[rev.version] = rev
+	}
+
+	if err := rows.Err(); isUndefinedRevisionsTable(err) {
+		return map[string]revision{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// isUndefinedRevisionsTable reports errors of databases that were never migrated, which have no revisions table yet.
+func isUndefinedRevisionsTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == undefinedTableCode || pgErr.Code == undefinedSchemaCode)
+}
+
+func buildMigrationStatus(migrations []db.Migration, revisions map[string]revision) *domains.MigrationStatus {
+	status := &domains.MigrationStatus{Migrations: []domains.MigrationInfo{}}
+	if len(migrations) > 0 {
+		status.LatestVersion = migrations[len(migrations)-1].Version
+	}
+
+	known := make(map[string]bool, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = true
+
+		info := domains.MigrationInfo{
+			Version:     migration.Version,
+			Description: migration.Description,
+			State:       domains.MigrationStatePending,
+		}
+
+		if rev, ok := revisions[migration.Version]; ok {
+			executedAt := rev.executedAt
+			info.ExecutedAt = &executedAt
+			info.Error = rev.err
+
+			switch {
+			case rev.applied < rev.total || rev.err != "":
+				info.State = domains.MigrationStatePartial
+			case rev.hash != migration.Hash:
+				info.State = domains.MigrationStateModified
+			default:
+				info.State = domains.MigrationStateApplied
+			}
+		}
+
+		if info.State == domains.MigrationStatePending {
+			status.Pending++
+		}
+		status.Migrations = append(status.Migrations, info)
+	}
+
+	for version, rev := range revisions {
+		if known[version] {
+			continue
+		}
+
+		executedAt := rev.executedAt
+		status.Migrations = append(status.Migrations, domains.MigrationInfo{
+			Version:     version,
+			Description: rev.description,
+			State:       domains.MigrationStateUnknown,
+			ExecutedAt:  &executedAt,
+		})
+	}
+
+	sort.Slice(status.Migrations, func(i, j int) bool {
+		return status.Migrations[i].Version < status.Migrations[j].Version
+	})
+	for _, migration := range status.Migrations {
+		if migration.State != domains.MigrationStatePending {
+			status.CurrentVersion = migration.Version
+		}
+	}
+
+	return status
+}
+
+func operatorVersion() string {
+	return "hotels-merge " + lib.Version
+}
@@ -0,0 +1,159 @@
+// Package policies derives structured hotel policies from the prose of booking conditions.
+package policies
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+var (
+	// clauseSeparator splits conditions holding several clauses, such as "... === ...".
+	clauseSeparator = regexp.MustCompile(`\s*={3,}\s*`)
+	// sentenceEnd splits clauses into sentences, keeping decimals such as "SGD 82.39" together.
+	sentenceEnd = regexp.MustCompile(`[.!?](\s+|$)`)
+
+	pets         = regexp.MustCompile(`\bpets?\b`)
+	petsDenied   = regexp.MustCompile(`\bno pets\b|\bpets? (are|is) not\b|\bpets? not (allowed|permitted)\b`)
+	petsAccepted = regexp.MustCompile(`\bpets? (are |is )?(allowed|welcome|permitted|accepted)\b`)
+
+	wifi    = regexp.MustCompile(`\bwi-?fi\b|\bwireless internet\b|\binternet\b`)
+	parking = regexp.MustCompile(`\bparking\b|\bcar park\b`)
+	free    = regexp.MustCompile(`\bfree\b|\bfree of charge\b|\bcomplimentary\b|\bat no (extra |additional )?(cost|charge)\b`)
+	paid    = regexp.MustCompile(`\bcharges? (apply|applies|are applicable)\b|\bcosts?\b|\bfees?\b|\bsurcharge\b|\bper (day|hour|night)\b|\bextra charge\b`)
+	absent  = regexp.MustCompile(`\bno (wi-?fi|internet|parking)\b|\b(is |are )?not (available|possible)\b`)
+
+	children         = regexp.MustCompile(`\bchild(ren)?\b|\bkids?\b|\badults only\b`)
+	childrenDenied   = regexp.MustCompile(`\badults only\b|\bchildren (are |is )?not (allowed|permitted|accepted)\b|\bno children\b`)
+	childrenAccepted = regexp.MustCompile(`\b(all )?children (of all ages )?are welcome\b|\bchildren (are |is )?(allowed|permitted|accepted)\b`)
+	childFree        = regexp.MustCompile(`\bchild(?:ren)? under (\d+) years?(?: old)? stays? free\b`)
+
+	prepaymentDenied   = regexp.MustCompile(`\bno (prepayment|deposit) (is )?(needed|required)\b`)
+	prepaymentRequired = regexp.MustCompile(`\b(prepayment|payment before arrival|deposit)\b.*\b(required|is due|will be charged|is needed)\b|\bfull amount\b.*\bdue before arrival\b`)
+
+	checkIn    = regexp.MustCompile(`\bcheck-?in\b|\bupon arrival\b|\bon arrival\b`)
+	photoID    = regexp.MustCompile(`\bphoto (identification|id)\b|\bgovernment-issued\b|\bpassport\b|\bidentity card\b|\bid card\b`)
+	creditCard = regexp.MustCompile(`\bcredit cards?\b`)
+)
+
+// Parse derives policies from booking conditions, leaving nil the policies no condition states. When
+// conditions disagree on the cost of wifi or parking, free wins over paid over unavailable.
+func Parse(conditions []string) *dto.HotelPolicies {
+	policies := &dto.HotelPolicies{}
+	for _, sentence := range sentences(conditions) {
+		parsePets(policies, sentence)
+		if wifi.MatchString(sentence) {
+			policies.Wifi = cost(policies.Wifi, sentence)
+		}
+		if parking.MatchString(sentence) {
+			policies.Parking = cost(policies.Parking, sentence)
+		}
+		parseChildren(policies, sentence)
+		parsePrepayment(policies, sentence)
+		parseCheckIn(policies, sentence)
+	}
+
+	return policies
+}
+
+func sentences(conditions []string) []string {
+	var sentences []string
+	for _, condition := range conditions {
+		for _, clause := range clauseSeparator.Split(condition, -1) {
+			for _, sentence := range sentenceEnd.Split(strings.ToLower(clause), -1) {
+				if sentence = strings.TrimSpace(sentence); sentence != "" {
+					sentences = append(sentences, sentence)
+				}
+			}
+		}
+	}
+
+	return sentences
+}
+
+func parsePets(policies *dto.HotelPolicies, sentence string) {
+	switch {
+	case !pets.MatchString(sentence):
+	case petsDenied.MatchString(sentence):
+		policies.PetsAllowed = pointer(false)
+	case petsAccepted.MatchString(sentence):
+		policies.PetsAllowed = pointer(true)
+	}
+}
+
+// cost ranks free over paid over unavailable, keeping the best of current and the sentence.
+func cost(current *string, sentence string) *string {
+	var value string
+	switch {
+	case free.MatchString(sentence):
+		value = dto.PolicyFree
+	case absent.MatchString(sentence):
+		value = dto.PolicyUnavailable
+	case paid.MatchString(sentence):
+		value = dto.PolicyPaid
+	default:
+		return current
+	}
+
+	rank := map[string]int{dto.PolicyUnavailable: 1, dto.PolicyPaid: 2, dto.PolicyFree: 3}
+	if current != nil && rank[*current] >= rank[value] {
+		return current
+	}
+	return &value
+}
+
+func parseChildren(policies *dto.HotelPolicies, sentence string) {
+	if !children.MatchString(sentence) {
+		return
+	}
+
+	switch {
+	case childrenDenied.MatchString(sentence):
+		policies.Children.Allowed = pointer(false)
+	case childrenAccepted.MatchString(sentence):
+		policies.Children.Allowed = pointer(true)
+	}
+
+	for _, match := range childFree.FindAllStringSubmatch(sentence, -1) {
+		age, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		if policies.Children.FreeUnderAge == nil || age > *policies.Children.FreeUnderAge {
+			policies.Children.FreeUnderAge = &age
+		}
+		if policies.Children.Allowed == nil {
+			policies.Children.Allowed = pointer(true)
+		}
+	}
+}
+
+func parsePrepayment(policies *dto.HotelPolicies, sentence string) {
+	switch {
+	case prepaymentDenied.MatchString(sentence):
+		if policies.PrepaymentRequired == nil {
+			policies.PrepaymentRequired = pointer(false)
+		}
+	case prepaymentRequired.MatchString(sentence):
+		policies.PrepaymentRequired = pointer(true)
+	}
+}
+
+func parseCheckIn(policies *dto.HotelPolicies, sentence string) {
+	if !checkIn.MatchString(sentence) {
+		return
+	}
+
+	if photoID.MatchString(sentence) {
+		policies.CheckIn.PhotoIDRequired = pointer(true)
+	}
+	if creditCard.MatchString(sentence) {
+		policies.CheckIn.CreditCardRequired = pointer(true)
+	}
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,62 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/policies"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should derive policies from supplier booking conditions", func(t *testing.T) {
+		parsed := policies.Parse([]string{
+			"All children are welcome. One child under 12 years stays free of charge when using existing beds. One child under 2 years stays free of charge in a child's cot/crib. One older child or adult is charged SGD 82.39 per person per night in an extra bed.",
+			"Pets are not allowed.",
+			"WiFi is available in all areas and is free of charge.",
+			"Free private parking is possible on site (reservation is not needed).",
+			"Guests are required to show a photo identification and credit card upon check-in. Payment before arrival via bank transfer is required. === Upon check-in, guests will be provided with complimentary Sentosa Pass (monorail). === Credit card provided upon reservation is for guarantee purpose.",
+		})
+
+		assert.Equal(t, &dto.HotelPolicies{
+			PetsAllowed:        pointer(false),
+			Wifi:               pointer(dto.PolicyFree),
+			Parking:            pointer(dto.PolicyFree),
+			Children:           dto.ChildPolicy{Allowed: pointer(true), FreeUnderAge: pointer(12)},
+			PrepaymentRequired: pointer(true),
+			CheckIn:            dto.CheckInPolicy{PhotoIDRequired: pointer(true), CreditCardRequired: pointer(true)},
+		}, parsed)
+	})
+
+	t.Run("should rank free over paid parking", func(t *testing.T) {
+		parsed := policies.Parse([]string{
+			"Public parking is possible at a location nearby and costs EUR 10 per day.",
+			"All guests can enjoy complimentary parking during their stay.",
+		})
+
+		assert.Equal(t, dto.PolicyFree, *parsed.Parking)
+	})
+
+	t.Run("should read paid, unavailable and denied policies", func(t *testing.T) {
+		parsed := policies.Parse([]string{
+			"WiFi is available in the hotel rooms and charges are applicable.",
+			"No parking available.",
+			"Pets are allowed on request. Adults only.",
+			"No prepayment is needed.",
+		})
+
+		assert.Equal(t, dto.PolicyPaid, *parsed.Wifi)
+		assert.Equal(t, dto.PolicyUnavailable, *parsed.Parking)
+		assert.Equal(t, pointer(true), parsed.PetsAllowed)
+		assert.Equal(t, pointer(false), parsed.Children.Allowed)
+		assert.Equal(t, pointer(false), parsed.PrepaymentRequired)
+	})
+
+	t.Run("should leave unstated policies nil", func(t *testing.T) {
+		assert.Equal(t, &dto.HotelPolicies{}, policies.Parse(nil))
+	})
+}
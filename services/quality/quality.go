@@ -0,0 +1,121 @@
+// Package quality scores how complete a hotel record is.
+package quality
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+const (
+	// DescriptionLength is the description length, in characters, earning the full description weight.
+	DescriptionLength = 200
+	// ImageCount is the image count earning the full image weight.
+	ImageCount = 5
+)
+
+// Field weights, adding up to 100.
+const (
+	nameWeight              = 5
+	addressWeight           = 10
+	cityWeight              = 5
+	countryWeight           = 5
+	descriptionWeight       = 15
+	imagesWeight            = 20
+	amenitiesWeight         = 10
+	bookingConditionsWeight = 10
+	coordinatesWeight       = 20
+)
+
+// coordinateWeights scale the coordinates weight by location status, in percent.
+var coordinateWeights = map[string]int{
+	dto.LocationOK:         100,
+	dto.LocationRepaired:   100,
+	dto.LocationUnverified: 50,
+	dto.LocationSuspect:    25,
+}
+
+// Score returns a copy of the data quality of hotel holding its score and missing fields. The location
+// status must already be checked, as it rates the coordinates.
+func Score(hotel sqlc.UpsertHotelParams) *dto.DataQuality {
+	quality := dto.DataQuality{Location: dto.LocationQuality{Status: dto.LocationMissing, Issues: []string{}}}
+	if hotel.DataQuality != nil {
+		quality = *hotel.DataQuality
+	}
+
+	location := hotel.Location
+	if location == nil {
+		location = &dto.HotelLocation{}
+	}
+
+	score := 0
+	missing := []string{}
+	add := func(field string, weight, percent int) {
+		if percent == 0 {
+			missing = append(missing, field)
+		}
+		score += weight * percent
+	}
+
+	add("name", nameWeight, present(&hotel.Name))
+	add("location.address", addressWeight, present(location.Address))
+	add("location.city", cityWeight, present(location.City))
+	add("country_code", countryWeight, present(hotel.CountryCode))
+	add("location.coordinates", coordinatesWeight, coordinateWeights[quality.Location.Status])
+	add("description", descriptionWeight, descriptionPercent(hotel.Description))
+	add("images", imagesWeight, min(imageCount(hotel.Images), ImageCount)*100/ImageCount)
+	add("amenities", amenitiesWeight, amenitiesPercent(hotel.Amenities))
+	add("booking_conditions", bookingConditionsWeight, conditionsPercent(hotel.BookingConditions))
+
+	score /= 100
+	quality.Score = &score
+	quality.Missing = missing
+	return &quality
+}
+
+func present(value *string) int {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return 0
+	}
+
+	return 100
+}
+
+func descriptionPercent(description *string) int {
+	if present(description) == 0 {
+		return 0
+	}
+
+	// A short description is worth half the weight at least.
+	length := utf8.RuneCountInString(sanitize.Text(*description))
+	return 50 + min(length, DescriptionLength)*50/DescriptionLength
+}
+
+func imageCount(images *dto.HotelImages) int {
+	if images == nil {
+		return 0
+	}
+
+	return len(images.Rooms) + len(images.Site) + len(images.Amenities)
+}
+
+func amenitiesPercent(amenities *dto.HotelAmenities) int {
+	if amenities == nil || len(amenities.Canonical)+len(amenities.General)+len(amenities.Room) == 0 {
+		return 0
+	}
+
+	return 100
+}
+
+func conditionsPercent(conditions []string) int {
+	for _, condition := range conditions {
+		if strings.TrimSpace(condition) != "" {
+			return 100
+		}
+	}
+
+	return 0
+}
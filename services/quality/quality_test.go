@@ -0,0 +1,80 @@
+package quality_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/quality"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func completeHotel() sqlc.UpsertHotelParams {
+	image := dto.HotelImage{Link: "https://example.com/room.jpg", Description: "Double room"}
+	return sqlc.UpsertHotelParams{
+		HotelID:       "iJhz",
+		DestinationID: "5432",
+		Name:          "Beach Villas Singapore",
+		Location: &dto.HotelLocation{
+			Latitude:  pointer(1.264751),
+			Longitude: pointer(103.824006),
+			Address:   pointer("8 Sentosa Gateway, Beach Villas"),
+			City:      pointer("Singapore"),
+			Country:   pointer("Singapore"),
+		},
+		CountryCode: pointer("SG"),
+		DataQuality: &dto.DataQuality{
+			Location:  dto.LocationQuality{Status: dto.LocationOK, Issues: []string{}},
+			Suppliers: []string{"acme"},
+		},
+		Description:       pointer(strings.Repeat("a", quality.DescriptionLength)),
+		Images:            &dto.HotelImages{Rooms: []dto.HotelImage{image, image, image}, Site: []dto.HotelImage{image, image}},
+		Amenities:         &dto.HotelAmenities{General: []string{"pool"}},
+		BookingConditions: []string{"All children are welcome."},
+	}
+}
+
+func TestScore(t *testing.T) {
+	t.Run("should score a complete hotel 100", func(t *testing.T) {
+		hotel := completeHotel()
+
+		scored := quality.Score(hotel)
+
+		require.NotNil(t, scored.Score)
+		assert.Equal(t, 100, *scored.Score)
+		assert.Empty(t, scored.Missing)
+		assert.Equal(t, dto.LocationOK, scored.Location.Status)
+		assert.Equal(t, []string{"acme"}, scored.Suppliers)
+		assert.Nil(t, hotel.DataQuality.Score, "the hotel should not change")
+	})
+
+	t.Run("should list the missing fields", func(t *testing.T) {
+		scored := quality.Score(sqlc.UpsertHotelParams{HotelID: "a", DestinationID: "1", Name: "Hotel A", BookingConditions: []string{" "}})
+
+		assert.Equal(t, 5, *scored.Score)
+		assert.Equal(t, []string{
+			"location.address", "location.city", "country_code", "location.coordinates",
+			"description", "images", "amenities", "booking_conditions",
+		}, scored.Missing)
+		assert.Equal(t, dto.LocationMissing, scored.Location.Status)
+	})
+
+	t.Run("should weigh partial fields", func(t *testing.T) {
+		hotel := completeHotel()
+		hotel.Description = pointer(strings.Repeat("a", quality.DescriptionLength/5))
+		hotel.Images.Site = nil
+		hotel.DataQuality.Location.Status = dto.LocationSuspect
+
+		scored := quality.Score(hotel)
+
+		// 3 of 5 of the description and image weights, 1 of 4 of the coordinates weight.
+		assert.Equal(t, 100-6-8-15, *scored.Score)
+		assert.Empty(t, scored.Missing)
+	})
+}
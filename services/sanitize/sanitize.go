@@ -0,0 +1,358 @@
+// Package sanitize cleans the prose of supplier descriptions and booking conditions into safe HTML.
+package sanitize
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Formats of sanitized text returned by the API.
+const (
+	FormatText = "text"
+	FormatHTML = "html"
+)
+
+// minSentenceWords is the fewest words of a sentence dropped as a duplicate, so that fragments split off
+// abbreviations such as "approx." are kept.
+const minSentenceWords = 3
+
+var (
+	// blocks are the allowed elements starting a new line.
+	blocks = map[atom.Atom]bool{atom.P: true, atom.Ul: true, atom.Ol: true, atom.Li: true}
+	// inlines are the allowed elements formatting text.
+	inlines = map[atom.Atom]bool{atom.B: true, atom.Strong: true, atom.I: true, atom.Em: true}
+	// paragraphs are elements kept as paragraphs.
+	paragraphs = map[atom.Atom]bool{
+		atom.Div: true, atom.Section: true, atom.Article: true, atom.Blockquote: true,
+		atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+		atom.Td: true, atom.Th: true, atom.Dt: true, atom.Dd: true,
+	}
+	// dropped are elements left out with their content.
+	dropped = map[atom.Atom]bool{
+		atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Noscript: true,
+		atom.Template: true, atom.Svg: true, atom.Math: true, atom.Head: true, atom.Title: true,
+		atom.Textarea: true, atom.Select: true,
+	}
+
+	sentenceEnd = regexp.MustCompile(`[.!?]+(\s+|$)`)
+	escaper     = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// node is an allowed element, or text when tag is zero.
+type node struct {
+	tag      atom.Atom
+	text     string
+	children []*node
+}
+
+// HTML sanitizes value into safe HTML. Only paragraphs, line breaks, lists and bold or italic text are kept,
+// without attributes. Other tags are dropped, scripts and styles with their content, and entities are decoded.
+// Text is NFC normalized, invisible characters are removed, whitespace is collapsed, and sentences repeating
+// an earlier one are dropped. Sentences are compared within runs of text between tags, ignoring case and
+// punctuation. Plain text comes out escaped and otherwise unchanged but for whitespace.
+func HTML(value string) string {
+	return newCleaner().html(value)
+}
+
+// Text returns the plain text of value: tags are dropped, entities decoded, and paragraphs, list items
+// and line breaks put on their own lines.
+func Text(value string) string {
+	return render(parse(value), true)
+}
+
+// Texts returns the plain text of each value.
+func Texts(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = Text(value)
+	}
+
+	return texts
+}
+
+// Clean sanitizes a description and booking conditions into safe HTML and returns them with their raw text.
+// Sentences repeated across booking conditions are dropped, and conditions left empty are removed.
+func Clean(description *string, bookingConditions []string) (*string, []string, *dto.RawText) {
+	raw := &dto.RawText{BookingConditions: slices.Clone(bookingConditions)}
+	if description != nil {
+		rawDescription := *description
+		raw.Description = &rawDescription
+
+		sanitized := HTML(*description)
+		description = nil
+		if sanitized != "" {
+			description = &sanitized
+		}
+	}
+
+	if bookingConditions == nil {
+		return description, nil, raw
+	}
+	cleaner := newCleaner()
+	conditions := make([]string, 0, len(bookingConditions))
+	for _, condition := range bookingConditions {
+		if sanitized := cleaner.html(condition); sanitized != "" {
+			conditions = append(conditions, sanitized)
+		}
+	}
+
+	return description, conditions, raw
+}
+
+// cleaner drops the sentences already seen by any value it cleaned.
+type cleaner struct {
+	seen map[string]bool
+}
+
+func newCleaner() *cleaner {
+	return &cleaner{seen: map[string]bool{}}
+}
+
+func (c *cleaner) html(value string) string {
+	root := parse(value)
+	c.dedupe(root)
+
+	return render(root, false)
+}
+
+func (c *cleaner) dedupe(n *node) {
+	if n.tag == 0 && n.children == nil {
+		n.text = c.sentences(n.text)
+	}
+	for _, child := range n.children {
+		c.dedupe(child)
+	}
+}
+
+func (c *cleaner) sentences(text string) string {
+	var kept strings.Builder
+	start := 0
+	for _, end := range sentenceEnd.FindAllStringIndex(text, -1) {
+		sentence := text[start:end[1]]
+		start = end[1]
+
+		words := strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		if len(words) >= minSentenceWords {
+			key := strings.Join(words, " ")
+			if c.seen[key] {
+				continue
+			}
+			c.seen[key] = true
+		}
+		kept.WriteString(sentence)
+	}
+	kept.WriteString(text[start:])
+
+	return kept.String()
+}
+
+// parse reads value as an HTML fragment into a tree of allowed elements. Unclosed elements are closed,
+// and a paragraph is closed by the next block as browsers do.
+func parse(value string) *node {
+	root := &node{}
+	stack := []*node{root}
+	skipped := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(value))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return root
+		case html.TextToken:
+			if skipped == 0 {
+				top := stack[len(stack)-1]
+				top.children = append(top.children, &node{text: normalize(string(tokenizer.Text()))})
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case dropped[tag]:
+				if tokenType == html.StartTagToken {
+					skipped++
+				}
+			case skipped > 0:
+			case tag == atom.Br:
+				top := stack[len(stack)-1]
+				top.children = append(top.children, &node{tag: atom.Br})
+			case blocks[tag] || inlines[tag] || paragraphs[tag]:
+				if paragraphs[tag] {
+					tag = atom.P
+				}
+				stack = open(stack, tag)
+				if tokenType == html.SelfClosingTagToken {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case dropped[tag]:
+				skipped = max(skipped-1, 0)
+			case skipped > 0:
+			default:
+				if paragraphs[tag] {
+					tag = atom.P
+				}
+				if i := lastIndex(stack, tag); i > 0 {
+					stack = stack[:i]
+				}
+			}
+		}
+	}
+}
+
+func open(stack []*node, tag atom.Atom) []*node {
+	if blocks[tag] {
+		for len(stack) > 1 && inlines[stack[len(stack)-1].tag] {
+			stack = stack[:len(stack)-1]
+		}
+		if i := lastIndex(stack, atom.P); i > 0 {
+			stack = stack[:i]
+		}
+		if tag == atom.Li {
+			if i := lastIndex(stack, atom.Li); i > max(lastIndex(stack, atom.Ul), lastIndex(stack, atom.Ol)) {
+				stack = stack[:i]
+			}
+		}
+	}
+
+	element := &node{tag: tag}
+	top := stack[len(stack)-1]
+	top.children = append(top.children, element)
+
+	return append(stack, element)
+}
+
+func lastIndex(stack []*node, tag atom.Atom) int {
+	for i := len(stack) - 1; i > 0; i-- {
+		if stack[i].tag == tag {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// normalize composes text to NFC, decodes entities escaped twice, turns any space or control character into
+// a space, and removes invisible characters such as zero width spaces and soft hyphens.
+func normalize(text string) string {
+	text = norm.NFC.String(html.UnescapeString(text))
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r) || unicode.IsControl(r):
+			return ' '
+		case unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// renderer writes words separated by single spaces, dropping whitespace at the start and end of lines.
+type renderer struct {
+	out   strings.Builder
+	plain bool
+	// space is set by whitespace, written before the next word.
+	space bool
+	// line is set by a line break, written before the next word in plain text.
+	line bool
+}
+
+func render(root *node, plain bool) string {
+	r := &renderer{plain: plain, line: true}
+	for _, child := range root.children {
+		r.node(child)
+	}
+
+	return r.out.String()
+}
+
+func (r *renderer) node(n *node) {
+	switch {
+	case n.tag == 0:
+		r.text(n.text)
+	case n.tag == atom.Br:
+		if !r.plain {
+			r.out.WriteString("<br>")
+		}
+		r.line = true
+	case !hasText(n):
+	default:
+		if blocks[n.tag] {
+			r.line = true
+		} else if r.space && !r.line && !r.plain {
+			r.out.WriteString(" ")
+			r.space = false
+		}
+		if !r.plain {
+			r.out.WriteString("<" + n.tag.String() + ">")
+		}
+		for _, child := range n.children {
+			r.node(child)
+		}
+		if !r.plain {
+			r.out.WriteString("</" + n.tag.String() + ">")
+		}
+		if blocks[n.tag] {
+			r.line = true
+		}
+	}
+}
+
+func (r *renderer) text(text string) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		r.space = r.space || text != ""
+		return
+	}
+
+	r.space = r.space || strings.TrimLeft(text, " ") != text
+	for _, word := range words {
+		switch {
+		case r.line:
+			if r.plain && r.out.Len() > 0 {
+				r.out.WriteString("\n")
+			}
+		case r.space:
+			r.out.WriteString(" ")
+		}
+		r.line, r.space = false, true
+
+		if r.plain {
+			r.out.WriteString(word)
+		} else {
+			r.out.WriteString(escaper.Replace(word))
+		}
+	}
+	r.space = strings.TrimRight(text, " ") != text
+}
+
+func hasText(n *node) bool {
+	if n.tag == 0 {
+		return strings.TrimSpace(n.text) != ""
+	}
+	for _, child := range n.children {
+		if hasText(child) {
+			return true
+		}
+	}
+
+	return false
+}
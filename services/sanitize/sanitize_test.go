@@ -0,0 +1,102 @@
+package sanitize_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func TestHTML(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "should keep plain text but for whitespace",
+			value: "  Surrounded by tropical gardens,\n these upscale villas.\tThere's 24-hour room service. ",
+			want:  "Surrounded by tropical gardens, these upscale villas. There's 24-hour room service.",
+		},
+		{
+			name:  "should keep allowed tags without attributes",
+			value: `<p class="intro" onclick="alert(1)">Hello <b>world</b>.</p><ul><li>Pool<li><em>Spa</em></ul>`,
+			want:  "<p>Hello <b>world</b>.</p><ul><li>Pool</li><li><em>Spa</em></li></ul>",
+		},
+		{
+			name:  "should drop other tags and scripts with their content",
+			value: `<a href="javascript:alert(1)">Beach</a> <img src=x onerror="alert(1)">Villas<script>alert(1)</script><style>p{}</style>`,
+			want:  "Beach Villas",
+		},
+		{
+			name:  "should turn divisions and headings into paragraphs",
+			value: "<h2>Rooms</h2><div>Sea views<br/>Free minibar</div>",
+			want:  "<p>Rooms</p><p>Sea views<br>Free minibar</p>",
+		},
+		{
+			name:  "should decode entities and escape the text",
+			value: "Caf&amp;eacute; &lt;b&gt; R&amp;D &#8211; 5 < 6",
+			want:  "Café &lt;b&gt; R&amp;D – 5 &lt; 6",
+		},
+		{
+			name:  "should normalize unicode and remove invisible characters",
+			value: "Cafe\u0301 de\u200b\u00a0Paris\u00adian",
+			want:  "Café de Parisian",
+		},
+		{
+			name:  "should drop repeated sentences and the paragraphs left empty, keeping short fragments",
+			value: "<p>Great pool and spa. Close to the beach!</p><p>Great pool AND spa!</p><p>Approx. 5 km. Approx. 5 km.</p>",
+			want:  "<p>Great pool and spa. Close to the beach!</p><p>Approx. 5 km. Approx. 5 km.</p>",
+		},
+		{
+			name:  "should close unclosed tags",
+			value: "<p><b>Unclosed <i>tags",
+			want:  "<p><b>Unclosed <i>tags</i></b></p>",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := sanitize.HTML(c.value)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, got, sanitize.HTML(got), "sanitizing should be idempotent")
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "Hello world & more.\nPool\nSpa\nSea views\nFree minibar",
+		sanitize.Text("<p>Hello <b>world</b> &amp; more.</p><ul><li>Pool</li><li>Spa</li></ul>Sea views<br>Free minibar"))
+	assert.Equal(t, "There's a pool.", sanitize.Text("There's a pool."))
+	assert.Nil(t, sanitize.Texts(nil))
+}
+
+func TestClean(t *testing.T) {
+	t.Run("should sanitize and keep the raw text", func(t *testing.T) {
+		description, conditions, raw := sanitize.Clean(pointer("<p>Beach  villas.</p>"), []string{
+			"Pets are not allowed.",
+			"<b>WiFi</b> is free. Pets are not allowed.",
+			"Pets are not allowed!",
+		})
+
+		assert.Equal(t, "<p>Beach villas.</p>", *description)
+		assert.Equal(t, []string{"Pets are not allowed.", "<b>WiFi</b> is free."}, conditions)
+		assert.Equal(t, &dto.RawText{
+			Description:       pointer("<p>Beach  villas.</p>"),
+			BookingConditions: []string{"Pets are not allowed.", "<b>WiFi</b> is free. Pets are not allowed.", "Pets are not allowed!"},
+		}, raw)
+	})
+
+	t.Run("should drop an empty description", func(t *testing.T) {
+		description, conditions, raw := sanitize.Clean(pointer("<script>alert(1)</script>"), nil)
+
+		assert.Nil(t, description)
+		assert.Nil(t, conditions)
+		assert.Equal(t, "<script>alert(1)</script>", *raw.Description)
+	})
+}
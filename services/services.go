@@ -1,7 +1,47 @@
 package services
 
-import "go.uber.org/fx"
+import (
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+func decorateHotelService(
+	service domains.HotelService,
+	metrics *lib.Metrics,
+	tracerProvider trace.TracerProvider,
+	cfg *config.Config,
+	watcher *config.Watcher,
+	changes *HotelChanges,
+) domains.HotelService {
+	if cfg.Cache.Enabled {
+		cache := NewHotelServiceCache(service, cfg.Cache.TTL, cfg.Cache.MaxEntries)
+		watcher.Subscribe("cache.ttl", func(reloaded *config.Config) error {
+			cache.SetTTL(reloaded.Cache.TTL)
+			return nil
+		})
+		changes.Subscribe(cache.Clear)
+		service = cache
+	}
+
+	// Metrics wrap the cache so that lookups served from it are counted with their latency.
+	service = NewHotelServiceMetrics(service, metrics)
+
+	return NewHotelServiceTracing(service, tracerProvider)
+}
 
 var Module = fx.Options(
 	fx.Provide(NewHotelService),
+	fx.Provide(NewHotelChanges),
+	fx.Provide(NewHealthService),
+	fx.Provide(NewMigrationService),
+	fx.Provide(NewHotelDataService),
+	fx.Provide(NewIngestService),
+	fx.Provide(NewSnapshotService),
+	fx.Provide(NewDuplicateService),
+	fx.Provide(NewTranslationService),
+	fx.Provide(NewDestinationService),
+	fx.Decorate(decorateHotelService),
 )
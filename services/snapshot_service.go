@@ -0,0 +1,429 @@
+package services
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	snapshotManifestFile     = "manifest.json"
+	snapshotHotelsFile       = "hotels.ndjson"
+	snapshotRevisionsFile    = "revisions.ndjson"
+	snapshotDestinationsFile = "destinations.ndjson"
+	snapshotDuplicatesFile   = "duplicates.ndjson"
+	snapshotRedirectsFile    = "redirects.ndjson"
+	snapshotTranslationsFile = "translations.ndjson"
+	// maxSnapshotFileSize bounds each file read from an archive.
+	maxSnapshotFileSize = 1 << 30
+)
+
+type snapshotService struct {
+	logger     *zap.Logger
+	db         *config.DBStore
+	migrations domains.MigrationService
+	changes    *HotelChanges
+}
+
+func NewSnapshotService(logger *zap.Logger, db *config.DBStore, migrations domains.MigrationService, changes *HotelChanges) domains.SnapshotService {
+	return &snapshotService{
+		logger:     logger,
+		db:         db,
+		migrations: migrations,
+		changes:    changes,
+	}
+}
+
+func (s *snapshotService) Export(ctx context.Context, w io.Writer) (*domains.SnapshotManifest, error) {
+	status, err := s.migrations.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the schema version: %w", err)
+	}
+
+	var revisions []domains.MigrationInfo
+	for _, migration := range status.Migrations {
+		if migration.State != domains.MigrationStatePending {
+			revisions = append(revisions, migration)
+		}
+	}
+
+	// Every table is read in one transaction, so that the snapshot does not mix states of concurrent writes.
+	var (
+		hotels       []*sqlc.Hotel
+		destinations []sqlc.RestoreDestinationParams
+		duplicates   []*sqlc.HotelDuplicate
+		redirects    []*sqlc.HotelRedirect
+		translations []*sqlc.HotelTranslation
+	)
+	readOnly := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err = s.db.InTxWithOptions(ctx, readOnly, func(queries sqlc.Querier) error {
+		var err error
+		duplicates = nil
+		if hotels, err = queries.ListHotels(ctx); err != nil {
+			return err
+		}
+		if destinations, err = listDestinations(ctx, queries); err != nil {
+			return err
+		}
+		for _, status := range []string{domains.DuplicatePending, domains.DuplicateConfirmed, domains.DuplicateRejected} {
+			listed, err := queries.ListHotelDuplicates(ctx, status)
+			if err != nil {
+				return err
+			}
+			duplicates = append(duplicates, listed...)
+		}
+		if redirects, err = queries.ListHotelRedirects(ctx); err != nil {
+			return err
+		}
+		translations, err = queries.ListAllHotelTranslations(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domains.SnapshotManifest{
+		FormatVersion: domains.SnapshotFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		AppVersion:    lib.Version,
+		SchemaVersion: status.CurrentVersion,
+	}
+
+	var files [][]byte
+	for _, file := range []struct {
+		name    string
+		records []any
+	}{
+		{snapshotHotelsFile, toAny(hotels)},
+		{snapshotRevisionsFile, toAny(revisions)},
+		{snapshotDestinationsFile, toAny(destinations)},
+		{snapshotDuplicatesFile, toAny(duplicates)},
+		{snapshotRedirectsFile, toAny(redirects)},
+		{snapshotTranslationsFile, toAny(translations)},
+	} {
+		content, err := encodeNDJSON(file.records)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, content)
+		manifest.Files = append(manifest.Files, domains.SnapshotFile{
+			Name:    file.name,
+			Records: len(file.records),
+			Size:    int64(len(content)),
+			SHA256:  checksum(content),
+		})
+	}
+
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	if err := writeTarFile(archive, snapshotManifestFile, manifestContent, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	for i, file := range manifest.Files {
+		if err := writeTarFile(archive, file.Name, files[i], manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Exported snapshot", zap.String("schema_version", manifest.SchemaVersion), zap.Int("hotels", len(hotels)))
+	return manifest, nil
+}
+
+// listDestinations lists every destination in the shape they are restored from.
+func listDestinations(ctx context.Context, queries sqlc.Querier) ([]sqlc.RestoreDestinationParams, error) {
+	count, err := queries.CountDestinations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.ListDestinations(ctx, sqlc.ListDestinationsParams{Limit: int32(count)})
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := make([]sqlc.RestoreDestinationParams, len(rows))
+	for i, row := range rows {
+		destinations[i] = sqlc.RestoreDestinationParams{
+			ID:          row.ID,
+			Name:        row.Name,
+			CountryCode: row.CountryCode,
+			Latitude:    row.Latitude,
+			Longitude:   row.Longitude,
+			Timezone:    row.Timezone,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		}
+	}
+
+	return destinations, nil
+}
+
+func (s *snapshotService) Restore(ctx context.Context, r io.Reader) (*domains.SnapshotManifest, error) {
+	manifest, files, err := readSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.migrations.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the schema version: %w", err)
+	}
+	if status.CurrentVersion != manifest.SchemaVersion {
+		return nil, fmt.Errorf("%w: snapshot has schema version %q but the database is at %q, migrate the database to the snapshot version first",
+			domains.ErrSnapshotMismatch, manifest.SchemaVersion, status.CurrentVersion)
+	}
+
+	count, err := s.db.Queries.CountHotels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: the database already has %d hotels, restore only loads into an empty database", domains.ErrSnapshotMismatch, count)
+	}
+	count, err = s.db.Queries.CountDestinations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: the database already has %d destinations, restore only loads into an empty database", domains.ErrSnapshotMismatch, count)
+	}
+
+	hotels, err := decodeSnapshotFile[sqlc.RestoreHotelParams](manifest, files, snapshotHotelsFile)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := decodeSnapshotFile[sqlc.RestoreDestinationParams](manifest, files, snapshotDestinationsFile)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := decodeSnapshotFile[sqlc.RestoreHotelDuplicateParams](manifest, files, snapshotDuplicatesFile)
+	if err != nil {
+		return nil, err
+	}
+	redirects, err := decodeSnapshotFile[sqlc.RestoreHotelRedirectParams](manifest, files, snapshotRedirectsFile)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := decodeSnapshotFile[sqlc.RestoreHotelTranslationParams](manifest, files, snapshotTranslationsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		for _, hotel := range hotels {
+			if err := queries.RestoreHotel(ctx, hotel); err != nil {
+				return fmt.Errorf("could not restore hotel %s: %w", hotel.HotelID, err)
+			}
+		}
+		if err := queries.ResetHotelIDSequence(ctx); err != nil {
+			return err
+		}
+		for _, destination := range destinations {
+			if err := queries.RestoreDestination(ctx, destination); err != nil {
+				return fmt.Errorf("could not restore destination %s: %w", destination.ID, err)
+			}
+		}
+		for _, duplicate := range duplicates {
+			if err := queries.RestoreHotelDuplicate(ctx, duplicate); err != nil {
+				return fmt.Errorf("could not restore duplicate candidate %d: %w", duplicate.ID, err)
+			}
+		}
+		if err := queries.ResetHotelDuplicateIDSequence(ctx); err != nil {
+			return err
+		}
+		for _, redirect := range redirects {
+			if err := queries.RestoreHotelRedirect(ctx, redirect); err != nil {
+				return fmt.Errorf("could not restore the redirect of hotel %s: %w", redirect.HotelID, err)
+			}
+		}
+		for _, translation := range translations {
+			if err := queries.RestoreHotelTranslation(ctx, translation); err != nil {
+				return fmt.Errorf("could not restore the %s translation of hotel %s: %w", translation.Locale, translation.HotelID, err)
+			}
+		}
+		return queries.InsertMissingDestinations(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.changes.Notify()
+
+	s.logger.Info("Restored snapshot", zap.String("schema_version", manifest.SchemaVersion), zap.Int("hotels", len(hotels)))
+	return manifest, nil
+}
+
+// decodeSnapshotFile decodes the records of the file name and checks their number against the manifest.
+func decodeSnapshotFile[T any](manifest *domains.SnapshotManifest, files map[string][]byte, name string) ([]T, error) {
+	content, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing", domains.ErrSnapshotCorrupted, name)
+	}
+
+	var records []T
+	if err := decodeNDJSON(content, &records); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", domains.ErrSnapshotCorrupted, name, err)
+	}
+	for _, file := range manifest.Files {
+		if file.Name == name && file.Records != len(records) {
+			return nil, fmt.Errorf("%w: manifest lists %d records in %s but the archive has %d", domains.ErrSnapshotCorrupted, file.Records, name, len(records))
+		}
+	}
+
+	return records, nil
+}
+
+// readSnapshot reads an archive and checks every file against the manifest, which must come first.
+func readSnapshot(r io.Reader) (*domains.SnapshotManifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", domains.ErrSnapshotCorrupted, err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	var manifest *domains.SnapshotManifest
+	expected := map[string]domains.SnapshotFile{}
+	files := map[string][]byte{}
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", domains.ErrSnapshotCorrupted, err)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(archive, maxSnapshotFileSize))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s: %w", domains.ErrSnapshotCorrupted, header.Name, err)
+		}
+
+		if manifest == nil {
+			if header.Name != snapshotManifestFile {
+				return nil, nil, fmt.Errorf("%w: %s must be the first file", domains.ErrSnapshotCorrupted, snapshotManifestFile)
+			}
+			if manifest, err = parseManifest(content); err != nil {
+				return nil, nil, err
+			}
+			for _, file := range manifest.Files {
+				expected[file.Name] = file
+			}
+			continue
+		}
+
+		file, ok := expected[header.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s is not listed in the manifest", domains.ErrSnapshotCorrupted, header.Name)
+		}
+		if int64(len(content)) != file.Size || checksum(content) != file.SHA256 {
+			return nil, nil, fmt.Errorf("%w: checksum mismatch for %s", domains.ErrSnapshotCorrupted, header.Name)
+		}
+		files[header.Name] = content
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("%w: %s is missing", domains.ErrSnapshotCorrupted, snapshotManifestFile)
+	}
+	for name := range expected {
+		if _, ok := files[name]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s is missing", domains.ErrSnapshotCorrupted, name)
+		}
+	}
+
+	return manifest, files, nil
+}
+
+func parseManifest(content []byte) (*domains.SnapshotManifest, error) {
+	var manifest domains.SnapshotManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", domains.ErrSnapshotCorrupted, snapshotManifestFile, err)
+	}
+
+	if manifest.FormatVersion != domains.SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d, this binary reads version %d", manifest.FormatVersion, domains.SnapshotFormatVersion)
+	}
+
+	return &manifest, nil
+}
+
+func writeTarFile(archive *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := archive.Write(content)
+	return err
+}
+
+func encodeNDJSON(records []any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func decodeNDJSON[T any](content []byte, records *[]T) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		*records = append(*records, record)
+	}
+
+	return scanner.Err()
+}
+
+func toAny[T any](records []T) []any {
+	converted := make([]any, len(records))
+	for i, record := range records {
+		converted[i] = record
+	}
+
+	return converted
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
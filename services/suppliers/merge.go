@@ -0,0 +1,213 @@
+package suppliers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/services/amenities"
+	"github.com/duylamasd/hotels-merge/services/geo"
+	"github.com/duylamasd/hotels-merge/services/images"
+	"github.com/duylamasd/hotels-merge/services/policies"
+	"github.com/duylamasd/hotels-merge/services/quality"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+)
+
+type sources struct {
+	acme       *acmeHotel
+	patagonia  *patagoniaHotel
+	paperflies *paperfliesHotel
+}
+
+// Merge combines the hotels of every supplier by hotel id, preferring suppliers field by field as documented
+// in the README, and returns them ordered by hotel id.
+func (d *Data) Merge() []sqlc.UpsertHotelParams {
+	byID := map[string]*sources{}
+	get := func(id flexString) *sources {
+		key := string(id)
+		if byID[key] == nil {
+			byID[key] = &sources{}
+		}
+		return byID[key]
+	}
+
+	for i := range d.Acme {
+		get(d.Acme[i].ID).acme = &d.Acme[i]
+	}
+	for i := range d.Patagonia {
+		get(d.Patagonia[i].ID).patagonia = &d.Patagonia[i]
+	}
+	for i := range d.Paperflies {
+		get(d.Paperflies[i].HotelID).paperflies = &d.Paperflies[i]
+	}
+
+	hotels := make([]sqlc.UpsertHotelParams, 0, len(byID))
+	for id, src := range byID {
+		hotels = append(hotels, src.merge(id))
+	}
+	sort.Slice(hotels, func(i, j int) bool {
+		return hotels[i].HotelID < hotels[j].HotelID
+	})
+
+	return hotels
+}
+
+// UnmappedAmenities counts the supplier hotels using each amenity term missing from the taxonomy.
+func (d *Data) UnmappedAmenities() map[string]int {
+	taxonomy := amenities.Default()
+	counts := map[string]int{}
+	count := func(terms ...[]string) {
+		_, unmapped := taxonomy.Classify(terms...)
+		for _, term := range unmapped {
+			counts[term]++
+		}
+	}
+
+	for _, hotel := range d.Acme {
+		count(hotel.Facilities)
+	}
+	for _, hotel := range d.Patagonia {
+		count(hotel.Amenities)
+	}
+	for _, hotel := range d.Paperflies {
+		count(hotel.Amenities.General, hotel.Amenities.Room)
+	}
+
+	return counts
+}
+
+func (s *sources) merge(id string) sqlc.UpsertHotelParams {
+	var acme acmeHotel
+	if s.acme != nil {
+		acme = *s.acme
+	}
+	var patagonia patagoniaHotel
+	if s.patagonia != nil {
+		patagonia = *s.patagonia
+	}
+	var paperflies paperfliesHotel
+	if s.paperflies != nil {
+		paperflies = *s.paperflies
+	}
+
+	taxonomy := amenities.Default()
+	canonical, _ := taxonomy.Classify(
+		paperflies.Amenities.General, acme.Facilities,
+		paperflies.Amenities.Room, patagonia.Amenities,
+	)
+
+	raw := &dto.HotelImages{}
+	for _, image := range patagonia.Images.Rooms {
+		raw.Rooms = append(raw.Rooms, dto.HotelImage{Link: image.URL, Description: image.Description})
+	}
+	for _, image := range paperflies.Images.Rooms {
+		raw.Rooms = append(raw.Rooms, dto.HotelImage{Link: image.Link, Description: image.Caption})
+	}
+	for _, image := range paperflies.Images.Site {
+		raw.Site = append(raw.Site, dto.HotelImage{Link: image.Link, Description: image.Caption})
+	}
+	for _, image := range patagonia.Images.Amenities {
+		raw.Amenities = append(raw.Amenities, dto.HotelImage{Link: image.URL, Description: image.Description})
+	}
+
+	description, bookingConditions, rawText := sanitize.Clean(
+		optional(firstString(paperflies.Details, deref(patagonia.Info), acme.Description)),
+		cleanStrings(paperflies.BookingConditions),
+	)
+	location, countryCode := geo.Normalize(&dto.HotelLocation{
+		Latitude:   firstFloat(acme.Latitude, patagonia.Lat),
+		Longitude:  firstFloat(acme.Longitude, patagonia.Lng),
+		Address:    optional(firstString(deref(patagonia.Address), paperflies.Location.Address, acme.Address)),
+		City:       optional(firstString(acme.City)),
+		Country:    optional(firstString(paperflies.Location.Country, acme.Country)),
+		PostalCode: optional(firstString(acme.PostalCode)),
+	})
+	location, locationQuality := geo.Check(location, countryCode)
+
+	hotel := sqlc.UpsertHotelParams{
+		HotelID:       id,
+		DestinationID: firstString(string(acme.DestinationID), string(patagonia.Destination), string(paperflies.DestinationID)),
+		Name:          firstString(acme.Name, patagonia.Name, paperflies.HotelName),
+		Location:      location,
+		CountryCode:   countryCode,
+		DataQuality:   &dto.DataQuality{Location: locationQuality, Suppliers: s.suppliers()},
+		Description:   description,
+		Images:        images.Normalize(raw),
+		Amenities: &dto.HotelAmenities{
+			General:         cleanStrings(paperflies.Amenities.General),
+			Room:            cleanStrings(paperflies.Amenities.Room),
+			Canonical:       canonical,
+			TaxonomyVersion: taxonomy.Version,
+		},
+		BookingConditions: bookingConditions,
+		Policies:          policies.Parse(sanitize.Texts(bookingConditions)),
+		RawText:           rawText,
+	}
+	hotel.DataQuality = quality.Score(hotel)
+
+	return hotel
+}
+
+// suppliers names the suppliers with a hotel, in the order of their constants.
+func (s *sources) suppliers() []string {
+	var names []string
+	if s.acme != nil {
+		names = append(names, Acme)
+	}
+	if s.patagonia != nil {
+		names = append(names, Patagonia)
+	}
+	if s.paperflies != nil {
+		names = append(names, Paperflies)
+	}
+
+	return names
+}
+
+func firstString(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func firstFloat(values ...flexFloat) *float64 {
+	for _, value := range values {
+		if value.value != nil {
+			return value.value
+		}
+	}
+
+	return nil
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func cleanStrings(values []string) []string {
+	cleaned := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			cleaned = append(cleaned, value)
+		}
+	}
+
+	return cleaned
+}
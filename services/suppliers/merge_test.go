@@ -0,0 +1,163 @@
+package suppliers_test
+
+import (
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/services/suppliers"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const acmeBody = `[
+  {"Id": "iJhz", "DestinationId": 5432, "Name": "Beach Villas Singapore", "Latitude": 1.264751, "Longitude": 103.824006,
+   "Address": " 8 Sentosa Gateway, Beach Villas ", "City": "Singapore", "Country": "SG", "Description": "  This 5 star hotel  ",
+   "Facilities": ["Pool", "BusinessCenter", "WiFi ", "Sauna"]},
+  {"Id": "f8c9", "DestinationId": 1122, "Name": "Hilton Shinjuku Tokyo", "Latitude": "", "Longitude": "",
+   "Address": "160-0023, SHINJUKU-KU, 6-6-2 NISHI-SHINJUKU", "City": "Tokyo", "Country": "JP", "Description": null}
+]`
+
+const patagoniaBody = `[
+  {"id": "iJhz", "destination": 5432, "name": "Beach Villas Singapore", "lat": 1.264751, "lng": 103.824006,
+   "address": "8 Sentosa Gateway, Beach Villas, 098269", "info": "Located at the western tip of Resorts World Sentosa",
+   "amenities": ["Aircon", "Tv"],
+   "images": {
+     "rooms": [{"url": "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", "description": "Double room"}],
+     "amenities": [{"url": "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/0.jpg", "description": "RWS"}]
+   }},
+  {"id": "f8c9", "destination": 1122, "name": "Hilton Tokyo", "lat": 35.6926, "lng": 139.690965,
+   "address": null, "info": null, "amenities": null, "images": {"rooms": [], "amenities": []}}
+]`
+
+const paperfliesBody = `[
+  {"hotel_id": "iJhz", "destination_id": 5432, "hotel_name": "Beach Villas Singapore",
+   "location": {"address": "8 Sentosa Gateway, Beach Villas, 098269", "country": "Singapore"},
+   "details": "Surrounded by tropical&nbsp;gardens ",
+   "amenities": {"general": ["outdoor pool", " indoor pool "], "room": ["tv", "coffee machine"]},
+   "images": {
+     "rooms": [
+       {"link": "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", "caption": "Double room"},
+       {"link": "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/3.jpg", "caption": "Double room"}
+     ],
+     "site": [{"link": "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/1.jpg", "caption": "Front"}]
+   },
+   "booking_conditions": ["All children are welcome.", "", "<p>All children are welcome!</p>"]}
+]`
+
+func parse(t *testing.T) *suppliers.Data {
+	t.Helper()
+
+	var data suppliers.Data
+	for supplier, body := range map[string]string{
+		suppliers.Acme:       acmeBody,
+		suppliers.Patagonia:  patagoniaBody,
+		suppliers.Paperflies: paperfliesBody,
+	} {
+		_, err := data.Parse(supplier, []byte(body))
+		require.NoError(t, err, supplier)
+	}
+
+	return &data
+}
+
+func TestData_Merge(t *testing.T) {
+	hotels := parse(t).Merge()
+	require.Len(t, hotels, 2)
+
+	t.Run("should order hotels by hotel id", func(t *testing.T) {
+		assert.Equal(t, "f8c9", hotels[0].HotelID)
+		assert.Equal(t, "iJhz", hotels[1].HotelID)
+	})
+
+	t.Run("should prefer suppliers field by field", func(t *testing.T) {
+		hotel := hotels[1]
+
+		assert.Equal(t, "5432", hotel.DestinationID)
+		assert.Equal(t, "Beach Villas Singapore", hotel.Name)
+		assert.Equal(t, "8 Sentosa Gateway, Beach Villas, 098269", *hotel.Location.Address)
+		assert.Equal(t, "Singapore", *hotel.Location.City)
+		assert.Equal(t, "Singapore", *hotel.Location.Country)
+		assert.Equal(t, "098269", *hotel.Location.PostalCode)
+		assert.Equal(t, "SG", *hotel.CountryCode)
+		assert.Equal(t, dto.LocationOK, hotel.DataQuality.Location.Status)
+		assert.Equal(t, "Surrounded by tropical gardens", *hotel.Description)
+		assert.Equal(t, []string{"outdoor pool", "indoor pool"}, hotel.Amenities.General)
+		assert.Equal(t, []string{"All children are welcome."}, hotel.BookingConditions)
+	})
+
+	t.Run("should merge unique images", func(t *testing.T) {
+		images := hotels[1].Images
+
+		assert.Equal(t, []dto.HotelImage{
+			{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room"},
+			{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/3.jpg", Description: "Double room"},
+		}, images.Rooms)
+		assert.Len(t, images.Site, 1)
+		assert.Len(t, images.Amenities, 1)
+	})
+
+	t.Run("should fall back when acme coordinates are empty", func(t *testing.T) {
+		hotel := hotels[0]
+
+		assert.Equal(t, "Hilton Shinjuku Tokyo", hotel.Name)
+		assert.Equal(t, 35.6926, *hotel.Location.Latitude)
+		assert.Equal(t, 139.690965, *hotel.Location.Longitude)
+		assert.Equal(t, "160-0023, SHINJUKU-KU, 6-6-2 NISHI-SHINJUKU", *hotel.Location.Address)
+		assert.Equal(t, "Japan", *hotel.Location.Country)
+		assert.Equal(t, "160-0023", *hotel.Location.PostalCode)
+		assert.Equal(t, "JP", *hotel.CountryCode)
+		assert.Equal(t, dto.LocationOK, hotel.DataQuality.Location.Status)
+		assert.Nil(t, hotel.Description)
+		assert.Empty(t, hotel.BookingConditions)
+	})
+
+	t.Run("should sanitize prose and keep the raw text", func(t *testing.T) {
+		hotel := hotels[1]
+
+		assert.Equal(t, "Surrounded by tropical&nbsp;gardens", *hotel.RawText.Description)
+		assert.Equal(t, []string{"All children are welcome.", "<p>All children are welcome!</p>"}, hotel.RawText.BookingConditions)
+		assert.True(t, *hotel.Policies.Children.Allowed)
+	})
+
+	t.Run("should score the merged hotels and record their suppliers", func(t *testing.T) {
+		complete, sparse := hotels[1].DataQuality, hotels[0].DataQuality
+
+		assert.Greater(t, *complete.Score, *sparse.Score)
+		assert.Empty(t, complete.Missing)
+		assert.Equal(t, []string{suppliers.Acme, suppliers.Patagonia, suppliers.Paperflies}, complete.Suppliers)
+		assert.Equal(t, []string{"description", "images", "amenities", "booking_conditions"}, sparse.Missing)
+		assert.Equal(t, []string{suppliers.Acme, suppliers.Patagonia}, sparse.Suppliers)
+	})
+}
+
+func TestData_Parse(t *testing.T) {
+	t.Run("should reject an unknown supplier", func(t *testing.T) {
+		var data suppliers.Data
+		_, err := data.Parse("unknown", []byte("[]"))
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject invalid coordinates", func(t *testing.T) {
+		var data suppliers.Data
+		_, err := data.Parse(suppliers.Acme, []byte(`[{"Id": "a", "Latitude": "north"}]`))
+		assert.Error(t, err)
+	})
+}
+
+func TestData_MergeAmenities(t *testing.T) {
+	data := parse(t)
+	hotel := data.Merge()[1]
+
+	t.Run("should map the amenities of every supplier onto the taxonomy", func(t *testing.T) {
+		var codes []string
+		for _, amenity := range hotel.Amenities.Canonical {
+			codes = append(codes, amenity.Code)
+		}
+		assert.Equal(t, []string{"outdoor_pool", "indoor_pool", "pool", "business_center", "wifi", "tv", "coffee_machine", "air_conditioning"}, codes)
+		assert.Equal(t, dto.HotelAmenity{Code: "wifi", Label: "Wi-Fi", Category: "connectivity"}, hotel.Amenities.Canonical[4])
+	})
+
+	t.Run("should report unmapped terms", func(t *testing.T) {
+		assert.Equal(t, map[string]int{"sauna": 1}, data.UnmappedAmenities())
+	})
+}
@@ -0,0 +1,205 @@
+// Package suppliers fetches the raw hotels of each supplier and merges them into hotel records.
+// It ports the merge rules of the crawler, which lives in its own repository, because the ingest
+// command runs maintenance from the service image without it. Rule changes belong in both.
+package suppliers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	Acme       = "acme"
+	Patagonia  = "patagonia"
+	Paperflies = "paperflies"
+)
+
+// maxResponseSize bounds the body read from a supplier.
+const maxResponseSize = 64 << 20
+
+// Fetch reads the body at rawURL, which is either an http(s) URL or a file:// URL for local dumps.
+func Fetch(ctx context.Context, client *http.Client, rawURL string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return os.ReadFile(parsed.Path)
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", parsed.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+}
+
+// flexString decodes a JSON string or number, as suppliers disagree on the type of identifiers.
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*s = ""
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*s = flexString(strings.TrimSpace(value))
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*s = flexString(number.String())
+	return nil
+}
+
+// flexFloat decodes a JSON number, a numeric string, an empty string or null, the last two meaning unknown.
+type flexFloat struct {
+	value *float64
+}
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	f.value = nil
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	raw := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			return nil
+		}
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	f.value = &value
+	return nil
+}
+
+type acmeHotel struct {
+	ID            flexString `json:"Id"`
+	DestinationID flexString `json:"DestinationId"`
+	Name          string     `json:"Name"`
+	Latitude      flexFloat  `json:"Latitude"`
+	Longitude     flexFloat  `json:"Longitude"`
+	Address       string     `json:"Address"`
+	City          string     `json:"City"`
+	Country       string     `json:"Country"`
+	PostalCode    string     `json:"PostalCode"`
+	Description   string     `json:"Description"`
+	Facilities    []string   `json:"Facilities"`
+}
+
+type patagoniaImage struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+type patagoniaHotel struct {
+	ID          flexString `json:"id"`
+	Destination flexString `json:"destination"`
+	Name        string     `json:"name"`
+	Lat         flexFloat  `json:"lat"`
+	Lng         flexFloat  `json:"lng"`
+	Address     *string    `json:"address"`
+	Info        *string    `json:"info"`
+	Amenities   []string   `json:"amenities"`
+	Images      struct {
+		Rooms     []patagoniaImage `json:"rooms"`
+		Amenities []patagoniaImage `json:"amenities"`
+	} `json:"images"`
+}
+
+type paperfliesImage struct {
+	Link    string `json:"link"`
+	Caption string `json:"caption"`
+}
+
+type paperfliesHotel struct {
+	HotelID       flexString `json:"hotel_id"`
+	DestinationID flexString `json:"destination_id"`
+	HotelName     string     `json:"hotel_name"`
+	Location      struct {
+		Address string `json:"address"`
+		Country string `json:"country"`
+	} `json:"location"`
+	Details   string `json:"details"`
+	Amenities struct {
+		General []string `json:"general"`
+		Room    []string `json:"room"`
+	} `json:"amenities"`
+	Images struct {
+		Rooms []paperfliesImage `json:"rooms"`
+		Site  []paperfliesImage `json:"site"`
+	} `json:"images"`
+	BookingConditions []string `json:"booking_conditions"`
+}
+
+// Data holds the raw hotels of every supplier.
+type Data struct {
+	Acme       []acmeHotel
+	Patagonia  []patagoniaHotel
+	Paperflies []paperfliesHotel
+}
+
+// Parse decodes the body fetched from supplier into data.
+func (d *Data) Parse(supplier string, body []byte) (int, error) {
+	switch supplier {
+	case Acme:
+		return decode(body, &d.Acme)
+	case Patagonia:
+		return decode(body, &d.Patagonia)
+	case Paperflies:
+		return decode(body, &d.Paperflies)
+	default:
+		return 0, fmt.Errorf("unknown supplier %q", supplier)
+	}
+}
+
+func decode[T any](body []byte, hotels *[]T) (int, error) {
+	var decoded []T
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return 0, err
+	}
+
+	*hotels = append(*hotels, decoded...)
+	return len(decoded), nil
+}
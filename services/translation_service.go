@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services/amenities"
+	"github.com/duylamasd/hotels-merge/services/i18n"
+	"github.com/duylamasd/hotels-merge/services/sanitize"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"go.uber.org/zap"
+)
+
+type translationService struct {
+	logger *zap.Logger
+	db     *config.DBStore
+}
+
+func NewTranslationService(logger *zap.Logger, db *config.DBStore) domains.TranslationService {
+	return &translationService{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (s *translationService) Localize(ctx context.Context, hotels []*sqlc.Hotel, chain []string) ([]*sqlc.Hotel, []string, error) {
+	if len(hotels) == 0 {
+		return hotels, nil, nil
+	}
+	if len(chain) == 0 || chain[0] == i18n.DefaultLocale {
+		return hotels, []string{i18n.DefaultLocale}, nil
+	}
+
+	hotelIDs := make([]string, len(hotels))
+	for i, hotel := range hotels {
+		hotelIDs[i] = hotel.HotelID
+	}
+	translations, err := s.db.Reader().ListHotelTranslations(ctx, hotelIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	byHotelID := map[string][]*sqlc.HotelTranslation{}
+	for _, translation := range translations {
+		byHotelID[translation.HotelID] = append(byHotelID[translation.HotelID], translation)
+	}
+
+	localized := make([]*sqlc.Hotel, len(hotels))
+	served := []string{}
+	for i, hotel := range hotels {
+		var locale string
+		localized[i], locale = i18n.Localize(hotel, byHotelID[hotel.HotelID], chain)
+		if !slices.Contains(served, locale) {
+			served = append(served, locale)
+		}
+	}
+
+	return localized, served, nil
+}
+
+func (s *translationService) List(ctx context.Context, hotelID string) ([]*sqlc.HotelTranslation, error) {
+	hotel, err := s.db.Queries.FindHotelByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.db.Queries.ListHotelTranslations(ctx, []string{hotel.HotelID})
+	if err != nil {
+		return nil, err
+	}
+	if translations == nil {
+		return []*sqlc.HotelTranslation{}, nil
+	}
+
+	return translations, nil
+}
+
+func (s *translationService) Upsert(ctx context.Context, translation sqlc.UpsertHotelTranslationParams) (*sqlc.HotelTranslation, error) {
+	locale, err := translationLocale(translation.Locale)
+	if err != nil {
+		return nil, err
+	}
+	translation.Locale = locale
+
+	taxonomy := amenities.Default()
+	for code := range translation.AmenityLabels {
+		if amenity, ok := taxonomy.Lookup(code); !ok || amenity.Code != code {
+			return nil, fmt.Errorf("%w %q", domains.ErrUnknownAmenity, code)
+		}
+	}
+	if translation.AmenityLabels == nil {
+		translation.AmenityLabels = dto.AmenityLabels{}
+	}
+	if translation.Name != nil {
+		if name := strings.TrimSpace(*translation.Name); name != "" {
+			translation.Name = &name
+		} else {
+			translation.Name = nil
+		}
+	}
+	translation.Description, translation.BookingConditions, _ = sanitize.Clean(translation.Description, translation.BookingConditions)
+
+	var stored *sqlc.HotelTranslation
+	err = s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		hotel, err := queries.FindHotelByHotelID(ctx, translation.HotelID)
+		if err != nil {
+			return err
+		}
+
+		params := translation
+		params.HotelID = hotel.HotelID
+		if err := queries.UpsertHotelTranslation(ctx, params); err != nil {
+			return err
+		}
+
+		stored, err = queries.FindHotelTranslation(ctx, sqlc.FindHotelTranslationParams{HotelID: params.HotelID, Locale: params.Locale})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lib.LoggerFromContext(ctx, s.logger).Info("Stored hotel translation",
+		zap.String("hotel_id", stored.HotelID),
+		zap.String("locale", stored.Locale),
+	)
+	return stored, nil
+}
+
+func (s *translationService) Delete(ctx context.Context, hotelID string, locale string) error {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.db.InTx(ctx, func(queries sqlc.Querier) error {
+		hotel, err := queries.FindHotelByHotelID(ctx, hotelID)
+		if err != nil {
+			return err
+		}
+
+		key := sqlc.FindHotelTranslationParams{HotelID: hotel.HotelID, Locale: locale}
+		if _, err := queries.FindHotelTranslation(ctx, key); err != nil {
+			return err
+		}
+		return queries.DeleteHotelTranslation(ctx, sqlc.DeleteHotelTranslationParams(key))
+	})
+}
+
+// translationLocale returns the canonical form of a translation locale.
+func translationLocale(locale string) (string, error) {
+	canonical, err := i18n.Canonical(locale)
+	if err != nil || canonical == i18n.DefaultLocale {
+		return "", domains.ErrInvalidLocale
+	}
+
+	return canonical, nil
+}
@@ -11,15 +11,100 @@ type HotelImages struct {
 	Amenities []HotelImage `json:"amenities"`
 }
 
+type HotelAmenity struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Category string `json:"category"`
+}
+
 type HotelAmenities struct {
 	General []string `json:"general"`
 	Room    []string `json:"room"`
+	// Canonical are the amenities mapped onto the amenity taxonomy of TaxonomyVersion.
+	Canonical       []HotelAmenity `json:"canonical,omitempty"`
+	TaxonomyVersion int            `json:"taxonomy_version,omitempty"`
 }
 
 type HotelLocation struct {
-	Latitude  *float64 `json:"latitude"`
-	Longitude *float64 `json:"longitude"`
-	Address   *string  `json:"address"`
-	City      *string  `json:"city"`
-	Country   *string  `json:"country"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	Address    *string  `json:"address"`
+	City       *string  `json:"city"`
+	Country    *string  `json:"country"`
+	PostalCode *string  `json:"postal_code"`
+}
+
+// Policy values of wifi and parking.
+const (
+	PolicyFree        = "free"
+	PolicyPaid        = "paid"
+	PolicyUnavailable = "unavailable"
+)
+
+type ChildPolicy struct {
+	Allowed *bool `json:"allowed"`
+	// FreeUnderAge is the age under which a child stays free of charge.
+	FreeUnderAge *int `json:"free_under_age"`
+}
+
+type CheckInPolicy struct {
+	PhotoIDRequired    *bool `json:"photo_id_required"`
+	CreditCardRequired *bool `json:"credit_card_required"`
+}
+
+// HotelPolicies are derived from the booking conditions. Nil fields are not stated by them.
+type HotelPolicies struct {
+	PetsAllowed        *bool         `json:"pets_allowed"`
+	Wifi               *string       `json:"wifi"`
+	Parking            *string       `json:"parking"`
+	Children           ChildPolicy   `json:"children"`
+	PrepaymentRequired *bool         `json:"prepayment_required"`
+	CheckIn            CheckInPolicy `json:"check_in"`
 }
+
+// Location statuses of the data quality, from a location matching its city or country to none at all.
+const (
+	LocationOK         = "ok"
+	LocationRepaired   = "repaired"
+	LocationUnverified = "unverified"
+	LocationSuspect    = "suspect"
+	LocationInvalid    = "invalid"
+	LocationMissing    = "missing"
+)
+
+// Location issues of the data quality.
+const (
+	IssuePartialCoordinates = "partial_coordinates"
+	IssueOutOfRange         = "out_of_range"
+	IssueNullIsland         = "null_island"
+	IssueSwappedAxes        = "swapped_axes"
+	IssueFarFromCity        = "far_from_city"
+	IssueOutsideCountry     = "outside_country"
+)
+
+type LocationQuality struct {
+	Status string   `json:"status"`
+	Issues []string `json:"issues"`
+	// DistanceKm is the distance between the coordinates and the center of the stated city, when it is known.
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+// DataQuality records the checks run on a hotel when it was stored.
+type DataQuality struct {
+	Location LocationQuality `json:"location"`
+	// Score rates the completeness of the hotel from 0 to 100. It is nil for hotels stored before scoring.
+	Score *int `json:"score"`
+	// Missing lists the fields the score found empty.
+	Missing []string `json:"missing"`
+	// Suppliers are the suppliers the hotel was merged from.
+	Suppliers []string `json:"suppliers,omitempty"`
+}
+
+// RawText keeps the description and booking conditions as the supplier wrote them, before sanitization.
+type RawText struct {
+	Description       *string  `json:"description"`
+	BookingConditions []string `json:"booking_conditions"`
+}
+
+// AmenityLabels are translated labels of canonical amenities, by amenity code.
+type AmenityLabels map[string]string
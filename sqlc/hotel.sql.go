@@ -7,14 +7,70 @@ package sqlc
 
 import (
 	"context"
+
+	dto "github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const findHotelByHotelID = `-- name: FindHotelByHotelID :one
-SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at
+const countHotelsByDestination = `-- name: CountHotelsByDestination :many
+SELECT destination_id, COUNT(*) AS hotel_count
 FROM hotels
+GROUP BY destination_id
+`
+
+type CountHotelsByDestinationRow struct {
+	DestinationID string `json:"destination_id"`
+	HotelCount    int64  `json:"hotel_count"`
+}
+
+func (q *Queries) CountHotelsByDestination(ctx context.Context) ([]*CountHotelsByDestinationRow, error) {
+	rows, err := q.db.Query(ctx, countHotelsByDestination)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*CountHotelsByDestinationRow
+	for rows.Next() {
+		var i CountHotelsByDestinationRow
+		if err := rows.Scan(&i.DestinationID, &i.HotelCount); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countHotels = `-- name: CountHotels :one
+SELECT COUNT(*)
+FROM hotels
+`
+
+func (q *Queries) CountHotels(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countHotels)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteHotel = `-- name: DeleteHotel :exec
+DELETE FROM hotels
 WHERE hotel_id = $1
 `
 
+func (q *Queries) DeleteHotel(ctx context.Context, hotelID string) error {
+	_, err := q.db.Exec(ctx, deleteHotel, hotelID)
+	return err
+}
+
+const findHotelByHotelID = `-- name: FindHotelByHotelID :one
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
+FROM hotels
+WHERE hotel_id = COALESCE((SELECT canonical_hotel_id FROM hotel_redirects WHERE hotel_redirects.hotel_id = $1), $1)
+`
+
 func (q *Queries) FindHotelByHotelID(ctx context.Context, hotelID string) (*Hotel, error) {
 	row := q.db.QueryRow(ctx, findHotelByHotelID, hotelID)
 	var i Hotel
@@ -30,15 +86,65 @@ func (q *Queries) FindHotelByHotelID(ctx context.Context, hotelID string) (*Hote
 		&i.BookingConditions,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Policies,
+		&i.CountryCode,
+		&i.DataQuality,
+		&i.RawText,
 	)
 	return &i, err
 }
 
+const findHotelsByCountryCode = `-- name: FindHotelsByCountryCode :many
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
+FROM hotels
+WHERE country_code = $1::TEXT
+`
+
+func (q *Queries) FindHotelsByCountryCode(ctx context.Context, countryCode string) ([]*Hotel, error) {
+	rows, err := q.db.Query(ctx, findHotelsByCountryCode, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Hotel
+	for rows.Next() {
+		var i Hotel
+		if err := rows.Scan(
+			&i.ID,
+			&i.HotelID,
+			&i.DestinationID,
+			&i.Name,
+			&i.Location,
+			&i.Description,
+			&i.Images,
+			&i.Amenities,
+			&i.BookingConditions,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Policies,
+			&i.CountryCode,
+			&i.DataQuality,
+			&i.RawText,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findHotelsByDestinationAndHotelIDs = `-- name: FindHotelsByDestinationAndHotelIDs :many
-SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
 FROM hotels
 WHERE destination_id = $1
-  AND hotel_id = ANY($2::TEXT[])
+  AND hotel_id IN (
+    SELECT COALESCE(hotel_redirects.canonical_hotel_id, requested.hotel_id)
+    FROM UNNEST($2::TEXT[]) AS requested(hotel_id)
+    LEFT JOIN hotel_redirects ON hotel_redirects.hotel_id = requested.hotel_id
+  )
 `
 
 type FindHotelsByDestinationAndHotelIDsParams struct {
@@ -67,6 +173,10 @@ func (q *Queries) FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg Fi
 			&i.BookingConditions,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Policies,
+			&i.CountryCode,
+			&i.DataQuality,
+			&i.RawText,
 		); err != nil {
 			return nil, err
 		}
@@ -79,7 +189,7 @@ func (q *Queries) FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg Fi
 }
 
 const findHotelsByDestinationID = `-- name: FindHotelsByDestinationID :many
-SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
 FROM hotels
 WHERE destination_id = $1
 `
@@ -105,6 +215,10 @@ func (q *Queries) FindHotelsByDestinationID(ctx context.Context, destinationID s
 			&i.BookingConditions,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Policies,
+			&i.CountryCode,
+			&i.DataQuality,
+			&i.RawText,
 		); err != nil {
 			return nil, err
 		}
@@ -117,9 +231,13 @@ func (q *Queries) FindHotelsByDestinationID(ctx context.Context, destinationID s
 }
 
 const findHotelsByHotelIDs = `-- name: FindHotelsByHotelIDs :many
-SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
 FROM hotels
-WHERE hotel_id = ANY($1::TEXT[])
+WHERE hotel_id IN (
+  SELECT COALESCE(hotel_redirects.canonical_hotel_id, requested.hotel_id)
+  FROM UNNEST($1::TEXT[]) AS requested(hotel_id)
+  LEFT JOIN hotel_redirects ON hotel_redirects.hotel_id = requested.hotel_id
+)
 `
 
 func (q *Queries) FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) ([]*Hotel, error) {
@@ -143,6 +261,10 @@ func (q *Queries) FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) (
 			&i.BookingConditions,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Policies,
+			&i.CountryCode,
+			&i.DataQuality,
+			&i.RawText,
 		); err != nil {
 			return nil, err
 		}
@@ -153,3 +275,162 @@ func (q *Queries) FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) (
 	}
 	return items, nil
 }
+
+const getLastSyncedAt = `-- name: GetLastSyncedAt :one
+SELECT MAX(updated_at)::TIMESTAMPTZ AS last_synced_at
+FROM hotels
+`
+
+func (q *Queries) GetLastSyncedAt(ctx context.Context) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getLastSyncedAt)
+	var last_synced_at pgtype.Timestamptz
+	err := row.Scan(&last_synced_at)
+	return last_synced_at, err
+}
+
+const listHotels = `-- name: ListHotels :many
+SELECT id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text
+FROM hotels
+ORDER BY hotel_id
+`
+
+func (q *Queries) ListHotels(ctx context.Context) ([]*Hotel, error) {
+	rows, err := q.db.Query(ctx, listHotels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Hotel
+	for rows.Next() {
+		var i Hotel
+		if err := rows.Scan(
+			&i.ID,
+			&i.HotelID,
+			&i.DestinationID,
+			&i.Name,
+			&i.Location,
+			&i.Description,
+			&i.Images,
+			&i.Amenities,
+			&i.BookingConditions,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Policies,
+			&i.CountryCode,
+			&i.DataQuality,
+			&i.RawText,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resetHotelIDSequence = `-- name: ResetHotelIDSequence :exec
+SELECT setval(pg_get_serial_sequence('hotels', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL)
+FROM hotels
+`
+
+func (q *Queries) ResetHotelIDSequence(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, resetHotelIDSequence)
+	return err
+}
+
+const restoreHotel = `-- name: RestoreHotel :exec
+INSERT INTO hotels (id, hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, created_at, updated_at, policies, country_code, data_quality, raw_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+`
+
+type RestoreHotelParams struct {
+	ID                int32               `json:"id"`
+	HotelID           string              `json:"hotel_id"`
+	DestinationID     string              `json:"destination_id"`
+	Name              string              `json:"name"`
+	Location          *dto.HotelLocation  `json:"location"`
+	Description       *string             `json:"description"`
+	Images            *dto.HotelImages    `json:"images"`
+	Amenities         *dto.HotelAmenities `json:"amenities"`
+	BookingConditions []string            `json:"booking_conditions"`
+	CreatedAt         pgtype.Timestamptz  `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz  `json:"updated_at"`
+	Policies          *dto.HotelPolicies  `json:"policies"`
+	CountryCode       *string             `json:"country_code"`
+	DataQuality       *dto.DataQuality    `json:"data_quality"`
+	RawText           *dto.RawText        `json:"raw_text"`
+}
+
+func (q *Queries) RestoreHotel(ctx context.Context, arg RestoreHotelParams) error {
+	_, err := q.db.Exec(ctx, restoreHotel,
+		arg.ID,
+		arg.HotelID,
+		arg.DestinationID,
+		arg.Name,
+		arg.Location,
+		arg.Description,
+		arg.Images,
+		arg.Amenities,
+		arg.BookingConditions,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Policies,
+		arg.CountryCode,
+		arg.DataQuality,
+		arg.RawText,
+	)
+	return err
+}
+
+const upsertHotel = `-- name: UpsertHotel :exec
+INSERT INTO hotels (hotel_id, destination_id, name, location, description, images, amenities, booking_conditions, policies, country_code, data_quality, raw_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (hotel_id) DO UPDATE
+SET destination_id = EXCLUDED.destination_id,
+  name = EXCLUDED.name,
+  location = EXCLUDED.location,
+  description = EXCLUDED.description,
+  images = EXCLUDED.images,
+  amenities = EXCLUDED.amenities,
+  booking_conditions = EXCLUDED.booking_conditions,
+  policies = EXCLUDED.policies,
+  country_code = EXCLUDED.country_code,
+  data_quality = EXCLUDED.data_quality,
+  raw_text = EXCLUDED.raw_text,
+  updated_at = NOW()
+`
+
+type UpsertHotelParams struct {
+	HotelID           string              `json:"hotel_id"`
+	DestinationID     string              `json:"destination_id"`
+	Name              string              `json:"name"`
+	Location          *dto.HotelLocation  `json:"location"`
+	Description       *string             `json:"description"`
+	Images            *dto.HotelImages    `json:"images"`
+	Amenities         *dto.HotelAmenities `json:"amenities"`
+	BookingConditions []string            `json:"booking_conditions"`
+	Policies          *dto.HotelPolicies  `json:"policies"`
+	CountryCode       *string             `json:"country_code"`
+	DataQuality       *dto.DataQuality    `json:"data_quality"`
+	RawText           *dto.RawText        `json:"raw_text"`
+}
+
+func (q *Queries) UpsertHotel(ctx context.Context, arg UpsertHotelParams) error {
+	_, err := q.db.Exec(ctx, upsertHotel,
+		arg.HotelID,
+		arg.DestinationID,
+		arg.Name,
+		arg.Location,
+		arg.Description,
+		arg.Images,
+		arg.Amenities,
+		arg.BookingConditions,
+		arg.Policies,
+		arg.CountryCode,
+		arg.DataQuality,
+		arg.RawText,
+	)
+	return err
+}
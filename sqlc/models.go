@@ -9,6 +9,17 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Destination struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	CountryCode *string            `json:"country_code"`
+	Latitude    *float64           `json:"latitude"`
+	Longitude   *float64           `json:"longitude"`
+	Timezone    *string            `json:"timezone"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Hotel struct {
 	ID                int32               `json:"id"`
 	HotelID           string              `json:"hotel_id"`
@@ -21,4 +32,40 @@ type Hotel struct {
 	BookingConditions []string            `json:"booking_conditions"`
 	CreatedAt         pgtype.Timestamptz  `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz  `json:"updated_at"`
+	Policies          *dto.HotelPolicies  `json:"policies"`
+	CountryCode       *string             `json:"country_code"`
+	DataQuality       *dto.DataQuality    `json:"data_quality"`
+	RawText           *dto.RawText        `json:"raw_text"`
+}
+
+type HotelDuplicate struct {
+	ID               int32              `json:"id"`
+	HotelID          string             `json:"hotel_id"`
+	DuplicateHotelID string             `json:"duplicate_hotel_id"`
+	DestinationID    string             `json:"destination_id"`
+	Score            float64            `json:"score"`
+	NameScore        float64            `json:"name_score"`
+	AddressScore     *float64           `json:"address_score"`
+	DistanceKm       *float64           `json:"distance_km"`
+	Status           string             `json:"status"`
+	CanonicalHotelID *string            `json:"canonical_hotel_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type HotelRedirect struct {
+	HotelID          string             `json:"hotel_id"`
+	CanonicalHotelID string             `json:"canonical_hotel_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type HotelTranslation struct {
+	HotelID           string             `json:"hotel_id"`
+	Locale            string             `json:"locale"`
+	Name              *string            `json:"name"`
+	Description       *string            `json:"description"`
+	AmenityLabels     dto.AmenityLabels  `json:"amenity_labels"`
+	BookingConditions []string           `json:"booking_conditions"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
@@ -6,13 +6,50 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	CountDestinations(ctx context.Context) (int64, error)
+	CountHotels(ctx context.Context) (int64, error)
+	CountHotelsByDestination(ctx context.Context) ([]*CountHotelsByDestinationRow, error)
+	DecideHotelDuplicate(ctx context.Context, arg DecideHotelDuplicateParams) error
+	DeleteHotel(ctx context.Context, hotelID string) error
+	DeleteHotelTranslation(ctx context.Context, arg DeleteHotelTranslationParams) error
+	DeletePendingHotelDuplicates(ctx context.Context) error
+	DeletePendingHotelDuplicatesOfHotel(ctx context.Context, hotelID string) error
+	FindDestination(ctx context.Context, id string) (*FindDestinationRow, error)
 	FindHotelByHotelID(ctx context.Context, hotelID string) (*Hotel, error)
+	FindHotelDuplicate(ctx context.Context, id int32) (*HotelDuplicate, error)
+	FindHotelTranslation(ctx context.Context, arg FindHotelTranslationParams) (*HotelTranslation, error)
+	FindHotelsByCountryCode(ctx context.Context, countryCode string) ([]*Hotel, error)
 	FindHotelsByDestinationAndHotelIDs(ctx context.Context, arg FindHotelsByDestinationAndHotelIDsParams) ([]*Hotel, error)
 	FindHotelsByDestinationID(ctx context.Context, destinationID string) ([]*Hotel, error)
 	FindHotelsByHotelIDs(ctx context.Context, hotelIds []string) ([]*Hotel, error)
+	GetLastSyncedAt(ctx context.Context) (pgtype.Timestamptz, error)
+	// Destinations of hotels are named after their most common city, in their most common country, centered
+	// on their checked coordinates. Existing destinations are left alone.
+	InsertMissingDestinations(ctx context.Context) error
+	ListAllHotelTranslations(ctx context.Context) ([]*HotelTranslation, error)
+	ListDestinations(ctx context.Context, arg ListDestinationsParams) ([]*ListDestinationsRow, error)
+	ListHotelDuplicates(ctx context.Context, status string) ([]*HotelDuplicate, error)
+	ListHotelRedirects(ctx context.Context) ([]*HotelRedirect, error)
+	ListHotelTranslations(ctx context.Context, hotelIds []string) ([]*HotelTranslation, error)
+	ListHotels(ctx context.Context) ([]*Hotel, error)
+	RepointHotelRedirects(ctx context.Context, arg RepointHotelRedirectsParams) error
+	ResetHotelDuplicateIDSequence(ctx context.Context) error
+	ResetHotelIDSequence(ctx context.Context) error
+	RestoreDestination(ctx context.Context, arg RestoreDestinationParams) error
+	RestoreHotel(ctx context.Context, arg RestoreHotelParams) error
+	RestoreHotelDuplicate(ctx context.Context, arg RestoreHotelDuplicateParams) error
+	RestoreHotelRedirect(ctx context.Context, arg RestoreHotelRedirectParams) error
+	RestoreHotelTranslation(ctx context.Context, arg RestoreHotelTranslationParams) error
+	UpsertDestination(ctx context.Context, arg UpsertDestinationParams) error
+	UpsertHotel(ctx context.Context, arg UpsertHotelParams) error
+	UpsertHotelDuplicate(ctx context.Context, arg UpsertHotelDuplicateParams) error
+	UpsertHotelRedirect(ctx context.Context, arg UpsertHotelRedirectParams) error
+	UpsertHotelTranslation(ctx context.Context, arg UpsertHotelTranslationParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,77 @@
+package e2e_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/db"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCountryCodeBackfill runs the backfill migration again on hotels without a country code, as stored
+// before the column was added, in a transaction rolled back afterwards.
+func TestCountryCodeBackfill(t *testing.T) {
+	uri := os.Getenv("DB_URI")
+	if uri == "" {
+		t.Skip("DB_URI is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, uri)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	migrations, err := db.Migrations()
+	require.NoError(t, err)
+	var backfill string
+	for _, migration := range migrations {
+		if migration.Version == "20261019160000" {
+			backfill = migration.Up
+		}
+	}
+	require.NotEmpty(t, backfill)
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback(ctx)
+		// setval is not transactional, so the sequence is put back past the remaining ids.
+		_ = sqlc.New(pool).ResetHotelIDSequence(ctx)
+	}()
+	_, err = tx.Exec(ctx, "DELETE FROM hotels")
+	require.NoError(t, err)
+	queries := sqlc.New(tx)
+
+	for hotelID, country := range map[string]string{"backfill-1": " singapore ", "backfill-2": "Côte d'Ivoire", "backfill-3": "Atlantis"} {
+		err := queries.UpsertHotel(ctx, sqlc.UpsertHotelParams{
+			HotelID:       hotelID,
+			DestinationID: "backfill-dest",
+			Name:          hotelID,
+			Location:      &dto.HotelLocation{Country: &country},
+		})
+		require.NoError(t, err)
+	}
+
+	hotels, err := queries.FindHotelsByCountryCode(ctx, "SG")
+	require.NoError(t, err)
+	require.Empty(t, hotels, "hotels stored without a country code should not match before the backfill")
+
+	_, err = tx.Exec(ctx, backfill)
+	require.NoError(t, err)
+
+	for code, hotelID := range map[string]string{"SG": "backfill-1", "CI": "backfill-2"} {
+		hotels, err := queries.FindHotelsByCountryCode(ctx, code)
+		require.NoError(t, err)
+		require.Len(t, hotels, 1)
+		assert.Equal(t, hotelID, hotels[0].HotelID)
+	}
+
+	hotel, err := queries.FindHotelByHotelID(ctx, "backfill-3")
+	require.NoError(t, err)
+	assert.Nil(t, hotel.CountryCode, "an unknown country should keep no code")
+}
@@ -67,8 +67,8 @@ func TestGetV1Hotels(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&body)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, body.Code)
-		assert.Equal(t, body.Message, "Either destination or list of hotel ids need to be provided")
+		assert.Equal(t, http.StatusBadRequest, body.Status)
+		assert.Equal(t, body.Detail, "Either destination, list of hotel ids or country code need to be provided")
 	})
 
 	t.Run("GET /api/v1/hotels returns 200 with destination_id", func(t *testing.T) {
@@ -103,7 +103,7 @@ func TestGetV1Hotels(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&body)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, body.Code)
+		assert.Equal(t, http.StatusBadRequest, body.Status)
 	})
 
 	t.Run("GET /api/v1/hotels returns 400 with invalid destination_id", func(t *testing.T) {
@@ -116,6 +116,6 @@ func TestGetV1Hotels(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&body)
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, body.Code)
+		assert.Equal(t, http.StatusBadRequest, body.Status)
 	})
 }
@@ -0,0 +1,47 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	testApp, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	t.Run("GET /healthz returns 200", func(t *testing.T) {
+		resp, err := http.Get(testApp.Server.URL + "/healthz")
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("GET /readyz returns 200 with a migrated database", func(t *testing.T) {
+		resp, err := http.Get(testApp.Server.URL + "/readyz")
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body domains.ReadinessReport
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, domains.HealthStatusOK, body.Status)
+	})
+
+	t.Run("GET /status returns pool stats and build info", func(t *testing.T) {
+		resp, err := http.Get(testApp.Server.URL + "/status")
+		assert.NoError(t, err)
+
+		var body domains.StatusReport
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.NotNil(t, body.Pool)
+		assert.NotEmpty(t, body.Build.Version)
+	})
+}
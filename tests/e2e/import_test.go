@@ -0,0 +1,76 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	apiDomains "github.com/duylamasd/hotels-merge/api/domains"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostV1AdminHotelsImport(t *testing.T) {
+	t.Setenv("AUTH_ADMIN_TOKEN", "e2e-admin-token")
+
+	testApp, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	post := func(token, body string) *http.Response {
+		req, err := http.NewRequest("POST", testApp.Server.URL+"/api/v1/admin/hotels:import", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	body := `{"hotel_id":"import-1","destination_id":"import-dest","name":"Imported One","location":{"latitude":1.3,"longitude":103.8,"address":null,"city":null,"country":null}}
+{"hotel_id":"import-2","destination_id":"import-dest","name":"Imported Two","booking_conditions":["No pets"]}
+`
+
+	t.Run("returns 401 without the admin token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, post("", body).StatusCode)
+	})
+
+	t.Run("inserts then updates hotels", func(t *testing.T) {
+		for _, expected := range []domains.ImportResult{{Imported: 2, Inserted: 2}, {Imported: 2, Updated: 2}} {
+			resp := post("e2e-admin-token", body)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var result domains.ImportResult
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, expected.Imported, result.Imported)
+			assert.Equal(t, expected.Inserted, result.Inserted)
+			assert.Equal(t, expected.Updated, result.Updated)
+		}
+	})
+
+	t.Run("returns 422 with the invalid lines", func(t *testing.T) {
+		resp := post("e2e-admin-token", body+`{"hotel_id":"import-3","destination_id":"import-dest"}`+"\n")
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var problem apiDomains.HttpError
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		require.Len(t, problem.Records, 1)
+		assert.Equal(t, 3, problem.Records[0].Line)
+	})
+
+	t.Run("returns 404 for unknown custom methods", func(t *testing.T) {
+		req, err := http.NewRequest("POST", testApp.Server.URL+"/api/v1/admin/hotels:purge", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer e2e-admin-token")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,34 @@
+package e2e_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/bootstrap"
+	"github.com/duylamasd/hotels-merge/db"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	var migrationService domains.MigrationService
+	app := fxtest.New(t, bootstrap.CoreModules, fx.Provide(services.NewMigrationService), fx.Populate(&migrationService))
+	app.RequireStart()
+	defer app.RequireStop()
+
+	ctx := context.Background()
+
+	status, err := migrationService.Status(ctx)
+	require.NoError(t, err)
+
+	latest, err := db.LatestVersion()
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, status.Pending)
+	assert.Equal(t, latest, status.CurrentVersion)
+	assert.NoError(t, migrationService.CheckSchema(ctx))
+}
@@ -0,0 +1,39 @@
+package e2e_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/db/querytest"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQuerierContract runs every subtest in a transaction rolled back afterwards, so the other tests keep their data.
+func TestQuerierContract(t *testing.T) {
+	uri := os.Getenv("DB_URI")
+	if uri == "" {
+		t.Skip("DB_URI is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, uri)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	querytest.Run(t, func(t *testing.T) sqlc.Querier {
+		tx, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = tx.Rollback(ctx)
+			// setval is not transactional, so the sequence is put back past the remaining ids.
+			_ = sqlc.New(pool).ResetHotelIDSequence(ctx)
+		})
+
+		_, err = tx.Exec(ctx, "DELETE FROM hotels; DELETE FROM hotel_duplicates; DELETE FROM hotel_redirects; DELETE FROM hotel_translations; DELETE FROM destinations")
+		require.NoError(t, err)
+		return sqlc.New(tx)
+	})
+}
@@ -0,0 +1,63 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadReplicaRouting runs against two independent databases standing in for a primary and a replica,
+// so a hotel inserted only in the replica proves reads are served by it.
+func TestReadReplicaRouting(t *testing.T) {
+	replicaURI, _, _ := strings.Cut(os.Getenv("DB_REPLICA_URIS"), ",")
+	if replicaURI == "" {
+		t.Skip("DB_REPLICA_URIS is not set")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, replicaURI)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, `INSERT INTO hotels (hotel_id, destination_id, name) VALUES ('replica_only', 'replica_dest', 'Replica Hotel')`)
+	require.NoError(t, err)
+	defer func() {
+		_, _ = conn.Exec(ctx, `DELETE FROM hotels WHERE hotel_id = 'replica_only'`)
+	}()
+
+	testApp, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	t.Run("GET /api/v1/hotels reads from the replica", func(t *testing.T) {
+		resp, err := http.Get(testApp.Server.URL + "/api/v1/hotels?hotel_ids=replica_only")
+		require.NoError(t, err)
+
+		var hotels []*sqlc.Hotel
+		err = json.NewDecoder(resp.Body).Decode(&hotels)
+		assert.NoError(t, err)
+
+		require.Len(t, hotels, 1)
+		assert.Equal(t, "Replica Hotel", hotels[0].Name)
+	})
+
+	t.Run("GET /status reports the replica", func(t *testing.T) {
+		resp, err := http.Get(testApp.Server.URL + "/status")
+		require.NoError(t, err)
+
+		var body domains.StatusReport
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		require.NotEmpty(t, body.Replicas)
+		assert.Equal(t, domains.HealthStatusOK, body.Replicas[0].Status)
+	})
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestDestinationService(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	db := config.NewMemoryDBStore(memory.New())
+	hotelDataService := services.NewHotelDataService(logger, db, services.NewHotelChanges())
+	destinationService := services.NewDestinationService(logger, config.Default(), db)
+
+	input := `{"hotel_id":"iJhz","destination_id":"5432","name":"Beach Villas Singapore","location":{"latitude":1.264751,"longitude":103.824006,"city":"Singapore","country":"SG"}}
+{"hotel_id":"f8c9","destination_id":"5432","name":"InterContinental Robertson Quay","location":{"latitude":1.2893,"longitude":103.84,"city":"Singapore","country":"Singapore"}}
+{"hotel_id":"k3l4","destination_id":"1122","name":"Hilton Tokyo Shinjuku","location":{"city":"Tokyo","country":"Japan"}}
+`
+	_, err := hotelDataService.Import(ctx, strings.NewReader(input))
+	require.NoError(t, err)
+
+	t.Run("should derive the destinations of imported hotels", func(t *testing.T) {
+		destination, err := destinationService.Find(ctx, "5432")
+		require.NoError(t, err)
+		assert.Equal(t, "Singapore", destination.Name)
+		assert.Equal(t, "SG", *destination.CountryCode)
+		assert.InDelta(t, 1.2770255, *destination.Latitude, 1e-9)
+		assert.InDelta(t, 103.832003, *destination.Longitude, 1e-9)
+		assert.EqualValues(t, 2, destination.HotelCount)
+
+		_, err = destinationService.Find(ctx, "unknown")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("should list destinations by name with hotel counts", func(t *testing.T) {
+		page, err := destinationService.List(ctx, 1, 0)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, page.Total)
+		require.Len(t, page.Destinations, 1)
+		assert.Equal(t, "Singapore", page.Destinations[0].Name)
+
+		page, err = destinationService.List(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, page.Destinations, 1)
+		assert.Equal(t, "Tokyo", page.Destinations[0].Name)
+		assert.EqualValues(t, 1, page.Destinations[0].HotelCount)
+
+		page, err = destinationService.List(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.NotNil(t, page.Destinations)
+		assert.Empty(t, page.Destinations)
+	})
+
+	t.Run("should keep edited destinations when hotels are imported again", func(t *testing.T) {
+		countryCode, timezone := "jp", "Asia/Tokyo"
+		destination, err := destinationService.Upsert(ctx, sqlc.UpsertDestinationParams{
+			ID:          "1122",
+			Name:        " Shinjuku ",
+			CountryCode: &countryCode,
+			Timezone:    &timezone,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Shinjuku", destination.Name)
+		assert.Equal(t, "JP", *destination.CountryCode)
+
+		_, err = hotelDataService.Import(ctx, strings.NewReader(input))
+		require.NoError(t, err)
+		destination, err = destinationService.Find(ctx, "1122")
+		require.NoError(t, err)
+		assert.Equal(t, "Shinjuku", destination.Name)
+		assert.Equal(t, "Asia/Tokyo", *destination.Timezone)
+	})
+
+	t.Run("should reject blank names, unknown countries and timezones", func(t *testing.T) {
+		unknownCountry, unknownTimezone, local := "XX", "Asia/Atlantis", "Local"
+		for _, destination := range []sqlc.UpsertDestinationParams{
+			{ID: "1122", Name: "   "},
+			{ID: "1122", Name: "Tokyo", CountryCode: &unknownCountry},
+			{ID: "1122", Name: "Tokyo", Timezone: &unknownTimezone},
+			{ID: "1122", Name: "Tokyo", Timezone: &local},
+		} {
+			_, err := destinationService.Upsert(ctx, destination)
+			assert.ErrorIs(t, err, domains.ErrInvalidDestination)
+		}
+	})
+	t.Run("should bound queries with the configured deadline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
+		destinationService := services.NewDestinationService(logger, config.Default(), &config.DBStore{Queries: mockSqlcQuerier})
+
+		mockSqlcQuerier.EXPECT().FindDestination(gomock.Any(), "1122").
+			DoAndReturn(func(ctx context.Context, id string) (*sqlc.FindDestinationRow, error) {
+				deadline, ok := ctx.Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(config.Default().Database.QueryTimeout), deadline, time.Second)
+				return &sqlc.FindDestinationRow{ID: "1122"}, nil
+			})
+
+		_, err := destinationService.Find(ctx, "1122")
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDuplicateService(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	db := config.NewMemoryDBStore(memory.New())
+	changes := services.NewHotelChanges()
+	hotelDataService := services.NewHotelDataService(logger, db, changes)
+	hotelService := services.NewHotelServiceCache(services.NewHotelService(logger, config.Default(), db), time.Hour, 10)
+	changes.Subscribe(hotelService.Clear)
+	duplicateService := services.NewDuplicateService(logger, db, changes)
+
+	input := `{"hotel_id":"iJhz","destination_id":"5432","name":"Beach Villas Singapore","location":{"latitude":1.264751,"longitude":103.824006,"address":"8 Sentosa Gateway, Beach Villas"}}
+{"hotel_id":"SjyX","destination_id":"5432","name":"The Beach Villas","location":{"latitude":1.26475,"longitude":103.8241,"address":"8 Sentosa Gateway"}}
+{"hotel_id":"f8c9","destination_id":"5432","name":"InterContinental Robertson Quay","location":{"latitude":1.2893,"longitude":103.84,"address":"1 Nanson Road"}}
+{"hotel_id":"k3l4","destination_id":"1122","name":"Hilton Shinjuku Tokyo"}
+{"hotel_id":"m5n6","destination_id":"1122","name":"Hilton Tokyo Shinjuku"}
+`
+	_, err := hotelDataService.Import(ctx, strings.NewReader(input))
+	require.NoError(t, err)
+
+	result, err := duplicateService.Detect(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Candidates)
+	assert.Equal(t, [][]string{{"SjyX", "iJhz"}, {"k3l4", "m5n6"}}, result.Clusters)
+
+	pending, err := duplicateService.List(ctx, domains.DuplicatePending)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	byHotelID := map[string]int32{}
+	for _, duplicate := range pending {
+		byHotelID[duplicate.HotelID] = duplicate.ID
+	}
+
+	t.Run("should merge a confirmed pair into its canonical hotel", func(t *testing.T) {
+		hotels, err := hotelService.FindByDestinationID(ctx, "5432")
+		require.NoError(t, err)
+		require.Len(t, hotels, 3)
+
+		villas, beachVillas, plage := "ビーチヴィラ", "ザ・ビーチヴィラ", "Les Villas de la Plage"
+		for _, translation := range []sqlc.UpsertHotelTranslationParams{
+			{HotelID: "iJhz", Locale: "ja", Name: &villas, AmenityLabels: dto.AmenityLabels{}},
+			{HotelID: "SjyX", Locale: "ja", Name: &beachVillas, AmenityLabels: dto.AmenityLabels{}},
+			{HotelID: "SjyX", Locale: "fr", Name: &plage, AmenityLabels: dto.AmenityLabels{}},
+		} {
+			require.NoError(t, db.Queries.UpsertHotelTranslation(ctx, translation))
+		}
+
+		_, err = duplicateService.Confirm(ctx, byHotelID["SjyX"], "f8c9")
+		assert.ErrorIs(t, err, domains.ErrCanonicalHotel)
+
+		confirmed, err := duplicateService.Confirm(ctx, byHotelID["SjyX"], "iJhz")
+		require.NoError(t, err)
+		assert.Equal(t, domains.DuplicateConfirmed, confirmed.Status)
+		assert.Equal(t, "iJhz", *confirmed.CanonicalHotelID)
+
+		hotel, err := hotelService.FindByHotelID(ctx, "SjyX")
+		require.NoError(t, err)
+		assert.Equal(t, "iJhz", hotel.HotelID, "the merged hotel id should redirect")
+
+		hotels, err = hotelService.FindByDestinationID(ctx, "5432")
+		require.NoError(t, err)
+		assert.Len(t, hotels, 2, "the confirmation should clear the cached hotels")
+
+		translations, err := db.Queries.ListHotelTranslations(ctx, []string{"iJhz", "SjyX"})
+		require.NoError(t, err)
+		names := map[string]string{}
+		for _, translation := range translations {
+			assert.Equal(t, "iJhz", translation.HotelID, "the merged hotel should keep no translations")
+			names[translation.Locale] = *translation.Name
+		}
+		assert.Equal(t, map[string]string{"ja": villas, "fr": plage}, names, "only the missing locales should move to the canonical hotel")
+
+		_, err = duplicateService.Confirm(ctx, byHotelID["SjyX"], "iJhz")
+		assert.ErrorIs(t, err, domains.ErrDuplicateDecided)
+	})
+
+	t.Run("should keep a rejected pair out of later detections", func(t *testing.T) {
+		rejected, err := duplicateService.Reject(ctx, byHotelID["k3l4"])
+		require.NoError(t, err)
+		assert.Equal(t, domains.DuplicateRejected, rejected.Status)
+
+		result, err := duplicateService.Detect(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, result.Candidates)
+
+		pending, err := duplicateService.List(ctx, domains.DuplicatePending)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("should reject importing a merged hotel id", func(t *testing.T) {
+		result, err := hotelDataService.Import(ctx, strings.NewReader(`{"hotel_id":"SjyX","destination_id":"5432","name":"The Beach Villas"}`))
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "hotel_id", result.Errors[0].Field)
+		assert.Contains(t, result.Errors[0].Message, "iJhz")
+	})
+
+	t.Run("should report a missing candidate", func(t *testing.T) {
+		_, err := duplicateService.Reject(ctx, 999)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
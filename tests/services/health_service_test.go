@@ -0,0 +1,67 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestHealthService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
+	healthService := services.NewHealthService(logger, &config.Config{Readiness: config.ReadinessConfig{MaxSyncAge: time.Hour}}, &config.DBStore{
+		Queries:  mockSqlcQuerier,
+		ConnPool: nil,
+	}, lib.NewBuildInfo())
+
+	t.Run("should not be ready without a database connection", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockSqlcQuerier.EXPECT().GetLastSyncedAt(gomock.Any()).Return(pgtype.Timestamptz{Time: time.Now(), Valid: true}, nil).Times(1)
+
+		report := healthService.Readiness(ctx)
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, domains.HealthStatusFail, report.Status)
+		assert.Equal(t, "database", report.Checks[0].Name)
+		assert.Equal(t, domains.HealthStatusFail, report.Checks[0].Status)
+	})
+
+	t.Run("should fail last sync check when sync is too old", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockSqlcQuerier.EXPECT().GetLastSyncedAt(gomock.Any()).Return(pgtype.Timestamptz{Time: time.Now().Add(-2 * time.Hour), Valid: true}, nil).Times(1)
+
+		report := healthService.Status(ctx)
+
+		assert.Equal(t, "last_sync", report.Checks[2].Name)
+		assert.Equal(t, domains.HealthStatusFail, report.Checks[2].Status)
+		assert.NotNil(t, report.LastSyncedAt)
+		assert.Nil(t, report.Pool)
+	})
+
+	t.Run("should report draining once marked", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockSqlcQuerier.EXPECT().GetLastSyncedAt(gomock.Any()).Return(pgtype.Timestamptz{}, nil).Times(1)
+
+		healthService.MarkDraining()
+		report := healthService.Readiness(ctx)
+
+		assert.True(t, healthService.IsDraining())
+		assert.Equal(t, domains.HealthStatusDraining, report.Status)
+	})
+}
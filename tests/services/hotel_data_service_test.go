@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestHotelDataService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
+	hotelDataService := services.NewHotelDataService(logger, &config.DBStore{
+		Queries:  mockSqlcQuerier,
+		ConnPool: nil,
+	}, services.NewHotelChanges())
+
+	hotels := []*sqlc.Hotel{
+		{ID: 1, HotelID: "a", DestinationID: "1", Name: "Hotel A", Location: createMockLocation()},
+		{ID: 2, HotelID: "b", DestinationID: "1", Name: "Hotel B", Images: &dto.HotelImages{
+			Rooms: []dto.HotelImage{{Link: "rooms/1.jpg"}},
+		}},
+	}
+
+	t.Run("should export one hotel per line", func(t *testing.T) {
+		mockSqlcQuerier.EXPECT().ListHotels(gomock.Any()).Return(hotels, nil)
+
+		var out bytes.Buffer
+		count, err := hotelDataService.Export(context.Background(), &out)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var exported sqlc.UpsertHotelParams
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &exported))
+		assert.Equal(t, "a", exported.HotelID)
+		assert.NotContains(t, lines[0], `"created_at"`)
+	})
+
+	t.Run("should report stored hotels breaking the rules", func(t *testing.T) {
+		mockSqlcQuerier.EXPECT().ListHotels(gomock.Any()).Return(hotels, nil)
+
+		issues, err := hotelDataService.Validate(context.Background())
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "b", issues[0].HotelID)
+		assert.Equal(t, "images.rooms", issues[0].Field)
+	})
+
+	t.Run("should not write anything when a line is invalid", func(t *testing.T) {
+		input := `{"hotel_id":"a","destination_id":"1","name":"Hotel A"}
+{"hotel_id":"c","destination_id":"1"}
+`
+
+		result, err := hotelDataService.Import(context.Background(), strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Imported)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, 2, result.Errors[0].Line)
+		assert.Equal(t, "name", result.Errors[0].Field)
+	})
+}
+
+func TestParseHotels(t *testing.T) {
+	input := `{"hotel_id":"a","destination_id":"1","name":"Hotel A","location":{"latitude":91,"longitude":10}}
+
+{"id":7,"hotel_id":"b","destination_id":"1","name":"Hotel B","created_at":"2025-09-14T14:01:29Z","updated_at":null}
+{"hotel_id":"b","destination_id":"2","name":"Hotel B"}
+{"hotel_id":"c","destination_id":"1","name":"Hotel C","rating":5}
+{"hotel_id":"d","destination_id":"1","name":"Hotel D","country_code":"XX"}
+{"hotel_id":"e","destination_id":"1","name":"Hotel E","location":{"address":"6-6-2 Nishi-Shinjuku 160-0023","country":"jpn"}}
+`
+
+	hotels, issues, err := services.ParseHotels(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, hotels, 2)
+	assert.Equal(t, "b", hotels[0].HotelID)
+	assert.Equal(t, "e", hotels[1].HotelID)
+	assert.Equal(t, "JP", *hotels[1].CountryCode)
+	assert.Equal(t, "Japan", *hotels[1].Location.Country)
+	assert.Equal(t, "160-0023", *hotels[1].Location.PostalCode)
+	assert.Equal(t, dto.LocationMissing, hotels[1].DataQuality.Location.Status)
+
+	require.Len(t, issues, 4)
+	assert.Equal(t, 1, issues[0].Line)
+	assert.Equal(t, "location.latitude", issues[0].Field)
+	assert.Equal(t, 4, issues[1].Line)
+	assert.Equal(t, "duplicates line 3", issues[1].Message)
+	assert.Equal(t, 5, issues[2].Line)
+	assert.Equal(t, 6, issues[3].Line)
+	assert.Equal(t, "country_code", issues[3].Field)
+}
+
+func TestParseHotels_Sanitize(t *testing.T) {
+	input := `{"hotel_id":"a","destination_id":"1","name":"Hotel A","description":"<p onclick=\"x\">Sea  views</p><script>x</script>","booking_conditions":["Pets are not allowed."]}
+{"hotel_id":"b","destination_id":"1","name":"Hotel B","description":"<p>Sea views</p>","raw_text":{"description":"<div>Sea views</div>","booking_conditions":null}}
+`
+
+	hotels, issues, err := services.ParseHotels(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Empty(t, issues)
+	require.Len(t, hotels, 2)
+
+	assert.Equal(t, "<p>Sea views</p>", *hotels[0].Description)
+	assert.Equal(t, `<p onclick="x">Sea  views</p><script>x</script>`, *hotels[0].RawText.Description)
+	assert.Equal(t, []string{"Pets are not allowed."}, hotels[0].RawText.BookingConditions)
+	assert.False(t, *hotels[0].Policies.PetsAllowed)
+	assert.Equal(t, "<div>Sea views</div>", *hotels[1].RawText.Description, "exported raw text should be kept")
+}
+
+func TestHotelDataService_MemoryBackend(t *testing.T) {
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	db := config.NewMemoryDBStore(memory.New())
+	hotelDataService := services.NewHotelDataService(logger, db, services.NewHotelChanges())
+
+	input := `{"hotel_id":"a","destination_id":"1","name":"Hotel A"}
+{"hotel_id":"b","destination_id":"1","name":"Hotel B"}
+`
+	result, err := hotelDataService.Import(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Inserted)
+
+	result, err = hotelDataService.Import(context.Background(), strings.NewReader(`{"hotel_id":"a","destination_id":"2","name":"Hotel A"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Inserted)
+	assert.Equal(t, 1, result.Updated)
+
+	var out bytes.Buffer
+	count, err := hotelDataService.Export(context.Background(), &out)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Contains(t, out.String(), `"destination_id":"2"`)
+
+	report, err := hotelDataService.Quality(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Overall.Hotels)
+	assert.Equal(t, 5.0, report.Overall.AverageScore)
+	assert.Equal(t, 2, report.Overall.Missing["description"])
+	assert.Equal(t, 2, report.Overall.Locations[dto.LocationMissing])
+	assert.Equal(t, 1, report.Destinations["1"].Hotels)
+	assert.Equal(t, 1, report.Destinations["2"].Hotels)
+	assert.Equal(t, 2, report.Suppliers[domains.UnknownSupplier].Hotels)
+}
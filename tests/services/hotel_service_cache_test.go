@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHotelServiceCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve repeated lookups from the cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		cache := services.NewHotelServiceCache(next, time.Minute, 10)
+
+		hotels := []*sqlc.Hotel{{HotelID: "hotel_1", DestinationID: "dest_1"}}
+		next.EXPECT().FindByDestinationID(ctx, "dest_1").Return(hotels, nil).Times(1)
+
+		for range 3 {
+			result, err := cache.FindByDestinationID(ctx, "dest_1")
+			assert.NoError(t, err)
+			assert.Equal(t, hotels, result)
+		}
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		cache := services.NewHotelServiceCache(next, time.Minute, 10)
+
+		hotel := &sqlc.Hotel{HotelID: "hotel_1"}
+		gomock.InOrder(
+			next.EXPECT().FindByHotelID(ctx, "hotel_1").Return(nil, errors.New("connection refused")),
+			next.EXPECT().FindByHotelID(ctx, "hotel_1").Return(hotel, nil),
+		)
+
+		_, err := cache.FindByHotelID(ctx, "hotel_1")
+		assert.Error(t, err)
+
+		result, err := cache.FindByHotelID(ctx, "hotel_1")
+		assert.NoError(t, err)
+		assert.Equal(t, hotel, result)
+	})
+
+	t.Run("should expire entries after the updated TTL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		cache := services.NewHotelServiceCache(next, time.Minute, 10)
+		cache.SetTTL(time.Nanosecond)
+
+		next.EXPECT().FindByHotelIDs(ctx, []string{"hotel_1"}).Return([]*sqlc.Hotel{}, nil).Times(2)
+
+		_, _ = cache.FindByHotelIDs(ctx, []string{"hotel_1"})
+		time.Sleep(time.Millisecond)
+		_, _ = cache.FindByHotelIDs(ctx, []string{"hotel_1"})
+	})
+
+	t.Run("should evict entries beyond the maximum", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		cache := services.NewHotelServiceCache(next, time.Minute, 1)
+
+		next.EXPECT().FindByDestinationID(ctx, gomock.Any()).Return([]*sqlc.Hotel{}, nil).Times(3)
+
+		_, _ = cache.FindByDestinationID(ctx, "dest_1")
+		_, _ = cache.FindByDestinationID(ctx, "dest_2")
+		_, _ = cache.FindByDestinationID(ctx, "dest_1")
+	})
+	t.Run("should drop every entry when hotels change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		cache := services.NewHotelServiceCache(next, time.Minute, 10)
+		changes := services.NewHotelChanges()
+		changes.Subscribe(cache.Clear)
+
+		next.EXPECT().FindByDestinationID(ctx, "dest_1").Return([]*sqlc.Hotel{}, nil).Times(2)
+
+		_, _ = cache.FindByDestinationID(ctx, "dest_1")
+		changes.Notify()
+		_, _ = cache.FindByDestinationID(ctx, "dest_1")
+	})
+}
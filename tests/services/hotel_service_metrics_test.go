@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHotelServiceMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should observe latency and result size of successful calls", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		metrics := lib.NewMetrics()
+		service := services.NewHotelServiceMetrics(next, metrics)
+
+		hotels := []*sqlc.Hotel{{HotelID: "hotel_1"}, {HotelID: "hotel_2"}}
+		next.EXPECT().FindByDestinationID(ctx, "dest_1").Return(hotels, nil)
+
+		result, err := service.FindByDestinationID(ctx, "dest_1")
+		assert.NoError(t, err)
+		assert.Equal(t, hotels, result)
+
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.ServiceCallDuration))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.HotelsResultSize))
+		assert.Equal(t, 0, testutil.CollectAndCount(metrics.ServiceErrorsTotal))
+	})
+
+	t.Run("should count errors without observing a result size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := mocks.NewMockHotelService(ctrl)
+		metrics := lib.NewMetrics()
+		service := services.NewHotelServiceMetrics(next, metrics)
+
+		next.EXPECT().FindByHotelIDs(ctx, []string{"hotel_1"}).Return(nil, errors.New("connection refused")).Times(2)
+
+		for range 2 {
+			_, err := service.FindByHotelIDs(ctx, []string{"hotel_1"})
+			assert.Error(t, err)
+		}
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(metrics.ServiceErrorsTotal.WithLabelValues("hotel", "FindByHotelIDs")))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.ServiceCallDuration))
+		assert.Equal(t, 0, testutil.CollectAndCount(metrics.HotelsResultSize))
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 )
 
 func createMockLocation() *dto.HotelLocation {
@@ -37,9 +38,9 @@ func TestHotelService_FindByHotelID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	logger, _ := lib.NewLogger(&config.Config{LogLevel: "info"})
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
 	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
-	hotelService := services.NewHotelService(logger, &config.DBStore{
+	hotelService := services.NewHotelService(logger, config.Default(), &config.DBStore{
 		Queries:  mockSqlcQuerier,
 		ConnPool: nil,
 	})
@@ -65,7 +66,7 @@ func TestHotelService_FindByHotelID(t *testing.T) {
 
 		mockSqlcQuerier.
 			EXPECT().
-			FindHotelByHotelID(ctx, hotelID).
+			FindHotelByHotelID(gomock.Any(), hotelID).
 			Return(expectedHotel, nil).
 			Times(1)
 
@@ -78,12 +79,33 @@ func TestHotelService_FindByHotelID(t *testing.T) {
 		assert.Equal(t, result.Name, expectedHotel.Name)
 	})
 
+	t.Run("should return hotels with duplicate images removed", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockSqlcQuerier.EXPECT().FindHotelByHotelID(gomock.Any(), "hotel_125").Return(&sqlc.Hotel{
+			HotelID: "hotel_125",
+			Images: &dto.HotelImages{
+				Rooms: []dto.HotelImage{
+					{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room"},
+					{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room"},
+				},
+				Site: []dto.HotelImage{{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg#front", Description: "Front"}},
+			},
+		}, nil).Times(1)
+
+		result, err := hotelService.FindByHotelID(ctx, "hotel_125")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.HotelImage{{Link: "https://d2ey9sqrvkqdfs.cloudfront.net/0qZF/2.jpg", Description: "Double room"}}, result.Images.Rooms)
+		assert.Empty(t, result.Images.Site)
+	})
+
 	t.Run("should return error when hotel not found", func(t *testing.T) {
 		ctx := context.Background()
 
 		hotelID := "non_existent_hotel"
 
-		mockSqlcQuerier.EXPECT().FindHotelByHotelID(ctx, hotelID).Return(nil, pgx.ErrNoRows).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelByHotelID(gomock.Any(), hotelID).Return(nil, pgx.ErrNoRows).Times(1)
 
 		result, err := hotelService.FindByHotelID(ctx, hotelID)
 
@@ -97,13 +119,30 @@ func TestHotelService_FindByDestinationID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	logger, _ := lib.NewLogger(&config.Config{LogLevel: "info"})
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
 	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
-	hotelService := services.NewHotelService(logger, &config.DBStore{
+	hotelService := services.NewHotelService(logger, config.Default(), &config.DBStore{
 		Queries:  mockSqlcQuerier,
 		ConnPool: nil,
 	})
 
+	t.Run("should bound queries with the configured deadline", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(gomock.Any(), "dest_789").
+			DoAndReturn(func(ctx context.Context, destinationID string) ([]*sqlc.Hotel, error) {
+				deadline, ok := ctx.Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(config.Default().Database.QueryTimeout), deadline, time.Second)
+				return []*sqlc.Hotel{}, nil
+			}).
+			Times(1)
+
+		_, err := hotelService.FindByDestinationID(ctx, "dest_789")
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("should return hotels when found", func(t *testing.T) {
 		ctx := context.Background()
 		destinationID := "dest_456"
@@ -113,7 +152,7 @@ func TestHotelService_FindByDestinationID(t *testing.T) {
 			{ID: 2, HotelID: "hotel_124", DestinationID: destinationID, Name: "Test Hotel 2", Location: createMockLocation(), Description: nil, Images: nil, Amenities: nil, BookingConditions: []string{"No pets"}, CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}, UpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
 		}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(ctx, destinationID).Return(expectedHotels, nil).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(gomock.Any(), destinationID).Return(expectedHotels, nil).Times(1)
 
 		result, err := hotelService.FindByDestinationID(ctx, destinationID)
 
@@ -129,7 +168,7 @@ func TestHotelService_FindByDestinationID(t *testing.T) {
 
 		expectedHotels := []*sqlc.Hotel{}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(ctx, destinationID).Return(expectedHotels, nil).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(gomock.Any(), destinationID).Return(expectedHotels, nil).Times(1)
 
 		result, err := hotelService.FindByDestinationID(ctx, destinationID)
 
@@ -141,7 +180,7 @@ func TestHotelService_FindByDestinationID(t *testing.T) {
 		ctx := context.Background()
 		destinationID := "error_dest"
 
-		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(ctx, destinationID).Return(nil, pgx.ErrTxClosed).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByDestinationID(gomock.Any(), destinationID).Return(nil, pgx.ErrTxClosed).Times(1)
 
 		result, err := hotelService.FindByDestinationID(ctx, destinationID)
 
@@ -154,9 +193,9 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	logger, _ := lib.NewLogger(&config.Config{LogLevel: "info"})
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
 	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
-	hotelService := services.NewHotelService(logger, &config.DBStore{
+	hotelService := services.NewHotelService(logger, config.Default(), &config.DBStore{
 		Queries:  mockSqlcQuerier,
 		ConnPool: nil,
 	})
@@ -171,7 +210,7 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 			{ID: 2, HotelID: "hotel_124", DestinationID: destinationID, Name: "Test Hotel 2", Location: createMockLocation(), Description: nil, Images: nil, Amenities: nil, BookingConditions: []string{"No pets"}, CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}, UpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
 		}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(ctx, hotelIDs).Return(expectedHotels, nil).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), hotelIDs).Return(expectedHotels, nil).Times(1)
 
 		result, err := hotelService.FindByHotelIDs(ctx, hotelIDs)
 
@@ -185,7 +224,7 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 		ctx := context.Background()
 		hotelIDs := []string{"non_existent_hotel"}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(ctx, hotelIDs).Return([]*sqlc.Hotel{}, nil).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), hotelIDs).Return([]*sqlc.Hotel{}, nil).Times(1)
 
 		result, err := hotelService.FindByHotelIDs(ctx, hotelIDs)
 
@@ -202,7 +241,7 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 			{ID: 1, HotelID: "hotel_123", DestinationID: destinationID, Name: "Test Hotel 1", Location: createMockLocation(), Description: nil, Images: nil, Amenities: nil, BookingConditions: []string{"No smoking"}, CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}, UpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
 		}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(ctx, hotelIDs).Return(expectedHotels, nil).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), hotelIDs).Return(expectedHotels, nil).Times(1)
 
 		result, err := hotelService.FindByHotelIDs(ctx, hotelIDs)
 
@@ -216,7 +255,7 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 		ctx := context.Background()
 		hotelIDs := []string{"hotel_123", "hotel_124"}
 
-		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(ctx, hotelIDs).Return(nil, pgx.ErrTxClosed).Times(1)
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), hotelIDs).Return(nil, pgx.ErrTxClosed).Times(1)
 
 		result, err := hotelService.FindByHotelIDs(ctx, hotelIDs)
 
@@ -224,3 +263,63 @@ func TestHotelService_FindByHotelIDs(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func TestHotelService_FindByCountryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
+	hotelService := services.NewHotelService(logger, config.Default(), &config.DBStore{
+		Queries:  mockSqlcQuerier,
+		ConnPool: nil,
+	})
+
+	t.Run("should return hotels when found", func(t *testing.T) {
+		countryCode := "SG"
+		mockSqlcQuerier.EXPECT().FindHotelsByCountryCode(gomock.Any(), "SG").Return([]*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_123", CountryCode: &countryCode},
+		}, nil).Times(1)
+
+		result, err := hotelService.FindByCountryCode(context.Background(), "SG")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("should return empty list when no hotels found", func(t *testing.T) {
+		mockSqlcQuerier.EXPECT().FindHotelsByCountryCode(gomock.Any(), "JP").Return(nil, nil).Times(1)
+
+		result, err := hotelService.FindByCountryCode(context.Background(), "JP")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("should code the country of hotels stored before countries were coded", func(t *testing.T) {
+		country := "sg"
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), []string{"hotel_126"}).Return([]*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_126", Location: &dto.HotelLocation{Country: &country}},
+		}, nil).Times(1)
+
+		result, err := hotelService.FindByHotelIDs(context.Background(), []string{"hotel_126"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SG", *result[0].CountryCode)
+		assert.Equal(t, "Singapore", *result[0].Location.Country)
+	})
+
+	t.Run("should check the location of hotels stored before locations were checked", func(t *testing.T) {
+		latitude, longitude, country := 103.824006, 1.264751, "SG"
+		mockSqlcQuerier.EXPECT().FindHotelsByHotelIDs(gomock.Any(), []string{"hotel_127"}).Return([]*sqlc.Hotel{
+			{ID: 1, HotelID: "hotel_127", Location: &dto.HotelLocation{Latitude: &latitude, Longitude: &longitude, Country: &country}},
+		}, nil).Times(1)
+
+		result, err := hotelService.FindByHotelIDs(context.Background(), []string{"hotel_127"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.LocationRepaired, result[0].DataQuality.Location.Status)
+		assert.Equal(t, 1.264751, *result[0].Location.Latitude)
+	})
+}
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIngestService(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	db := config.NewMemoryDBStore(memory.New())
+	changes := services.NewHotelChanges()
+
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+		return "file://" + path
+	}
+
+	cfg := config.Default()
+	cfg.Ingest.AcmeURL = write("acme.json", `[
+  {"Id": "iJhz", "DestinationId": 5432, "Name": "Beach Villas Singapore", "Country": "SG"},
+  {"Id": "f8c9", "DestinationId": 1122, "Name": "Hilton Shinjuku Tokyo", "Country": "JP"}
+]`)
+	cfg.Ingest.PatagoniaURL = write("patagonia.json", `[{"id": "iJhz", "destination": 5432, "name": "Beach Villas Singapore"}]`)
+	cfg.Ingest.PaperfliesURL = write("paperflies.json", `[]`)
+	ingestService := services.NewIngestService(logger, cfg, db, changes)
+
+	_, err := services.NewHotelDataService(logger, db, changes).Import(ctx, strings.NewReader(`{"hotel_id":"imported","destination_id":"5432","name":"Imported Hotel"}`+"\n"))
+	require.NoError(t, err)
+
+	t.Run("should refuse to run without every supplier", func(t *testing.T) {
+		partial := *cfg
+		partial.Ingest.PaperfliesURL = ""
+
+		_, err := services.NewIngestService(logger, &partial, db, changes).Ingest(ctx)
+
+		assert.ErrorContains(t, err, "ingest.paperflies_url")
+	})
+
+	t.Run("should upsert supplier hotels and keep the other hotels", func(t *testing.T) {
+		result, err := ingestService.Ingest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Stored)
+		assert.Zero(t, result.Removed)
+
+		count, err := db.Queries.CountHotels(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+	})
+
+	t.Run("should keep hotel ids and remove supplier hotels no supplier returns anymore", func(t *testing.T) {
+		before, err := db.Queries.FindHotelByHotelID(ctx, "iJhz")
+		require.NoError(t, err)
+
+		write("acme.json", `[{"Id": "iJhz", "DestinationId": 5432, "Name": "Beach Villas Singapore", "Country": "SG"}]`)
+		result, err := ingestService.Ingest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Stored)
+		assert.Equal(t, 1, result.Removed)
+
+		after, err := db.Queries.FindHotelByHotelID(ctx, "iJhz")
+		require.NoError(t, err)
+		assert.Equal(t, before.ID, after.ID)
+
+		_, err = db.Queries.FindHotelByHotelID(ctx, "f8c9")
+		assert.Error(t, err)
+		_, err = db.Queries.FindHotelByHotelID(ctx, "imported")
+		assert.NoError(t, err, "hotels without suppliers should be kept")
+	})
+}
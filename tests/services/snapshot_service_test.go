@@ -0,0 +1,229 @@
+package services_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/mocks"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestSnapshotService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockSqlcQuerier := mocks.NewMockQuerier(ctrl)
+	mockMigrationService := mocks.NewMockMigrationService(ctrl)
+	snapshotService := services.NewSnapshotService(logger, &config.DBStore{
+		Queries:  mockSqlcQuerier,
+		ConnPool: nil,
+	}, mockMigrationService, services.NewHotelChanges())
+
+	executedAt := time.Date(2025, 9, 14, 14, 1, 29, 0, time.UTC)
+	status := &domains.MigrationStatus{
+		CurrentVersion: "20250914140129",
+		LatestVersion:  "20250914140129",
+		Migrations: []domains.MigrationInfo{
+			{Version: "20250914140129", Description: "init", State: domains.MigrationStateApplied, ExecutedAt: &executedAt},
+		},
+	}
+	ctx := context.Background()
+	timestamp := pgtype.Timestamptz{Time: executedAt, Valid: true}
+	source := config.NewMemoryDBStore(memory.New())
+	for _, hotel := range []sqlc.RestoreHotelParams{
+		{ID: 3, HotelID: "a", DestinationID: "1", Name: "Hotel A", Location: createMockLocation(), CreatedAt: timestamp, UpdatedAt: timestamp},
+		{ID: 7, HotelID: "b", DestinationID: "1", Name: "Hotel B", CreatedAt: timestamp, UpdatedAt: timestamp},
+	} {
+		require.NoError(t, source.Queries.RestoreHotel(ctx, hotel))
+	}
+	require.NoError(t, source.Queries.RestoreDestination(ctx, sqlc.RestoreDestinationParams{ID: "1", Name: "Singapore", CreatedAt: timestamp, UpdatedAt: timestamp}))
+	canonicalHotelID := "a"
+	require.NoError(t, source.Queries.RestoreHotelDuplicate(ctx, sqlc.RestoreHotelDuplicateParams{ID: 1, HotelID: "a", DuplicateHotelID: "c", DestinationID: "1",
+		Status: domains.DuplicateConfirmed, CanonicalHotelID: &canonicalHotelID, CreatedAt: timestamp, UpdatedAt: timestamp}))
+	require.NoError(t, source.Queries.RestoreHotelRedirect(ctx, sqlc.RestoreHotelRedirectParams{HotelID: "c", CanonicalHotelID: "a", CreatedAt: timestamp}))
+	sourceSnapshotService := services.NewSnapshotService(logger, source, mockMigrationService, services.NewHotelChanges())
+
+	t.Run("should read the tables in a read only transaction", func(t *testing.T) {
+		mockMigrationService.EXPECT().Status(gomock.Any()).Return(status, nil)
+
+		_, err := snapshotService.Export(context.Background(), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "connection pool is not configured")
+	})
+
+	export := func(t *testing.T) []byte {
+		mockMigrationService.EXPECT().Status(gomock.Any()).Return(status, nil)
+
+		var archive bytes.Buffer
+		manifest, err := sourceSnapshotService.Export(context.Background(), &archive)
+		require.NoError(t, err)
+		assert.Equal(t, domains.SnapshotFormatVersion, manifest.FormatVersion)
+		assert.Equal(t, "20250914140129", manifest.SchemaVersion)
+		records := map[string]int{}
+		for _, file := range manifest.Files {
+			records[file.Name] = file.Records
+		}
+		assert.Equal(t, map[string]int{
+			"hotels.ndjson":       2,
+			"revisions.ndjson":    1,
+			"destinations.ndjson": 1,
+			"duplicates.ndjson":   1,
+			"redirects.ndjson":    1,
+			"translations.ndjson": 0,
+		}, records)
+
+		return archive.Bytes()
+	}
+
+	t.Run("should verify the archive and the database before restoring", func(t *testing.T) {
+		archive := export(t)
+		mockMigrationService.EXPECT().Status(gomock.Any()).Return(status, nil)
+		mockSqlcQuerier.EXPECT().CountHotels(gomock.Any()).Return(int64(0), nil)
+		mockSqlcQuerier.EXPECT().CountDestinations(gomock.Any()).Return(int64(0), nil)
+
+		_, err := snapshotService.Restore(context.Background(), bytes.NewReader(archive))
+		assert.ErrorContains(t, err, "connection pool is not configured")
+	})
+
+	t.Run("should refuse a database at another schema version", func(t *testing.T) {
+		archive := export(t)
+		mockMigrationService.EXPECT().Status(gomock.Any()).Return(&domains.MigrationStatus{CurrentVersion: "20990101000000"}, nil)
+
+		_, err := snapshotService.Restore(context.Background(), bytes.NewReader(archive))
+		assert.ErrorIs(t, err, domains.ErrSnapshotMismatch)
+	})
+
+	t.Run("should refuse a database with hotels", func(t *testing.T) {
+		archive := export(t)
+		mockMigrationService.EXPECT().Status(gomock.Any()).Return(status, nil)
+		mockSqlcQuerier.EXPECT().CountHotels(gomock.Any()).Return(int64(1), nil)
+
+		_, err := snapshotService.Restore(context.Background(), bytes.NewReader(archive))
+		assert.ErrorIs(t, err, domains.ErrSnapshotMismatch)
+	})
+
+	t.Run("should detect a tampered file", func(t *testing.T) {
+		for _, name := range []string{"hotels.ndjson", "duplicates.ndjson", "redirects.ndjson", "destinations.ndjson"} {
+			archive := tamper(t, export(t), name)
+
+			_, err := snapshotService.Restore(context.Background(), bytes.NewReader(archive))
+			assert.ErrorIs(t, err, domains.ErrSnapshotCorrupted)
+			assert.ErrorContains(t, err, "checksum mismatch for "+name)
+		}
+	})
+
+	t.Run("should reject data that is not an archive", func(t *testing.T) {
+		_, err := snapshotService.Restore(context.Background(), bytes.NewReader([]byte("not a snapshot")))
+		assert.ErrorIs(t, err, domains.ErrSnapshotCorrupted)
+	})
+}
+
+func TestSnapshotService_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	mockMigrationService := mocks.NewMockMigrationService(ctrl)
+	mockMigrationService.EXPECT().Status(gomock.Any()).Return(&domains.MigrationStatus{CurrentVersion: "20261019160000"}, nil).AnyTimes()
+
+	source := config.NewMemoryDBStore(memory.New())
+	_, err := services.NewHotelDataService(logger, source, services.NewHotelChanges()).Import(ctx, strings.NewReader(
+		`{"hotel_id":"iJhz","destination_id":"5432","name":"Beach Villas Singapore","location":{"city":"Singapore","country":"SG"}}
+{"hotel_id":"SjyX","destination_id":"5432","name":"The Beach Villas"}
+{"hotel_id":"f8c9","destination_id":"5432","name":"InterContinental Robertson Quay"}
+`))
+	require.NoError(t, err)
+	require.NoError(t, source.Queries.UpsertHotelDuplicate(ctx, sqlc.UpsertHotelDuplicateParams{HotelID: "SjyX", DuplicateHotelID: "iJhz", DestinationID: "5432", Score: 0.9}))
+	require.NoError(t, source.Queries.UpsertHotelDuplicate(ctx, sqlc.UpsertHotelDuplicateParams{HotelID: "f8c9", DuplicateHotelID: "iJhz", DestinationID: "5432", Score: 0.6}))
+	pending, err := source.Queries.ListHotelDuplicates(ctx, domains.DuplicatePending)
+	require.NoError(t, err)
+	canonicalHotelID := "iJhz"
+	require.NoError(t, source.Queries.DecideHotelDuplicate(ctx, sqlc.DecideHotelDuplicateParams{ID: pending[0].ID, Status: domains.DuplicateConfirmed, CanonicalHotelID: &canonicalHotelID}))
+	require.NoError(t, source.Queries.UpsertHotelRedirect(ctx, sqlc.UpsertHotelRedirectParams{HotelID: "SjyX", CanonicalHotelID: "iJhz"}))
+	name, timezone := "ビーチヴィラ", "Asia/Singapore"
+	require.NoError(t, source.Queries.UpsertHotelTranslation(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "iJhz", Locale: "ja", Name: &name, AmenityLabels: dto.AmenityLabels{}}))
+	require.NoError(t, source.Queries.UpsertDestination(ctx, sqlc.UpsertDestinationParams{ID: "5432", Name: "Singapore", Timezone: &timezone}))
+
+	var archive bytes.Buffer
+	_, err = services.NewSnapshotService(logger, source, mockMigrationService, services.NewHotelChanges()).Export(ctx, &archive)
+	require.NoError(t, err)
+
+	target := config.NewMemoryDBStore(memory.New())
+	_, err = services.NewSnapshotService(logger, target, mockMigrationService, services.NewHotelChanges()).Restore(ctx, &archive)
+	require.NoError(t, err)
+
+	list := func(db *config.DBStore) string {
+		hotels, err := db.Queries.ListHotels(ctx)
+		require.NoError(t, err)
+		destinations, err := db.Queries.ListDestinations(ctx, sqlc.ListDestinationsParams{Limit: 10})
+		require.NoError(t, err)
+		confirmed, err := db.Queries.ListHotelDuplicates(ctx, domains.DuplicateConfirmed)
+		require.NoError(t, err)
+		pending, err := db.Queries.ListHotelDuplicates(ctx, domains.DuplicatePending)
+		require.NoError(t, err)
+		redirects, err := db.Queries.ListHotelRedirects(ctx)
+		require.NoError(t, err)
+		translations, err := db.Queries.ListAllHotelTranslations(ctx)
+		require.NoError(t, err)
+		for _, table := range []int{len(hotels), len(destinations), len(confirmed), len(pending), len(redirects), len(translations)} {
+			require.NotZero(t, table)
+		}
+
+		// Timestamps are compared as JSON, as decoding them loses their location.
+		tables, err := json.Marshal([]any{hotels, destinations, confirmed, pending, redirects, translations})
+		require.NoError(t, err)
+		return string(tables)
+	}
+	assert.JSONEq(t, list(source), list(target))
+}
+
+// tamper rewrites the archive with a byte of name flipped.
+func tamper(t *testing.T, archive []byte, name string) []byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	reader := tar.NewReader(gz)
+
+	var out bytes.Buffer
+	outGz := gzip.NewWriter(&out)
+	writer := tar.NewWriter(outGz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		if header.Name == name {
+			content[0] ^= 0xff
+		}
+
+		require.NoError(t, writer.WriteHeader(header))
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, outGz.Close())
+
+	return out.Bytes()
+}
@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/duylamasd/hotels-merge/config"
+	"github.com/duylamasd/hotels-merge/db/memory"
+	"github.com/duylamasd/hotels-merge/domains"
+	"github.com/duylamasd/hotels-merge/lib"
+	"github.com/duylamasd/hotels-merge/services"
+	"github.com/duylamasd/hotels-merge/services/i18n"
+	"github.com/duylamasd/hotels-merge/sqlc"
+	"github.com/duylamasd/hotels-merge/sqlc/dto"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTranslationService(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := lib.NewLogger(&config.Config{}, zap.NewAtomicLevel())
+	db := config.NewMemoryDBStore(memory.New())
+	hotelDataService := services.NewHotelDataService(logger, db, services.NewHotelChanges())
+	hotelService := services.NewHotelService(logger, config.Default(), db)
+	translationService := services.NewTranslationService(logger, db)
+
+	input := `{"hotel_id":"iJhz","destination_id":"5432","name":"Beach Villas Singapore","description":"Surrounded by tropical gardens.","amenities":{"general":["swimming pool","wifi"]},"booking_conditions":["Pets are not allowed."]}
+{"hotel_id":"f8c9","destination_id":"5432","name":"InterContinental Robertson Quay"}
+`
+	_, err := hotelDataService.Import(ctx, strings.NewReader(input))
+	require.NoError(t, err)
+
+	name := " ビーチヴィラ シンガポール "
+	description := "<p>熱帯の庭園に囲まれています。</p><script>alert(1)</script>"
+	translation, err := translationService.Upsert(ctx, sqlc.UpsertHotelTranslationParams{
+		HotelID:           "iJhz",
+		Locale:            "JA",
+		Name:              &name,
+		Description:       &description,
+		AmenityLabels:     dto.AmenityLabels{"pool": "プール"},
+		BookingConditions: []string{"ペット不可。"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ja", translation.Locale, "the locale should be canonical")
+	assert.Equal(t, "ビーチヴィラ シンガポール", *translation.Name)
+	assert.Equal(t, "<p>熱帯の庭園に囲まれています。</p>", *translation.Description)
+
+	t.Run("should serve the first locale of the chain with a translation", func(t *testing.T) {
+		hotels, err := hotelService.FindByDestinationID(ctx, "5432")
+		require.NoError(t, err)
+		require.Len(t, hotels, 2)
+
+		localized, locales, err := translationService.Localize(ctx, hotels, i18n.Chain("", "fr, ja-JP;q=0.8"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ja", "en"}, locales)
+		assert.Equal(t, "ビーチヴィラ シンガポール", localized[0].Name)
+		assert.Equal(t, []string{"ペット不可。"}, localized[0].BookingConditions)
+		labels := map[string]string{}
+		for _, amenity := range localized[0].Amenities.Canonical {
+			labels[amenity.Code] = amenity.Label
+		}
+		assert.Equal(t, map[string]string{"pool": "プール", "wifi": "Wi-Fi"}, labels)
+		assert.Equal(t, "InterContinental Robertson Quay", localized[1].Name)
+		assert.Equal(t, "Beach Villas Singapore", hotels[0].Name, "the found hotel should not be changed")
+	})
+
+	t.Run("should serve the default locale without translations", func(t *testing.T) {
+		hotels, err := hotelService.FindByDestinationID(ctx, "5432")
+		require.NoError(t, err)
+
+		localized, locales, err := translationService.Localize(ctx, hotels, i18n.Chain("de", ""))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"en"}, locales)
+		assert.Equal(t, hotels, localized)
+	})
+
+	t.Run("should reject invalid locales and unknown amenities", func(t *testing.T) {
+		_, err := translationService.Upsert(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "iJhz", Locale: "en"})
+		assert.ErrorIs(t, err, domains.ErrInvalidLocale)
+
+		_, err = translationService.Upsert(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "iJhz", Locale: "12345"})
+		assert.ErrorIs(t, err, domains.ErrInvalidLocale)
+
+		_, err = translationService.Upsert(ctx, sqlc.UpsertHotelTranslationParams{
+			HotelID:       "iJhz",
+			Locale:        "fr",
+			AmenityLabels: dto.AmenityLabels{"moat": "Douves"},
+		})
+		assert.ErrorIs(t, err, domains.ErrUnknownAmenity)
+
+		_, err = translationService.Upsert(ctx, sqlc.UpsertHotelTranslationParams{HotelID: "unknown", Locale: "fr"})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("should list and delete translations", func(t *testing.T) {
+		translations, err := translationService.List(ctx, "iJhz")
+		require.NoError(t, err)
+		require.Len(t, translations, 1)
+		assert.Equal(t, "ja", translations[0].Locale)
+
+		assert.ErrorIs(t, translationService.Delete(ctx, "iJhz", "fr"), pgx.ErrNoRows)
+		require.NoError(t, translationService.Delete(ctx, "iJhz", "ja"))
+
+		translations, err = translationService.List(ctx, "iJhz")
+		require.NoError(t, err)
+		assert.Empty(t, translations)
+	})
+}